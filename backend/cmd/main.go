@@ -1,30 +1,38 @@
 package main
 
 import (
-	"log"
+	"log/slog"
+	"os"
 
 	"github.com/erizkiatama/bluehorntech/config"
 	"github.com/erizkiatama/bluehorntech/internal/app"
+	"github.com/erizkiatama/bluehorntech/internal/logging"
 )
 
 func main() {
-	log.Println("Starting server...")
+	slog.Info("Starting server...")
 
 	if err := config.Load(); err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		slog.Error("Failed to load config", "error", err)
+		os.Exit(1)
 	}
 	cfg := config.Get()
 
+	// everything logged from here on, including through the standard log package, is structured
+	slog.SetDefault(logging.New(cfg.Log, cfg.Server.Env, os.Stdout))
+
 	application, err := app.New(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize application: %v", err)
+		slog.Error("Failed to initialize application", "error", err)
+		os.Exit(1)
 	}
 	defer func() {
 		_ = application.Close()
 	}()
 
-	log.Printf("Server starting on %s:%s", cfg.Server.Host, cfg.Server.Port)
+	slog.Info("Server starting", "host", cfg.Server.Host, "port", cfg.Server.Port)
 	if err := application.Run(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
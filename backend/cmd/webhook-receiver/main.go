@@ -0,0 +1,77 @@
+// Command webhook-receiver is a local webhook endpoint for trying out subscriptions.
+// It verifies each delivery's signature and prints the event, e.g.
+//
+//	go run ./cmd/webhook-receiver -addr :9000 -secret <subscription secret>
+//
+// and subscribe http://localhost:9000/webhook (or http://host.docker.internal:9000/webhook from docker).
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/service/webhook"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "address to listen on")
+	secret := flag.String("secret", "", "subscription secret the signatures are checked with")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "oldest delivery timestamp accepted")
+	failEvery := flag.Int("fail-every", 0, "respond 500 to every nth delivery to exercise retries, 0 to never fail")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("-secret is required")
+	}
+
+	var received int
+	http.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		timestamp := r.Header.Get(webhook.HeaderTimestamp)
+		if !webhook.Verify(*secret, timestamp, body, r.Header.Get(webhook.HeaderSignature)) {
+			log.Printf("Rejected delivery %s: bad signature", r.Header.Get(webhook.HeaderDelivery))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || time.Since(time.Unix(sentAt, 0)) > *tolerance {
+			log.Printf("Rejected delivery %s: stale timestamp %q", r.Header.Get(webhook.HeaderDelivery), timestamp)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		received++
+		if *failEvery > 0 && received%*failEvery == 0 {
+			log.Printf("Failing delivery %s on purpose", r.Header.Get(webhook.HeaderDelivery))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var pretty bytes.Buffer
+		if err = json.Indent(&pretty, body, "", "  "); err != nil {
+			pretty.Write(body)
+		}
+		log.Printf("Event %s (%s), delivery %s:\n%s", r.Header.Get(webhook.HeaderEventID),
+			r.Header.Get(webhook.HeaderEvent), r.Header.Get(webhook.HeaderDelivery), pretty.String())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook receiver listening on %s/webhook", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
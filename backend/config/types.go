@@ -3,15 +3,29 @@ package config
 import "time"
 
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Service  ServiceConfig  `yaml:"service"`
+	Server       ServerConfig       `yaml:"server"`
+	Log          LogConfig          `yaml:"log"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Service      ServiceConfig      `yaml:"service"`
+	Geocoding    GeocodingConfig    `yaml:"geocoding"`
+	Storage      StorageConfig      `yaml:"storage"`
+	Webhook      WebhookConfig      `yaml:"webhook"`
+	Stream       StreamConfig       `yaml:"stream"`
+	Notification NotificationConfig `yaml:"notification"`
+	Escalation   EscalationConfig   `yaml:"escalation"`
 }
 
 type ServerConfig struct {
-	Host string `yaml:"host"`
-	Port string `yaml:"port"`
-	Env  string `yaml:"env"`
+	Host          string `yaml:"host"`
+	Port          string `yaml:"port"`
+	Env           string `yaml:"env"`
+	DefaultLocale string `yaml:"defaultLocale"`
+}
+
+type LogConfig struct {
+	Level             string `yaml:"level"`
+	Format            string `yaml:"format"`
+	RedactCoordinates bool   `yaml:"redactCoordinates"`
 }
 
 type DatabaseConfig struct {
@@ -21,9 +35,155 @@ type DatabaseConfig struct {
 }
 
 type ServiceConfig struct {
-	MaxDistanceError        float64       `yaml:"maxDistanceError"`
-	MaxDistanceWarning      float64       `yaml:"maxDistanceWarning"`
-	MaxEarlyClockInSeconds  time.Duration `yaml:"maxEarlyClockInSeconds"`
-	MaxLateClockInSeconds   time.Duration `yaml:"maxLateClockInSeconds"`
-	MinVisitDurationSeconds time.Duration `yaml:"minVisitDurationSeconds"`
+	MaxDistanceError           float64       `yaml:"maxDistanceError"`
+	MaxDistanceWarning         float64       `yaml:"maxDistanceWarning"`
+	MaxEarlyClockInSeconds     time.Duration `yaml:"maxEarlyClockInSeconds"`
+	MaxLateClockInSeconds      time.Duration `yaml:"maxLateClockInSeconds"`
+	ClockInAnchor              string        `yaml:"clockInAnchor"`
+	ClockInEnforcement         string        `yaml:"clockInEnforcement"`
+	MinVisitDurationSeconds    time.Duration `yaml:"minVisitDurationSeconds"`
+	MinVisitDurationAction     string        `yaml:"minVisitDurationAction"`
+	MaxVisitDurationSeconds    time.Duration `yaml:"maxVisitDurationSeconds"`
+	MaxVisitDurationAction     string        `yaml:"maxVisitDurationAction"`
+	MaxDurationVarianceSeconds time.Duration `yaml:"maxDurationVarianceSeconds"`
+	DurationVarianceAction     string        `yaml:"durationVarianceAction"`
+	MaxDailyVisitSeconds       time.Duration `yaml:"maxDailyVisitSeconds"`
+	AverageTravelSpeedKmh      float64       `yaml:"averageTravelSpeedKmh"`
+	AgencyTimezone             string        `yaml:"agencyTimezone"`
+	MatchDistanceRadiusKm      float64       `yaml:"matchDistanceRadiusKm"`
+	CredentialEnforcement      string        `yaml:"credentialEnforcement"`
+	RoutingProvider            string        `yaml:"routingProvider"`
+	PayPeriodDays              int           `yaml:"payPeriodDays"`
+	PayPeriodAnchor            string        `yaml:"payPeriodAnchor"`
+	MileageRatePerMile         float64       `yaml:"mileageRatePerMile"`
+	AttestationPolicy          string        `yaml:"attestationPolicy"`
+	MaxSignatureBytes          int           `yaml:"maxSignatureBytes"`
+	NoteEditWindowSeconds      time.Duration `yaml:"noteEditWindowSeconds"`
+	RequiredTaskPolicy         string        `yaml:"requiredTaskPolicy"`
+	TaskCorrectionSeconds      time.Duration `yaml:"taskCorrectionSeconds"`
+	ComplianceNotesLocale      string        `yaml:"complianceNotesLocale"`
+
+	// ClockInWindows overrides the clock-in window per service name (matched case-insensitively)
+	ClockInWindows map[string]ClockInWindowConfig `yaml:"clockInWindows"`
+	// VisitDurations overrides the visit duration policy per service name (matched case-insensitively)
+	VisitDurations map[string]VisitDurationConfig `yaml:"visitDurations"`
+}
+
+// ClockInWindowConfig overrides the agency clock-in window for one service; unset fields keep the agency value
+type ClockInWindowConfig struct {
+	Anchor                 string         `yaml:"anchor"`
+	MaxEarlyClockInSeconds *time.Duration `yaml:"maxEarlyClockInSeconds"`
+	MaxLateClockInSeconds  *time.Duration `yaml:"maxLateClockInSeconds"`
+	Enforcement            string         `yaml:"enforcement"`
+}
+
+// VisitDurationConfig overrides the agency visit duration policy for one service; unset fields keep the agency value
+type VisitDurationConfig struct {
+	MinVisitDurationSeconds    *time.Duration `yaml:"minVisitDurationSeconds"`
+	MinVisitDurationAction     string         `yaml:"minVisitDurationAction"`
+	MaxVisitDurationSeconds    *time.Duration `yaml:"maxVisitDurationSeconds"`
+	MaxVisitDurationAction     string         `yaml:"maxVisitDurationAction"`
+	MaxDurationVarianceSeconds *time.Duration `yaml:"maxDurationVarianceSeconds"`
+	DurationVarianceAction     string         `yaml:"durationVarianceAction"`
+}
+
+type GeocodingConfig struct {
+	Provider       string        `yaml:"provider"`
+	URL            string        `yaml:"url"`
+	APIKey         string        `yaml:"apiKey"`
+	AddressField   string        `yaml:"addressField"`
+	UserAgent      string        `yaml:"userAgent"`
+	TimeoutSeconds time.Duration `yaml:"timeoutSeconds"`
+	CachePrecision int           `yaml:"cachePrecision"`
+}
+
+type StorageConfig struct {
+	Provider         string        `yaml:"provider"`
+	LocalDir         string        `yaml:"localDir"`
+	PublicBaseURL    string        `yaml:"publicBaseURL"`
+	SigningKey       string        `yaml:"signingKey"`
+	URLExpirySeconds time.Duration `yaml:"urlExpirySeconds"`
+	MaxUploadBytes   int64         `yaml:"maxUploadBytes"`
+	AllowedMimeTypes []string      `yaml:"allowedMimeTypes"`
+	S3Endpoint       string        `yaml:"s3Endpoint"`
+	S3Region         string        `yaml:"s3Region"`
+	S3Bucket         string        `yaml:"s3Bucket"`
+	S3AccessKey      string        `yaml:"s3AccessKey"`
+	S3SecretKey      string        `yaml:"s3SecretKey"`
+	TimeoutSeconds   time.Duration `yaml:"timeoutSeconds"`
+}
+
+type WebhookConfig struct {
+	Enabled             bool          `yaml:"enabled"`
+	PollIntervalSeconds time.Duration `yaml:"pollIntervalSeconds"`
+	BatchSize           int           `yaml:"batchSize"`
+	MaxAttempts         int           `yaml:"maxAttempts"`
+	RetryBaseSeconds    time.Duration `yaml:"retryBaseSeconds"`
+	RetryMaxSeconds     time.Duration `yaml:"retryMaxSeconds"`
+	TimeoutSeconds      time.Duration `yaml:"timeoutSeconds"`
+}
+
+type StreamConfig struct {
+	HeartbeatSeconds time.Duration `yaml:"heartbeatSeconds"`
+	BufferSize       int           `yaml:"bufferSize"`
+	ReplayLimit      int           `yaml:"replayLimit"`
+}
+
+type NotificationConfig struct {
+	Enabled                 bool          `yaml:"enabled"`
+	PollIntervalSeconds     time.Duration `yaml:"pollIntervalSeconds"`
+	BatchSize               int           `yaml:"batchSize"`
+	MaxAttempts             int           `yaml:"maxAttempts"`
+	RetryBaseSeconds        time.Duration `yaml:"retryBaseSeconds"`
+	RetryMaxSeconds         time.Duration `yaml:"retryMaxSeconds"`
+	VisitReminderSeconds    time.Duration `yaml:"visitReminderSeconds"`
+	ClockOutReminderSeconds time.Duration `yaml:"clockOutReminderSeconds"`
+	Push                    PushConfig    `yaml:"push"`
+	SMS                     SMSConfig     `yaml:"sms"`
+	Email                   EmailConfig   `yaml:"email"`
+}
+
+type PushConfig struct {
+	Provider       string        `yaml:"provider"`
+	URL            string        `yaml:"url"`
+	ServerKey      string        `yaml:"serverKey"`
+	TimeoutSeconds time.Duration `yaml:"timeoutSeconds"`
+}
+
+type SMSConfig struct {
+	Provider       string        `yaml:"provider"`
+	URL            string        `yaml:"url"`
+	APIKey         string        `yaml:"apiKey"`
+	From           string        `yaml:"from"`
+	TimeoutSeconds time.Duration `yaml:"timeoutSeconds"`
+}
+
+type EmailConfig struct {
+	Provider       string        `yaml:"provider"`
+	Host           string        `yaml:"host"`
+	Port           int           `yaml:"port"`
+	Username       string        `yaml:"username"`
+	Password       string        `yaml:"password"`
+	From           string        `yaml:"from"`
+	TimeoutSeconds time.Duration `yaml:"timeoutSeconds"`
+}
+
+type EscalationConfig struct {
+	Enabled             bool                              `yaml:"enabled"`
+	PollIntervalSeconds time.Duration                     `yaml:"pollIntervalSeconds"`
+	DefaultRiskLevel    string                            `yaml:"defaultRiskLevel"`
+	OnCallUserID        int64                             `yaml:"onCallUserId"`
+	BackupUserID        int64                             `yaml:"backupUserId"`
+	AdminUserID         int64                             `yaml:"adminUserId"`
+	Policies            map[string]EscalationPolicyConfig `yaml:"policies"`
+}
+
+// EscalationPolicyConfig lists the steps of a risk level's escalation, each due some time after the visit's start
+type EscalationPolicyConfig struct {
+	Steps []EscalationStepConfig `yaml:"steps"`
+}
+
+type EscalationStepConfig struct {
+	Contact      string        `yaml:"contact"`
+	AfterSeconds time.Duration `yaml:"afterSeconds"`
 }
@@ -1,11 +1,18 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/events"
+	"github.com/erizkiatama/bluehorntech/internal/monitoring"
 	"github.com/erizkiatama/bluehorntech/pkg/database"
+	"github.com/erizkiatama/bluehorntech/pkg/geocoding"
+	"github.com/erizkiatama/bluehorntech/pkg/notify"
+	"github.com/erizkiatama/bluehorntech/pkg/routing"
+	"github.com/erizkiatama/bluehorntech/pkg/storage"
 	"github.com/gin-gonic/gin"
 
 	_scheduleHandler "github.com/erizkiatama/bluehorntech/internal/handler/schedule"
@@ -15,6 +22,61 @@ import (
 	_taskHandler "github.com/erizkiatama/bluehorntech/internal/handler/task"
 	_taskRepo "github.com/erizkiatama/bluehorntech/internal/repository/task"
 	_taskService "github.com/erizkiatama/bluehorntech/internal/service/task"
+
+	_availabilityHandler "github.com/erizkiatama/bluehorntech/internal/handler/availability"
+	_availabilityRepo "github.com/erizkiatama/bluehorntech/internal/repository/availability"
+	_availabilityService "github.com/erizkiatama/bluehorntech/internal/service/availability"
+
+	_matchingHandler "github.com/erizkiatama/bluehorntech/internal/handler/matching"
+	_skillRepo "github.com/erizkiatama/bluehorntech/internal/repository/skill"
+	_userRepo "github.com/erizkiatama/bluehorntech/internal/repository/user"
+	_matchingService "github.com/erizkiatama/bluehorntech/internal/service/matching"
+
+	_credentialHandler "github.com/erizkiatama/bluehorntech/internal/handler/credential"
+	_credentialRepo "github.com/erizkiatama/bluehorntech/internal/repository/credential"
+	_credentialService "github.com/erizkiatama/bluehorntech/internal/service/credential"
+
+	_itineraryHandler "github.com/erizkiatama/bluehorntech/internal/handler/itinerary"
+	_itineraryService "github.com/erizkiatama/bluehorntech/internal/service/itinerary"
+
+	_noteHandler "github.com/erizkiatama/bluehorntech/internal/handler/note"
+	_noteRepo "github.com/erizkiatama/bluehorntech/internal/repository/note"
+	_noteService "github.com/erizkiatama/bluehorntech/internal/service/note"
+
+	_attachmentHandler "github.com/erizkiatama/bluehorntech/internal/handler/attachment"
+	_attachmentRepo "github.com/erizkiatama/bluehorntech/internal/repository/attachment"
+	_attachmentService "github.com/erizkiatama/bluehorntech/internal/service/attachment"
+
+	_userHandler "github.com/erizkiatama/bluehorntech/internal/handler/user"
+	_userService "github.com/erizkiatama/bluehorntech/internal/service/user"
+
+	_reviewHandler "github.com/erizkiatama/bluehorntech/internal/handler/review"
+	_reviewRepo "github.com/erizkiatama/bluehorntech/internal/repository/review"
+	_reviewService "github.com/erizkiatama/bluehorntech/internal/service/review"
+
+	_reportHandler "github.com/erizkiatama/bluehorntech/internal/handler/report"
+	_reportRepo "github.com/erizkiatama/bluehorntech/internal/repository/report"
+	_reportService "github.com/erizkiatama/bluehorntech/internal/service/report"
+
+	_streamHandler "github.com/erizkiatama/bluehorntech/internal/handler/stream"
+	_outboxRepo "github.com/erizkiatama/bluehorntech/internal/repository/outbox"
+	_streamService "github.com/erizkiatama/bluehorntech/internal/service/stream"
+
+	_notificationHandler "github.com/erizkiatama/bluehorntech/internal/handler/notification"
+	_notificationRepo "github.com/erizkiatama/bluehorntech/internal/repository/notification"
+	_notificationService "github.com/erizkiatama/bluehorntech/internal/service/notification"
+
+	_escalationHandler "github.com/erizkiatama/bluehorntech/internal/handler/escalation"
+	_escalationRepo "github.com/erizkiatama/bluehorntech/internal/repository/escalation"
+	_escalationService "github.com/erizkiatama/bluehorntech/internal/service/escalation"
+
+	_webhookHandler "github.com/erizkiatama/bluehorntech/internal/handler/webhook"
+	_webhookRepo "github.com/erizkiatama/bluehorntech/internal/repository/webhook"
+	_webhookService "github.com/erizkiatama/bluehorntech/internal/service/webhook"
+
+	_attestationRepo "github.com/erizkiatama/bluehorntech/internal/repository/attestation"
+	_geocodeRepo "github.com/erizkiatama/bluehorntech/internal/repository/geocode"
+	_conflictChecker "github.com/erizkiatama/bluehorntech/internal/service/conflict"
 )
 
 type App struct {
@@ -22,43 +84,159 @@ type App struct {
 	DB       *sqlx.DB
 	Router   *gin.Engine
 	Handlers *Handlers
+	Metrics  *monitoring.Metrics
+
+	dispatcher     *_webhookService.Dispatcher
+	listener       *events.Listener
+	scheduler      *_notificationService.Scheduler
+	escalator      *_escalationService.Escalator
+	stopBackground context.CancelFunc
 }
 
 type Handlers struct {
-	Schedule *_scheduleHandler.Handler
-	Task     *_taskHandler.Handler
+	Schedule     *_scheduleHandler.Handler
+	Task         *_taskHandler.Handler
+	Availability *_availabilityHandler.Handler
+	Matching     *_matchingHandler.Handler
+	Credential   *_credentialHandler.Handler
+	Itinerary    *_itineraryHandler.Handler
+	Note         *_noteHandler.Handler
+	Attachment   *_attachmentHandler.Handler
+	User         *_userHandler.Handler
+	Review       *_reviewHandler.Handler
+	Report       *_reportHandler.Handler
+	Webhook      *_webhookHandler.Handler
+	Stream       *_streamHandler.Handler
+	Notification *_notificationHandler.Handler
+	Escalation   *_escalationHandler.Handler
 }
 
 func New(cfg *config.Config) (*App, error) {
+	routeProvider, err := routing.New(cfg.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	geocodeProvider, err := geocoding.NewProvider(cfg.Geocoding)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := notify.NewChannels(cfg.Notification)
+	if err != nil {
+		return nil, err
+	}
+
 	db := database.New(cfg.Database)
+	appMetrics := monitoring.New(db)
 
 	scheduleRepo := _scheduleRepo.New(db)
 	taskRepo := _taskRepo.New(db)
+	availabilityRepo := _availabilityRepo.New(db)
+	userRepo := _userRepo.New(db)
+	skillRepo := _skillRepo.New(db)
+	credentialRepo := _credentialRepo.New(db)
+	geocodeRepo := _geocodeRepo.New(db)
+	attestationRepo := _attestationRepo.New(db)
+	noteRepo := _noteRepo.New(db)
+	attachmentRepo := _attachmentRepo.New(db)
+	reviewRepo := _reviewRepo.New(db)
+	reportRepo := _reportRepo.New(db)
+	webhookRepo := _webhookRepo.New(db)
+	outboxRepo := _outboxRepo.New(db)
+	notificationRepo := _notificationRepo.New(db)
+	escalationRepo := _escalationRepo.New(db)
+
+	conflictChecker := _conflictChecker.New(cfg.Service, scheduleRepo, availabilityRepo, routeProvider)
+	geocoder := geocoding.NewResolver(cfg.Geocoding, geocodeProvider, geocodeRepo)
+	eventBus := events.NewBus(cfg.Stream.BufferSize)
 
-	scheduleSvc := _scheduleService.New(cfg.Service, scheduleRepo, taskRepo)
-	taskSvc := _taskService.New(taskRepo, scheduleRepo)
+	notificationSvc := _notificationService.New(notificationRepo, userRepo)
+	scheduleSvc := _scheduleService.New(cfg.Service, scheduleRepo, taskRepo, conflictChecker, credentialRepo, geocoder, attestationRepo, notificationSvc, appMetrics)
+	taskSvc := _taskService.New(cfg.Service, taskRepo, scheduleRepo, userRepo, appMetrics)
+	availabilitySvc := _availabilityService.New(availabilityRepo)
+	matchingSvc := _matchingService.New(cfg.Service, scheduleRepo, userRepo, skillRepo, conflictChecker)
+	credentialSvc := _credentialService.New(credentialRepo)
+	itinerarySvc := _itineraryService.New(cfg.Service, scheduleRepo, routeProvider)
+	noteSvc := _noteService.New(cfg.Service, noteRepo, scheduleRepo)
+	attachmentSvc := _attachmentService.New(cfg.Storage, store, attachmentRepo, scheduleRepo, taskRepo)
+	userSvc := _userService.New(userRepo)
+	reviewSvc := _reviewService.New(cfg.Service, reviewRepo, userRepo)
+	reportSvc := _reportService.New(cfg.Service, reportRepo)
+	webhookSvc := _webhookService.New(webhookRepo)
+	streamSvc := _streamService.New(cfg.Stream, eventBus, outboxRepo)
+	escalationSvc := _escalationService.New(cfg.Escalation, escalationRepo, scheduleRepo)
 
 	handlers := &Handlers{
-		Schedule: _scheduleHandler.New(scheduleSvc),
-		Task:     _taskHandler.New(taskSvc),
+		Schedule:     _scheduleHandler.New(scheduleSvc),
+		Task:         _taskHandler.New(taskSvc),
+		Availability: _availabilityHandler.New(availabilitySvc),
+		Matching:     _matchingHandler.New(matchingSvc),
+		Credential:   _credentialHandler.New(credentialSvc),
+		Itinerary:    _itineraryHandler.New(itinerarySvc),
+		Note:         _noteHandler.New(noteSvc),
+		Attachment:   _attachmentHandler.New(attachmentSvc),
+		User:         _userHandler.New(userSvc),
+		Review:       _reviewHandler.New(reviewSvc),
+		Report:       _reportHandler.New(reportSvc),
+		Webhook:      _webhookHandler.New(webhookSvc),
+		Stream:       _streamHandler.New(streamSvc),
+		Notification: _notificationHandler.New(notificationSvc),
+		Escalation:   _escalationHandler.New(escalationSvc),
 	}
 
 	// Setup router
-	router := setupRouter(cfg, handlers)
+	router := setupRouter(cfg, handlers, userRepo, appMetrics)
 
-	return &App{
+	app := &App{
 		Config:   cfg,
 		DB:       db,
 		Router:   router,
 		Handlers: handlers,
-	}, nil
+		Metrics:  appMetrics,
+		listener: events.NewListener(cfg.Database.URL, outboxRepo, eventBus),
+	}
+	if cfg.Webhook.Enabled {
+		app.dispatcher = _webhookService.NewDispatcher(cfg.Webhook, webhookRepo, nil)
+	}
+	if cfg.Notification.Enabled {
+		app.scheduler = _notificationService.NewScheduler(cfg.Notification, cfg.Service.AgencyTimezone,
+			cfg.Server.DefaultLocale, notificationRepo, channels)
+	}
+	if cfg.Escalation.Enabled {
+		app.escalator = _escalationService.NewEscalator(cfg.Escalation, cfg.Service.AgencyTimezone,
+			cfg.Server.DefaultLocale, escalationRepo, userRepo, notificationRepo, channels)
+	}
+
+	return app, nil
 }
 
 func (a *App) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopBackground = cancel
+	go a.listener.Run(ctx)
+	if a.dispatcher != nil {
+		go a.dispatcher.Run(ctx)
+	}
+	if a.scheduler != nil {
+		go a.scheduler.Run(ctx)
+	}
+	if a.escalator != nil {
+		go a.escalator.Run(ctx)
+	}
+
 	address := fmt.Sprintf("%s:%s", a.Config.Server.Host, a.Config.Server.Port)
 	return a.Router.Run(address)
 }
 
 func (a *App) Close() error {
+	if a.stopBackground != nil {
+		a.stopBackground()
+	}
 	return a.DB.Close()
 }
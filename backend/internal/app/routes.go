@@ -6,25 +6,31 @@ import (
 
 	"github.com/erizkiatama/bluehorntech/config"
 	"github.com/erizkiatama/bluehorntech/internal/middleware"
+	"github.com/erizkiatama/bluehorntech/internal/monitoring"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
 	"github.com/gin-gonic/gin"
 )
 
 // setupRouter configures Gin router with all routes and middleware
-func setupRouter(cfg *config.Config, handlers *Handlers) *gin.Engine {
+func setupRouter(cfg *config.Config, handlers *Handlers, userRepo user.Repository, appMetrics *monitoring.Metrics) *gin.Engine {
 	// Set Gin mode
 	if cfg.Server.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
 
 	// Global middleware
-	router.Use(middleware.UseCORS())
+	router.Use(middleware.UseRequestID())
 	router.Use(middleware.UseLogger())
+	router.Use(middleware.UseMetrics(appMetrics))
+	router.Use(middleware.UseRecovery())
+	router.Use(middleware.UseCORS())
 
 	// Setup route groups
 	setupHealthRoutes(router)
-	setupAPIV1Routes(router, handlers)
+	setupMetricsRoutes(router, appMetrics)
+	setupAPIV1Routes(router, handlers, middleware.UseLocale(cfg.Server.DefaultLocale, userRepo))
 
 	return router
 }
@@ -39,11 +45,29 @@ func setupHealthRoutes(router *gin.Engine) {
 	})
 }
 
+// setupMetricsRoutes exposes the metrics for Prometheus to scrape
+func setupMetricsRoutes(router *gin.Engine, appMetrics *monitoring.Metrics) {
+	router.GET("/metrics", gin.WrapH(appMetrics.Registry.Handler()))
+}
+
 // setupAPIV1Routes configures all v1 API routes
-func setupAPIV1Routes(router *gin.Engine, handlers *Handlers) {
-	apiV1 := router.Group("/api/v1")
+func setupAPIV1Routes(router *gin.Engine, handlers *Handlers, locale gin.HandlerFunc) {
+	apiV1 := router.Group("/api/v1", locale)
 	{
 		v1.RegisterScheduleRoutes(apiV1, handlers.Schedule)
 		v1.RegisterTaskRoutes(apiV1, handlers.Task)
+		v1.RegisterAvailabilityRoutes(apiV1, handlers.Availability)
+		v1.RegisterMatchingRoutes(apiV1, handlers.Matching)
+		v1.RegisterCredentialRoutes(apiV1, handlers.Credential)
+		v1.RegisterItineraryRoutes(apiV1, handlers.Itinerary)
+		v1.RegisterNoteRoutes(apiV1, handlers.Note)
+		v1.RegisterAttachmentRoutes(apiV1, handlers.Attachment)
+		v1.RegisterUserRoutes(apiV1, handlers.User)
+		v1.RegisterReviewRoutes(apiV1, handlers.Review)
+		v1.RegisterReportRoutes(apiV1, handlers.Report)
+		v1.RegisterWebhookRoutes(apiV1, handlers.Webhook)
+		v1.RegisterStreamRoutes(apiV1, handlers.Stream)
+		v1.RegisterNotificationRoutes(apiV1, handlers.Notification)
+		v1.RegisterEscalationRoutes(apiV1, handlers.Escalation)
 	}
 }
@@ -0,0 +1,23 @@
+package v1
+
+import (
+	attachmentHandler "github.com/erizkiatama/bluehorntech/internal/handler/attachment"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAttachmentRoutes registers visit attachment routes and the signed download route of the local store
+func RegisterAttachmentRoutes(router *gin.RouterGroup, attachmentHandler *attachmentHandler.Handler) {
+	schedules := router.Group("/schedules")
+	{
+		schedules.GET("/:id/attachments", attachmentHandler.GetAttachments)
+		schedules.POST("/:id/attachments", attachmentHandler.Upload)
+	}
+
+	attachments := router.Group("/attachments")
+	{
+		attachments.GET("/:id", attachmentHandler.GetAttachment)
+		attachments.DELETE("/:id", attachmentHandler.DeleteAttachment)
+	}
+
+	router.GET("/files/*key", attachmentHandler.Download)
+}
@@ -0,0 +1,24 @@
+package v1
+
+import (
+	availabilityHandler "github.com/erizkiatama/bluehorntech/internal/handler/availability"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAvailabilityRoutes registers availability and time off routes
+func RegisterAvailabilityRoutes(router *gin.RouterGroup, availabilityHandler *availabilityHandler.Handler) {
+	users := router.Group("/users")
+	{
+		users.GET("/:id/availability", availabilityHandler.GetAvailability)
+		users.PUT("/:id/availability", availabilityHandler.SetAvailability)
+
+		users.GET("/:id/time-off", availabilityHandler.GetTimeOffs)
+		users.POST("/:id/time-off", availabilityHandler.RequestTimeOff)
+	}
+
+	timeOff := router.Group("/time-off")
+	{
+		timeOff.POST("/:id/approve", availabilityHandler.ApproveTimeOff)
+		timeOff.POST("/:id/reject", availabilityHandler.RejectTimeOff)
+	}
+}
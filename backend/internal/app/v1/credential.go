@@ -0,0 +1,29 @@
+package v1
+
+import (
+	credentialHandler "github.com/erizkiatama/bluehorntech/internal/handler/credential"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCredentialRoutes registers credential tracking routes
+func RegisterCredentialRoutes(router *gin.RouterGroup, credentialHandler *credentialHandler.Handler) {
+	credentials := router.Group("/credentials")
+	{
+		credentials.GET("/types", credentialHandler.GetTypes)
+		credentials.POST("/types", credentialHandler.CreateType)
+		credentials.GET("/expiring", credentialHandler.GetExpiring)
+	}
+
+	users := router.Group("/users")
+	{
+		users.GET("/:id/credentials", credentialHandler.GetUserCredentials)
+		users.POST("/:id/credentials", credentialHandler.AddUserCredential)
+		users.DELETE("/:id/credentials/:credentialId", credentialHandler.RemoveUserCredential)
+	}
+
+	services := router.Group("/services")
+	{
+		services.GET("/:name/credentials", credentialHandler.GetRequirements)
+		services.PUT("/:name/credentials", credentialHandler.SetRequirements)
+	}
+}
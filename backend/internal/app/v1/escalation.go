@@ -0,0 +1,18 @@
+package v1
+
+import (
+	escalationHandler "github.com/erizkiatama/bluehorntech/internal/handler/escalation"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterEscalationRoutes registers late visit alert and client risk level routes
+func RegisterEscalationRoutes(router *gin.RouterGroup, escalationHandler *escalationHandler.Handler) {
+	router.GET("/schedules/:id/alerts", escalationHandler.GetScheduleAlerts)
+	router.POST("/alerts/:id/acknowledge", escalationHandler.AcknowledgeAlert)
+
+	riskLevels := router.Group("/client-risk-levels")
+	{
+		riskLevels.GET("", escalationHandler.GetRiskLevels)
+		riskLevels.PUT("", escalationHandler.SetRiskLevel)
+	}
+}
@@ -0,0 +1,15 @@
+package v1
+
+import (
+	itineraryHandler "github.com/erizkiatama/bluehorntech/internal/handler/itinerary"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterItineraryRoutes registers route planning and mileage routes
+func RegisterItineraryRoutes(router *gin.RouterGroup, itineraryHandler *itineraryHandler.Handler) {
+	users := router.Group("/users")
+	{
+		users.GET("/:id/itinerary", itineraryHandler.GetItinerary)
+		users.GET("/:id/mileage", itineraryHandler.GetMileageReport)
+	}
+}
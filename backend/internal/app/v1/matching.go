@@ -0,0 +1,23 @@
+package v1
+
+import (
+	matchingHandler "github.com/erizkiatama/bluehorntech/internal/handler/matching"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMatchingRoutes registers caregiver matching and skill routes
+func RegisterMatchingRoutes(router *gin.RouterGroup, matchingHandler *matchingHandler.Handler) {
+	router.GET("/schedules/:id/matches", matchingHandler.GetMatches)
+
+	users := router.Group("/users")
+	{
+		users.GET("/:id/skills", matchingHandler.GetUserSkills)
+		users.PUT("/:id/skills", matchingHandler.SetUserSkills)
+	}
+
+	services := router.Group("/services")
+	{
+		services.GET("/:name/requirements", matchingHandler.GetServiceRequirements)
+		services.PUT("/:name/requirements", matchingHandler.SetServiceRequirements)
+	}
+}
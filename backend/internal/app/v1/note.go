@@ -0,0 +1,20 @@
+package v1
+
+import (
+	noteHandler "github.com/erizkiatama/bluehorntech/internal/handler/note"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterNoteRoutes registers visit note routes
+func RegisterNoteRoutes(router *gin.RouterGroup, noteHandler *noteHandler.Handler) {
+	schedules := router.Group("/schedules")
+	{
+		schedules.GET("/:id/notes", noteHandler.GetNotes)
+		schedules.POST("/:id/notes", noteHandler.AddNote)
+	}
+
+	notes := router.Group("/notes")
+	{
+		notes.PATCH("/:id", noteHandler.UpdateNote)
+	}
+}
@@ -0,0 +1,15 @@
+package v1
+
+import (
+	notificationHandler "github.com/erizkiatama/bluehorntech/internal/handler/notification"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterNotificationRoutes registers caregiver notification preference routes
+func RegisterNotificationRoutes(router *gin.RouterGroup, notificationHandler *notificationHandler.Handler) {
+	users := router.Group("/users")
+	{
+		users.GET("/:id/notification-preferences", notificationHandler.GetPreferences)
+		users.PUT("/:id/notification-preferences", notificationHandler.UpdatePreferences)
+	}
+}
@@ -0,0 +1,14 @@
+package v1
+
+import (
+	reportHandler "github.com/erizkiatama/bluehorntech/internal/handler/report"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReportRoutes registers the compliance reporting routes
+func RegisterReportRoutes(router *gin.RouterGroup, reportHandler *reportHandler.Handler) {
+	reports := router.Group("/reports")
+	{
+		reports.GET("/compliance", reportHandler.GetComplianceReport)
+	}
+}
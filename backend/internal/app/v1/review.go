@@ -0,0 +1,18 @@
+package v1
+
+import (
+	reviewHandler "github.com/erizkiatama/bluehorntech/internal/handler/review"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReviewRoutes registers the supervisor exception review routes
+func RegisterReviewRoutes(router *gin.RouterGroup, reviewHandler *reviewHandler.Handler) {
+	router.GET("/exceptions", reviewHandler.GetExceptions)
+
+	schedules := router.Group("/schedules")
+	{
+		schedules.POST("/:id/exceptions/:flag/acknowledge", reviewHandler.AcknowledgeException)
+		schedules.POST("/:id/exceptions/:flag/approve", reviewHandler.ApproveException)
+		schedules.POST("/:id/exceptions/:flag/reject", reviewHandler.RejectException)
+	}
+}
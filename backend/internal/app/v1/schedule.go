@@ -12,6 +12,8 @@ func RegisterScheduleRoutes(router *gin.RouterGroup, scheduleHandler *scheduleHa
 		schedules.GET("/today", scheduleHandler.GetTodaySchedules)
 		schedules.GET("", scheduleHandler.GetAllSchedules)
 		schedules.GET("/:id", scheduleHandler.GetScheduleDetails)
+		schedules.POST("", scheduleHandler.CreateSchedule)
+		schedules.PATCH("/:id/assign", scheduleHandler.AssignSchedule)
 
 		schedules.POST("/:id/start", scheduleHandler.ClockIn)
 		schedules.POST("/:id/end", scheduleHandler.ClockOut)
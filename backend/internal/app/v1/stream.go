@@ -0,0 +1,14 @@
+package v1
+
+import (
+	streamHandler "github.com/erizkiatama/bluehorntech/internal/handler/stream"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterStreamRoutes registers the real-time visit event stream for coordinators
+func RegisterStreamRoutes(router *gin.RouterGroup, streamHandler *streamHandler.Handler) {
+	stream := router.Group("/stream")
+	{
+		stream.GET("/visits", streamHandler.StreamVisits)
+	}
+}
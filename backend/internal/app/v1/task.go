@@ -10,5 +10,19 @@ func RegisterTaskRoutes(router *gin.RouterGroup, taskHandler *taskHandler.Handle
 	tasks := router.Group("/tasks")
 	{
 		tasks.PATCH("/:id", taskHandler.UpdateTask)
+		tasks.GET("/:id/history", taskHandler.GetHistory)
+		tasks.POST("/:id/corrections", taskHandler.RequestCorrection)
+	}
+
+	corrections := router.Group("/task-corrections")
+	{
+		corrections.GET("", taskHandler.GetCorrections)
+		corrections.POST("/:id/approve", taskHandler.ApproveCorrection)
+		corrections.POST("/:id/reject", taskHandler.RejectCorrection)
+	}
+
+	schedules := router.Group("/schedules")
+	{
+		schedules.PATCH("/:id/tasks", taskHandler.UpdateTasks)
 	}
 }
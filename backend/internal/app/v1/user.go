@@ -0,0 +1,14 @@
+package v1
+
+import (
+	userHandler "github.com/erizkiatama/bluehorntech/internal/handler/user"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterUserRoutes registers user preference routes
+func RegisterUserRoutes(router *gin.RouterGroup, userHandler *userHandler.Handler) {
+	users := router.Group("/users")
+	{
+		users.PUT("/:id/locale", userHandler.UpdateLocale)
+	}
+}
@@ -0,0 +1,21 @@
+package v1
+
+import (
+	webhookHandler "github.com/erizkiatama/bluehorntech/internal/handler/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterWebhookRoutes registers webhook subscription and dead letter routes
+func RegisterWebhookRoutes(router *gin.RouterGroup, webhookHandler *webhookHandler.Handler) {
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.GET("", webhookHandler.GetWebhooks)
+		webhooks.POST("", webhookHandler.CreateWebhook)
+		webhooks.GET("/dead-letters", webhookHandler.GetDeadLetters)
+		webhooks.POST("/dead-letters/:id/retry", webhookHandler.RetryDeadLetter)
+		webhooks.GET("/:id", webhookHandler.GetWebhook)
+		webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhooks.POST("/:id/ping", webhookHandler.PingWebhook)
+	}
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+)
+
+const defaultBufferSize = 64
+
+// Bus fans events out to the subscribers connected to this replica
+type Bus struct {
+	mu         sync.Mutex
+	subs       map[*Subscription]struct{}
+	bufferSize int
+}
+
+func NewBus(bufferSize int) *Bus {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Bus{subs: make(map[*Subscription]struct{}), bufferSize: bufferSize}
+}
+
+// Subscription receives the bus's events until it is closed. Its channel is also closed when the subscriber
+// falls a full buffer behind, so that a slow client reconnects and replays instead of silently missing events.
+type Subscription struct {
+	bus    *Bus
+	events chan models.StreamEvent
+}
+
+func (b *Bus) Subscribe() *Subscription {
+	s := &Subscription{bus: b, events: make(chan models.StreamEvent, b.bufferSize)}
+
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	return s
+}
+
+// Publish hands the event to every subscriber without waiting on any of them
+func (b *Bus) Publish(event models.StreamEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		select {
+		case s.events <- event:
+		default:
+			b.remove(s)
+		}
+	}
+}
+
+func (s *Subscription) Events() <-chan models.StreamEvent {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.remove(s)
+}
+
+// remove must be called with mu held
+func (b *Bus) remove(s *Subscription) {
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.events)
+	}
+}
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/repository/outbox"
+	"github.com/lib/pq"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	listenerPingInterval = 90 * time.Second
+	catchUpBatchSize     = 100
+)
+
+// Listener publishes committed outbox events to the bus. Every replica listens to the same Postgres channel,
+// so all of them broadcast every event, whichever replica wrote it.
+type Listener struct {
+	dbURL      string
+	outboxRepo outbox.Repository
+	bus        *Bus
+	lastID     int64
+}
+
+func NewListener(dbURL string, outboxRepo outbox.Repository, bus *Bus) *Listener {
+	return &Listener{dbURL: dbURL, outboxRepo: outboxRepo, bus: bus}
+}
+
+// Run listens until ctx is cancelled, catching up on events committed while the connection was down
+func (l *Listener) Run(ctx context.Context) {
+	listener := pq.NewListener(l.dbURL, minReconnectInterval, maxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.WarnContext(ctx, "Outbox listener connection event", "event", int(ev), "error", err)
+		}
+	})
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	if err := listener.Listen(outbox.Channel); err != nil {
+		slog.ErrorContext(ctx, "Failed to listen for outbox events", "error", err)
+		return
+	}
+
+	lastID, err := l.outboxRepo.GetLatestEventID(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get latest outbox event", "error", err)
+	}
+	l.lastID = lastID
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			// a nil notification means the connection was re-established and notifications may have been lost
+			if n == nil {
+				l.catchUp(ctx)
+				continue
+			}
+			l.publish(ctx, n.Extra)
+		case <-time.After(listenerPingInterval):
+			if err = listener.Ping(); err != nil {
+				slog.WarnContext(ctx, "Outbox listener ping failed", "error", err)
+			}
+		}
+	}
+}
+
+func (l *Listener) publish(ctx context.Context, payload string) {
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		slog.WarnContext(ctx, "Ignoring outbox notification", "payload", payload, "error", err)
+		return
+	}
+
+	event, err := l.outboxRepo.GetEvent(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load outbox event", "event_id", id, "error", err)
+		return
+	}
+
+	l.bus.Publish(event.ToStreamEvent())
+	if id > l.lastID {
+		l.lastID = id
+	}
+}
+
+func (l *Listener) catchUp(ctx context.Context) {
+	for {
+		events, err := l.outboxRepo.GetEventsAfter(ctx, l.lastID, catchUpBatchSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to catch up on outbox events", "after_event_id", l.lastID, "error", err)
+			return
+		}
+
+		for i := range events {
+			l.bus.Publish(events[i].ToStreamEvent())
+			l.lastID = events[i].ID
+		}
+		if len(events) < catchUpBatchSize {
+			return
+		}
+	}
+}
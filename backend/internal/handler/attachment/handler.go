@@ -0,0 +1,133 @@
+package attachment
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/attachment"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded user id because there is no auth yet, will implement later
+var defaultUserID int64 = 1
+
+type Handler struct {
+	svc attachment.Service
+}
+
+func New(svc attachment.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetAttachments(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	resp, err := h.svc.GetAttachments(c.Request.Context(), defaultUserID, int64(scheduleID))
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgAttachmentsRetrieved, resp)
+}
+
+func (h *Handler) GetAttachment(c *gin.Context) {
+	attachmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || attachmentID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidAttachmentID, err)
+		return
+	}
+
+	resp, err := h.svc.GetAttachment(c.Request.Context(), defaultUserID, int64(attachmentID))
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgAttachmentRetrieved, resp)
+}
+
+// Upload accepts a multipart form with a "file" field and an optional "task_id"
+func (h *Handler) Upload(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	var taskID int
+	if raw := c.PostForm("task_id"); raw != "" {
+		taskID, err = strconv.Atoi(raw)
+		if err != nil || taskID <= 0 {
+			response.BadRequest(c, i18n.MsgInvalidTaskID, err)
+			return
+		}
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentUploadFailed, fmt.Errorf("%w: %v", models.ErrFileRequired, err))
+		return
+	}
+
+	resp, err := h.svc.Upload(c.Request.Context(), defaultUserID, int64(scheduleID), int64(taskID), file)
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentUploadFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Stored attachment", "attachment_id", resp.ID, "content_type", resp.ContentType, "size_bytes", resp.SizeBytes)
+	response.Success(c, i18n.MsgAttachmentUploaded, resp)
+}
+
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	attachmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || attachmentID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidAttachmentID, err)
+		return
+	}
+
+	err = h.svc.DeleteAttachment(c.Request.Context(), defaultUserID, int64(attachmentID))
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentDeleteFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgAttachmentDeleted, nil)
+}
+
+// Download streams a file from the local store; the signed query string is the only authorization
+func (h *Handler) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentDownloadFailed, fmt.Errorf("%w: %v", models.ErrInvalidDownloadURL, err))
+		return
+	}
+
+	att, body, err := h.svc.OpenFile(c.Request.Context(), key, expires, c.Query("signature"))
+	if err != nil {
+		response.FromError(c, i18n.MsgAttachmentDownloadFailed, err)
+		return
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", att.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, att.SizeBytes, att.ContentType, body, nil)
+}
@@ -0,0 +1,136 @@
+package availability
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/availability"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded user id because there is no auth yet, will implement later
+var defaultUserID int64 = 1
+
+type Handler struct {
+	svc availability.Service
+}
+
+func New(svc availability.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetAvailability(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetAvailability(c.Request.Context(), int64(userID))
+	if err != nil {
+		response.FromError(c, i18n.MsgAvailabilityFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgAvailabilityRetrieved, resp)
+}
+
+func (h *Handler) SetAvailability(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.SetAvailabilityRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.SetAvailability(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgAvailabilityUpdateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Updated availability", "caregiver_id", userID, "windows", len(resp))
+	response.Success(c, i18n.MsgAvailabilityUpdated, resp)
+}
+
+func (h *Handler) GetTimeOffs(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetTimeOffs(c.Request.Context(), int64(userID))
+	if err != nil {
+		response.FromError(c, i18n.MsgTimeOffsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgTimeOffsRetrieved, resp)
+}
+
+func (h *Handler) RequestTimeOff(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.CreateTimeOffRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.RequestTimeOff(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgTimeOffRequestFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgTimeOffRequested, resp)
+}
+
+func (h *Handler) ApproveTimeOff(c *gin.Context) {
+	h.reviewTimeOff(c, true)
+}
+
+func (h *Handler) RejectTimeOff(c *gin.Context) {
+	h.reviewTimeOff(c, false)
+}
+
+func (h *Handler) reviewTimeOff(c *gin.Context, approve bool) {
+	timeOffID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || timeOffID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidTimeOffID, err)
+		return
+	}
+
+	var req models.ReviewTimeOffRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+			return
+		}
+	}
+
+	// TODO: implement auth check (only supervisors can review time off)
+	resp, err := h.svc.ReviewTimeOff(c.Request.Context(), defaultUserID, int64(timeOffID), approve, &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgTimeOffReviewFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Reviewed time off", "time_off_id", timeOffID, "status", resp.Status)
+	response.Success(c, i18n.MsgTimeOffReviewed, resp)
+}
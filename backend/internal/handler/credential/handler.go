@@ -0,0 +1,158 @@
+package credential
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/credential"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultExpiringDays = 30
+
+type Handler struct {
+	svc credential.Service
+}
+
+func New(svc credential.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetTypes(c *gin.Context) {
+	resp, err := h.svc.GetTypes(c.Request.Context())
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialTypesFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialTypesRetrieved, resp)
+}
+
+func (h *Handler) CreateType(c *gin.Context) {
+	var req models.CreateCredentialTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.CreateType(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialTypeCreateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialTypeCreated, resp)
+}
+
+func (h *Handler) GetUserCredentials(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetUserCredentials(c.Request.Context(), int64(userID))
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialsRetrieved, resp)
+}
+
+func (h *Handler) AddUserCredential(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.CreateUserCredentialRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.AddUserCredential(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialAddFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Added credential", "type_code", resp.TypeCode, "caregiver_id", userID)
+	response.Success(c, i18n.MsgCredentialAdded, resp)
+}
+
+func (h *Handler) RemoveUserCredential(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	credentialID, err := strconv.Atoi(c.Param("credentialId"))
+	if err != nil || credentialID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidCredentialID, err)
+		return
+	}
+
+	err = h.svc.RemoveUserCredential(c.Request.Context(), int64(userID), int64(credentialID))
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialRemoveFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialRemoved, nil)
+}
+
+func (h *Handler) GetRequirements(c *gin.Context) {
+	resp, err := h.svc.GetRequirements(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialRequirementsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialRequirementsRetrieved, resp)
+}
+
+func (h *Handler) SetRequirements(c *gin.Context) {
+	var req models.SetCredentialRequirementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.SetRequirements(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgCredentialRequirementsUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgCredentialRequirementsUpdated, resp)
+}
+
+func (h *Handler) GetExpiring(c *gin.Context) {
+	days := defaultExpiringDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			response.BadRequest(c, i18n.MsgInvalidDays, err)
+			return
+		}
+		days = parsed
+	}
+
+	resp, err := h.svc.GetExpiring(c.Request.Context(), days)
+	if err != nil {
+		response.FromError(c, i18n.MsgExpiringCredentialsFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Found expiring credentials", "count", len(resp), "days", days)
+	response.Success(c, i18n.MsgExpiringCredentialsRetrieved, resp)
+}
@@ -0,0 +1,94 @@
+package escalation
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/escalation"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded user id because there is no auth yet, will implement later
+var defaultUserID int64 = 1
+
+type Handler struct {
+	svc escalation.Service
+}
+
+func New(svc escalation.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetScheduleAlerts(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	resp, err := h.svc.GetAlerts(c.Request.Context(), int64(scheduleID))
+	if err != nil {
+		response.FromError(c, i18n.MsgAlertsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgAlertsRetrieved, resp)
+}
+
+func (h *Handler) AcknowledgeAlert(c *gin.Context) {
+	alertID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || alertID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidAlertID, err)
+		return
+	}
+
+	// the note is optional, so an empty body is accepted
+	var req models.AcknowledgeAlertRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+			return
+		}
+	}
+
+	resp, err := h.svc.AcknowledgeAlert(c.Request.Context(), defaultUserID, int64(alertID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgAlertAcknowledgeFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Acknowledged alert", "alert_id", alertID, "schedule_id", resp.ScheduleID)
+	response.Success(c, i18n.MsgAlertAcknowledged, resp)
+}
+
+func (h *Handler) GetRiskLevels(c *gin.Context) {
+	resp, err := h.svc.GetRiskLevels(c.Request.Context())
+	if err != nil {
+		response.FromError(c, i18n.MsgRiskLevelsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgRiskLevelsRetrieved, resp)
+}
+
+func (h *Handler) SetRiskLevel(c *gin.Context) {
+	var req models.SetClientRiskLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.SetRiskLevel(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgRiskLevelUpdateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Set client risk level", "client_name", resp.ClientName, "risk_level", resp.RiskLevel)
+	response.Success(c, i18n.MsgRiskLevelUpdated, resp)
+}
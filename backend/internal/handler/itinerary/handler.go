@@ -0,0 +1,54 @@
+package itinerary
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/service/itinerary"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc itinerary.Service
+}
+
+func New(svc itinerary.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetItinerary(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetItinerary(c.Request.Context(), int64(userID), c.Query("date"))
+	if err != nil {
+		response.FromError(c, i18n.MsgItineraryFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Built itinerary", "caregiver_id", userID, "date", resp.Date, "visits", len(resp.Visits))
+	response.Success(c, i18n.MsgItineraryRetrieved, resp)
+}
+
+func (h *Handler) GetMileageReport(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetMileageReport(c.Request.Context(), int64(userID), c.Query("date"))
+	if err != nil {
+		response.FromError(c, i18n.MsgMileageReportFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgMileageReportRetrieved, resp)
+}
@@ -0,0 +1,103 @@
+package matching
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/matching"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc matching.Service
+}
+
+func New(svc matching.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetMatches(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	resp, err := h.svc.GetMatches(c.Request.Context(), int64(scheduleID))
+	if err != nil {
+		response.FromError(c, i18n.MsgCaregiverMatchesFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Ranked caregivers for schedule", "candidates", len(resp.Candidates))
+	response.Success(c, i18n.MsgCaregiverMatchesRetrieved, resp)
+}
+
+func (h *Handler) GetUserSkills(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetUserSkills(c.Request.Context(), int64(userID))
+	if err != nil {
+		response.FromError(c, i18n.MsgSkillsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgSkillsRetrieved, resp)
+}
+
+func (h *Handler) SetUserSkills(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.SetSkillsRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.SetUserSkills(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgSkillsUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgSkillsUpdated, resp)
+}
+
+func (h *Handler) GetServiceRequirements(c *gin.Context) {
+	resp, err := h.svc.GetServiceRequirements(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		response.FromError(c, i18n.MsgServiceRequirementsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgServiceRequirementsRetrieved, resp)
+}
+
+func (h *Handler) SetServiceRequirements(c *gin.Context) {
+	var req models.SetSkillsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.SetServiceRequirements(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgServiceRequirementsUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgServiceRequirementsUpdated, resp)
+}
@@ -0,0 +1,85 @@
+package note
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/note"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded user id because there is no auth yet, will implement later
+var defaultUserID int64 = 1
+
+type Handler struct {
+	svc note.Service
+}
+
+func New(svc note.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetNotes(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	resp, err := h.svc.GetNotes(c.Request.Context(), defaultUserID, int64(scheduleID))
+	if err != nil {
+		response.FromError(c, i18n.MsgNotesFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgNotesRetrieved, resp)
+}
+
+func (h *Handler) AddNote(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	var req models.NoteRequest
+	if err = c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Body) == "" {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.AddNote(c.Request.Context(), defaultUserID, int64(scheduleID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgNoteAddFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgNoteAdded, resp)
+}
+
+func (h *Handler) UpdateNote(c *gin.Context) {
+	noteID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || noteID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidNoteID, err)
+		return
+	}
+
+	var req models.NoteRequest
+	if err = c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Body) == "" {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.UpdateNote(c.Request.Context(), defaultUserID, int64(noteID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgNoteUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgNoteUpdated, resp)
+}
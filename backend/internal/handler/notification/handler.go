@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/notification"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc notification.Service
+}
+
+func New(svc notification.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetPreferences(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	resp, err := h.svc.GetPreferences(c.Request.Context(), int64(userID))
+	if err != nil {
+		response.FromError(c, i18n.MsgNotificationPreferencesFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgNotificationPreferencesRetrieved, resp)
+}
+
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.UpdatePreferences(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgNotificationPreferencesUpdateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Updated notification preferences", "target_user_id", userID)
+	response.Success(c, i18n.MsgNotificationPreferencesUpdated, resp)
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/report"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc report.Service
+}
+
+func New(svc report.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetComplianceReport(c *gin.Context) {
+	req := models.ComplianceReportRequest{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	}
+	if raw := c.Query("group_by"); raw != "" {
+		for _, g := range strings.Split(raw, ",") {
+			req.GroupBy = append(req.GroupBy, strings.ToLower(strings.TrimSpace(g)))
+		}
+	}
+
+	// TODO: implement auth check (only leadership and supervisors can see reports)
+	resp, err := h.svc.GetComplianceReport(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgComplianceReportFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgComplianceReportRetrieved, resp)
+}
@@ -0,0 +1,89 @@
+package review
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/review"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded supervisor id because there is no auth yet, the reviewer will come from the session
+var defaultSupervisorID int64 = 2
+
+type Handler struct {
+	svc review.Service
+}
+
+func New(svc review.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) GetExceptions(c *gin.Context) {
+	req := models.ExceptionQueryRequest{
+		Flag:   c.Query("flag"),
+		Client: c.Query("client"),
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+		Status: c.Query("status"),
+	}
+	if raw := c.Query("caregiver_id"); raw != "" {
+		caregiverID, err := strconv.Atoi(raw)
+		if err != nil || caregiverID <= 0 {
+			response.BadRequest(c, i18n.MsgInvalidExceptionFilters, err)
+			return
+		}
+		req.CaregiverID = int64(caregiverID)
+	}
+
+	// TODO: implement auth check (only supervisors can see the review queue)
+	resp, err := h.svc.GetExceptions(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgExceptionsFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgExceptionsRetrieved, resp)
+}
+
+func (h *Handler) AcknowledgeException(c *gin.Context) {
+	h.reviewException(c, models.ExceptionStatusAcknowledged)
+}
+
+func (h *Handler) ApproveException(c *gin.Context) {
+	h.reviewException(c, models.ExceptionStatusApproved)
+}
+
+func (h *Handler) RejectException(c *gin.Context) {
+	h.reviewException(c, models.ExceptionStatusRejected)
+}
+
+func (h *Handler) reviewException(c *gin.Context, status string) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	var req models.ReviewExceptionRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+			return
+		}
+	}
+
+	resp, err := h.svc.ReviewException(c.Request.Context(), defaultSupervisorID, int64(scheduleID), c.Param("flag"), status, &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgExceptionReviewFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Reviewed compliance exception", "flag", resp.Flag, "status", resp.Status)
+	response.Success(c, i18n.MsgExceptionReviewed, resp)
+}
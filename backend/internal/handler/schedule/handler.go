@@ -1,12 +1,12 @@
 package schedule
 
 import (
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
 	"github.com/erizkiatama/bluehorntech/internal/models"
 
 	"github.com/erizkiatama/bluehorntech/internal/service/schedule"
@@ -28,7 +28,7 @@ func New(svc schedule.Service) *Handler {
 }
 
 func (h *Handler) GetTodaySchedules(c *gin.Context) {
-	log.Printf("Getting today's schedules for user %d", defaultUserID)
+	slog.DebugContext(c.Request.Context(), "Getting today's schedules")
 
 	tz := c.Query("tz")
 	if tz == "" {
@@ -37,61 +37,61 @@ func (h *Handler) GetTodaySchedules(c *gin.Context) {
 
 	resp, err := h.svc.GetTodaySchedules(c.Request.Context(), defaultUserID, tz)
 	if err != nil {
-		response.InternalError(c, "Failed to fetch today's schedules", err)
+		response.FromError(c, i18n.MsgTodaySchedulesFailed, err)
 		return
 	}
 
-	log.Printf("Successfully retrieved %d schedules for today for user %d", len(resp.Schedules), defaultUserID)
-	response.Success(c, "Today's schedules retrieved successfully", resp)
+	slog.DebugContext(c.Request.Context(), "Retrieved today's schedules", "count", len(resp.Schedules))
+	response.Success(c, i18n.MsgTodaySchedulesRetrieved, resp)
 }
 
 func (h *Handler) GetAllSchedules(c *gin.Context) {
-	log.Printf("Getting all schedules for user %d", defaultUserID)
+	slog.DebugContext(c.Request.Context(), "Getting all schedules")
 
 	resp, err := h.svc.GetAllSchedules(c.Request.Context(), defaultUserID)
 	if err != nil {
-		response.InternalError(c, "Failed to fetch schedules", err)
+		response.FromError(c, i18n.MsgSchedulesFailed, err)
 		return
 	}
 
-	log.Printf("Successfully retrieved %d total schedules for user %d", len(resp.Schedules), defaultUserID)
-	response.Success(c, "Schedules retrieved successfully", resp)
+	slog.DebugContext(c.Request.Context(), "Retrieved all schedules", "count", len(resp.Schedules))
+	response.Success(c, i18n.MsgSchedulesRetrieved, resp)
 }
 
 func (h *Handler) GetScheduleDetails(c *gin.Context) {
-	log.Printf("Getting a schedule details for user %d", defaultUserID)
+	slog.DebugContext(c.Request.Context(), "Getting schedule details")
 
 	scheduleID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		response.BadRequest(c, "Invalid schedule ID", err)
+		response.BadRequest(c, i18n.MsgInvalidScheduleID, err)
 		return
 	}
 
 	resp, err := h.svc.GetScheduleDetails(c.Request.Context(), defaultUserID, int64(scheduleID))
 	if err != nil {
-		response.InternalError(c, "Failed to get schedule details", err)
+		response.FromError(c, i18n.MsgScheduleDetailsFailed, err)
 		return
 	}
 
-	log.Printf("Successfully retrieved schedule %d details for user %d", scheduleID, defaultUserID)
-	response.Success(c, "Schedule details retrieved successfully", resp)
+	slog.DebugContext(c.Request.Context(), "Retrieved schedule details")
+	response.Success(c, i18n.MsgScheduleDetailsRetrieved, resp)
 }
 
 func (h *Handler) ClockIn(c *gin.Context) {
 	scheduleID, err := strconv.Atoi(c.Param("id"))
 	if err != nil || scheduleID <= 0 {
-		response.Error(c, http.StatusBadRequest, "Invalid schedule ID", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidScheduleID, err)
 		return
 	}
 
 	var req models.ClockInOutRequest
 	if err = c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request body", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
 		return
 	}
 
 	if err = validateGeolocation(req.Latitude, req.Longitude); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid geo location", err)
+		response.FromError(c, i18n.MsgInvalidGeoLocation, err)
 		return
 	}
 
@@ -105,30 +105,13 @@ func (h *Handler) ClockIn(c *gin.Context) {
 
 	clockInResp, err := h.svc.ClockIn(c.Request.Context(), defaultUserID, int64(scheduleID), &req)
 	if err != nil {
-		errMsg := err.Error()
-		statusCode := http.StatusInternalServerError
-
-		switch {
-		case errors.Is(err, models.ErrScheduleNotFound):
-			statusCode = http.StatusNotFound
-		case errors.Is(err, models.ErrVisitAlreadyStarted):
-			statusCode = http.StatusConflict
-		case errors.Is(err, models.ErrLocationTooFar):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrClockInTooEarly):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrClockInTooLate):
-			statusCode = http.StatusBadRequest
-		default:
-			errMsg = "Failed to clock in: " + err.Error()
-		}
-		response.Error(c, statusCode, errMsg, err)
-		return
-	}
-
-	message := "Clocked in successfully"
+		response.FromError(c, i18n.MsgClockInFailed, err)
+		return
+	}
+
+	message := i18n.MsgClockedIn
 	if clockInResp.WarningMessage != "" {
-		message = "Clocked in with warnings"
+		message = i18n.MsgClockedInWithWarnings
 	}
 
 	response.Success(c, message, clockInResp)
@@ -137,18 +120,18 @@ func (h *Handler) ClockIn(c *gin.Context) {
 func (h *Handler) ClockOut(c *gin.Context) {
 	scheduleID, err := strconv.Atoi(c.Param("id"))
 	if err != nil || scheduleID <= 0 {
-		response.Error(c, http.StatusBadRequest, "Invalid schedule ID", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidScheduleID, err)
 		return
 	}
 
-	var req models.ClockInOutRequest
+	var req models.ClockOutRequest
 	if err = c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request body", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
 		return
 	}
 
 	if err = validateGeolocation(req.Latitude, req.Longitude); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid geo location", err)
+		response.FromError(c, i18n.MsgInvalidGeoLocation, err)
 		return
 	}
 
@@ -163,37 +146,70 @@ func (h *Handler) ClockOut(c *gin.Context) {
 	// 5. Call service layer
 	clockOutResp, err := h.svc.ClockOut(c.Request.Context(), defaultUserID, int64(scheduleID), &req)
 	if err != nil {
-		errMsg := err.Error()
-		statusCode := http.StatusInternalServerError
-
-		switch {
-		case errors.Is(err, models.ErrScheduleNotFound):
-			statusCode = http.StatusNotFound
-		case errors.Is(err, models.ErrVisitNotStarted):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrVisitAlreadyEnded):
-			statusCode = http.StatusConflict
-		case errors.Is(err, models.ErrLocationTooFar):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrClockOutTooEarly):
-			statusCode = http.StatusBadRequest
-		default:
-			errMsg = "Failed to clock out: " + err.Error()
-		}
-		response.Error(c, statusCode, errMsg, err)
-		return
-	}
-
-	response.Success(c, "Clocked out successfully", clockOutResp)
+		response.FromError(c, i18n.MsgClockOutFailed, err)
+		return
+	}
+
+	message := i18n.MsgClockedOut
+	if clockOutResp.WarningMessage != "" {
+		message = i18n.MsgClockedOutWithWarnings
+	}
+
+	response.Success(c, message, clockOutResp)
+}
+
+func (h *Handler) CreateSchedule(c *gin.Context) {
+	var req models.CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	if err := validateGeolocation(req.Latitude, req.Longitude); err != nil {
+		response.FromError(c, i18n.MsgInvalidGeoLocation, err)
+		return
+	}
+
+	resp, err := h.svc.CreateSchedule(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgScheduleCreateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Created schedule", "schedule_id", resp.ID, "caregiver_id", resp.UserID)
+	response.Success(c, i18n.MsgScheduleCreated, resp)
+}
+
+func (h *Handler) AssignSchedule(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	var req models.AssignScheduleRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.AssignSchedule(c.Request.Context(), int64(scheduleID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgScheduleAssignFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Assigned schedule", "caregiver_id", req.UserID)
+	response.Success(c, i18n.MsgScheduleAssigned, resp)
 }
 
 // validateGeolocation validates latitude and longitude values
 func validateGeolocation(lat, lng float64) error {
 	if lat < -90 || lat > 90 {
-		return errors.New("latitude must be between -90 and 90")
+		return models.ErrInvalidLatitude
 	}
 	if lng < -180 || lng > 180 {
-		return errors.New("longitude must be between -180 and 180")
+		return models.ErrInvalidLongitude
 	}
 	return nil
 }
@@ -0,0 +1,113 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/stream"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc stream.Service
+}
+
+func New(svc stream.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// StreamVisits pushes visit and task events as Server-Sent Events. Each event's id is its outbox id,
+// so a reconnecting EventSource sends Last-Event-ID and gets what it missed first.
+func (h *Handler) StreamVisits(c *gin.Context) {
+	req := models.StreamQueryRequest{
+		Client: c.Query("client"),
+		Types:  c.Query("types"),
+	}
+	for param, target := range map[string]*int64{"caregiver_id": &req.CaregiverID, "schedule_id": &req.ScheduleID} {
+		if raw := c.Query(param); raw != "" {
+			id, err := strconv.Atoi(raw)
+			if err != nil || id <= 0 {
+				response.BadRequest(c, i18n.MsgInvalidStreamFilters, err)
+				return
+			}
+			*target = int64(id)
+		}
+	}
+
+	var lastEventID int64
+	if raw := c.GetHeader("Last-Event-ID"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			response.BadRequest(c, i18n.MsgInvalidLastEventID, err)
+			return
+		}
+		lastEventID = id
+	}
+
+	// TODO: implement auth check (limit coordinators to the schedules they manage)
+	st, err := h.svc.Open(c.Request.Context(), &req, lastEventID)
+	if err != nil {
+		response.FromError(c, i18n.MsgStreamFailed, err)
+		return
+	}
+	defer st.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	if st.Reset {
+		// no id, so the client keeps its Last-Event-ID until a live event moves it on
+		if _, err = fmt.Fprintf(c.Writer, "event: %s\ndata: {}\n\n", models.StreamEventReset); err != nil {
+			return
+		}
+	}
+	for _, event := range st.Replay {
+		if err = writeEvent(c.Writer, event); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.svc.Heartbeat())
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-st.Events():
+			if !ok {
+				slog.WarnContext(c.Request.Context(), "Closing visit stream that fell behind; the client will reconnect and replay")
+				return false
+			}
+			if event.ID <= st.LastID || !st.Filter.Matches(event) {
+				return true
+			}
+			return writeEvent(w, event) == nil
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": keepalive\n\n")
+			return err == nil
+		}
+	})
+}
+
+func writeEvent(w io.Writer, event models.StreamEvent) error {
+	data, err := json.Marshal(event.ToWebhookEnvelope())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
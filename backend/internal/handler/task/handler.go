@@ -1,10 +1,11 @@
 package task
 
 import (
-	"errors"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
 	"github.com/erizkiatama/bluehorntech/internal/models"
 	"github.com/erizkiatama/bluehorntech/internal/service/task"
 	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -14,6 +15,9 @@ import (
 // TODO: hardcoded user id because there is no auth yet, will implement later
 var defaultUserID int64 = 1
 
+// TODO: hardcoded supervisor id because there is no auth yet, the reviewer will come from the session
+var defaultSupervisorID int64 = 2
+
 type Handler struct {
 	svc task.Service
 }
@@ -27,58 +31,135 @@ func New(svc task.Service) *Handler {
 func (h *Handler) UpdateTask(c *gin.Context) {
 	taskID, err := strconv.Atoi(c.Param("id"))
 	if err != nil || taskID <= 0 {
-		response.Error(c, http.StatusBadRequest, "Invalid task ID", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidTaskID, err)
 		return
 	}
 
 	var req models.UpdateTaskRequest
 	if err = c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request body", err)
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
 		return
 	}
 
-	if err = validateTaskStatus(req.Status, req.Reason); err != nil {
-		response.Error(c, http.StatusBadRequest, "Error validating task status", err)
+	if err = models.ValidateTaskStatus(req.Status, req.Reason); err != nil {
+		response.FromError(c, i18n.MsgTaskStatusInvalid, err)
 		return
 	}
 
 	// TODO: implement auth check (can only update their own tasks)
 	updateResp, err := h.svc.UpdateTask(c.Request.Context(), defaultUserID, int64(taskID), &req)
 	if err != nil {
-		errMsg := err.Error()
-		statusCode := http.StatusInternalServerError
-
-		switch {
-		case errors.Is(err, models.ErrTaskNotFound):
-			statusCode = http.StatusNotFound
-		case errors.Is(err, models.ErrInvalidTaskStatus):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrReasonRequired):
-			statusCode = http.StatusBadRequest
-		case errors.Is(err, models.ErrTaskAlreadyUpdated):
-			statusCode = http.StatusConflict
-		case errors.Is(err, models.ErrVisitNotInProgress):
-			statusCode = http.StatusBadRequest
-		default:
-			errMsg = "Failed to update task: " + err.Error()
-		}
-		response.Error(c, statusCode, errMsg, err)
+		response.FromError(c, i18n.MsgTaskUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgTaskUpdated, updateResp)
+}
+
+// UpdateTasks applies several task outcomes of one visit atomically; nothing is saved if any item is invalid
+func (h *Handler) UpdateTasks(c *gin.Context) {
+	scheduleID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || scheduleID <= 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidScheduleID, err)
+		return
+	}
+
+	var req models.BulkUpdateTasksRequest
+	if err = c.ShouldBindJSON(&req); err != nil || len(req.Tasks) == 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	// TODO: implement auth check (can only update their own tasks)
+	resp, err := h.svc.UpdateTasks(c.Request.Context(), defaultUserID, int64(scheduleID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgTasksUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgTasksUpdated, resp)
+}
+
+func (h *Handler) GetHistory(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || taskID <= 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidTaskID, err)
+		return
+	}
+
+	resp, err := h.svc.GetHistory(c.Request.Context(), defaultUserID, int64(taskID))
+	if err != nil {
+		response.FromError(c, i18n.MsgTaskHistoryFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgTaskHistoryRetrieved, resp)
+}
+
+// RequestCorrection asks a supervisor to approve a change to a task's outcome after clock-out
+func (h *Handler) RequestCorrection(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || taskID <= 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidTaskID, err)
+		return
+	}
+
+	var req models.UpdateTaskRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.RequestCorrection(c.Request.Context(), defaultUserID, int64(taskID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgCorrectionRequestFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Requested task correction", "correction_id", resp.ID, "task_id", resp.TaskID)
+	response.Success(c, i18n.MsgCorrectionRequested, resp)
+}
+
+func (h *Handler) GetCorrections(c *gin.Context) {
+	resp, err := h.svc.GetCorrections(c.Request.Context(), defaultSupervisorID, c.Query("status"))
+	if err != nil {
+		response.FromError(c, i18n.MsgCorrectionsFailed, err)
 		return
 	}
 
-	response.Success(c, "Task updated successfully", updateResp)
+	response.Success(c, i18n.MsgCorrectionsRetrieved, resp)
+}
+
+func (h *Handler) ApproveCorrection(c *gin.Context) {
+	h.reviewCorrection(c, true, i18n.MsgCorrectionApproved)
+}
+
+func (h *Handler) RejectCorrection(c *gin.Context) {
+	h.reviewCorrection(c, false, i18n.MsgCorrectionRejected)
 }
 
-func validateTaskStatus(status, reason string) error {
-	switch status {
-	case models.TaskStatusCompleted:
-		return nil
-	case models.TaskStatusNotCompleted:
-		if reason == "" {
-			return models.ErrReasonRequired
+func (h *Handler) reviewCorrection(c *gin.Context, approve bool, successKey string) {
+	correctionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || correctionID <= 0 {
+		response.Error(c, http.StatusBadRequest, i18n.MsgInvalidCorrectionID, err)
+		return
+	}
+
+	// the note is optional, so an empty body is accepted
+	var req models.ReviewTaskCorrectionRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, i18n.MsgInvalidRequestBody, err)
+			return
 		}
-		return nil
-	default:
-		return models.ErrInvalidTaskStatus
 	}
+
+	resp, err := h.svc.ReviewCorrection(c.Request.Context(), defaultSupervisorID, int64(correctionID), approve, &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgCorrectionReviewFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Reviewed task correction", "correction_id", resp.ID, "task_id", resp.TaskID, "status", resp.Status)
+	response.Success(c, successKey, resp)
 }
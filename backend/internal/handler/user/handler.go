@@ -0,0 +1,45 @@
+package user
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/user"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc user.Service
+}
+
+func New(svc user.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func (h *Handler) UpdateLocale(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidUserID, err)
+		return
+	}
+
+	var req models.UpdateLocaleRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.UpdateLocale(c.Request.Context(), int64(userID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgLocaleUpdateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "User set their language", "target_user_id", userID, "locale", resp.Locale)
+	response.Success(c, i18n.MsgLocaleUpdated, resp)
+}
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/service/webhook"
+	"github.com/erizkiatama/bluehorntech/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	svc webhook.Service
+}
+
+func New(svc webhook.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// TODO: implement auth check (only administrators can manage webhooks)
+func (h *Handler) GetWebhooks(c *gin.Context) {
+	resp, err := h.svc.GetWebhooks(c.Request.Context())
+	if err != nil {
+		response.FromError(c, i18n.MsgWebhooksFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgWebhooksRetrieved, resp)
+}
+
+func (h *Handler) GetWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidWebhookID, err)
+		return
+	}
+
+	resp, err := h.svc.GetWebhook(c.Request.Context(), int64(webhookID))
+	if err != nil {
+		response.FromError(c, i18n.MsgWebhookFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgWebhookRetrieved, resp)
+}
+
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.CreateWebhook(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgWebhookCreateFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Created webhook", "webhook_id", resp.ID, "event_types", resp.EventTypes)
+	response.Success(c, i18n.MsgWebhookCreated, resp)
+}
+
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidWebhookID, err)
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, i18n.MsgInvalidRequestBody, err)
+		return
+	}
+
+	resp, err := h.svc.UpdateWebhook(c.Request.Context(), int64(webhookID), &req)
+	if err != nil {
+		response.FromError(c, i18n.MsgWebhookUpdateFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgWebhookUpdated, resp)
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidWebhookID, err)
+		return
+	}
+
+	if err = h.svc.DeleteWebhook(c.Request.Context(), int64(webhookID)); err != nil {
+		response.FromError(c, i18n.MsgWebhookDeleteFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Deleted webhook", "webhook_id", webhookID)
+	response.Success(c, i18n.MsgWebhookDeleted, nil)
+}
+
+func (h *Handler) PingWebhook(c *gin.Context) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidWebhookID, err)
+		return
+	}
+
+	if err = h.svc.PingWebhook(c.Request.Context(), int64(webhookID)); err != nil {
+		response.FromError(c, i18n.MsgWebhookPingFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgWebhookPinged, nil)
+}
+
+func (h *Handler) GetDeadLetters(c *gin.Context) {
+	resp, err := h.svc.GetDeadLetters(c.Request.Context())
+	if err != nil {
+		response.FromError(c, i18n.MsgDeadLettersFailed, err)
+		return
+	}
+
+	response.Success(c, i18n.MsgDeadLettersRetrieved, resp)
+}
+
+func (h *Handler) RetryDeadLetter(c *gin.Context) {
+	deliveryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || deliveryID <= 0 {
+		response.BadRequest(c, i18n.MsgInvalidDeliveryID, err)
+		return
+	}
+
+	if err = h.svc.RetryDeadLetter(c.Request.Context(), int64(deliveryID)); err != nil {
+		response.FromError(c, i18n.MsgDeadLetterRetryFailed, err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Dead webhook delivery queued for retry", "delivery_id", deliveryID)
+	response.Success(c, i18n.MsgDeadLetterRetried, nil)
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type numberFormat struct {
+	decimal   string
+	thousands string
+}
+
+// durationFormat holds the unit suffixes, including the space some locales put before the unit
+type durationFormat struct {
+	hours   string
+	minutes string
+}
+
+var numberFormats = map[Locale]numberFormat{
+	English: {decimal: ".", thousands: ","},
+	Spanish: {decimal: ",", thousands: "."},
+	Tagalog: {decimal: ".", thousands: ","},
+}
+
+var durationFormats = map[Locale]durationFormat{
+	English: {hours: "h", minutes: "m"},
+	Spanish: {hours: " h", minutes: " min"},
+	Tagalog: {hours: " oras", minutes: " min"},
+}
+
+// Number formats v with the locale's decimal and thousands separators
+func (l Locale) Number(v float64, decimals int) string {
+	f, ok := numberFormats[l]
+	if !ok {
+		f = numberFormats[Default]
+	}
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(f.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Integer formats a count with the locale's thousands separator
+func (l Locale) Integer(v int64) string {
+	return l.Number(float64(v), 0)
+}
+
+// Meters formats a distance in whole meters, e.g. "1,250 m"
+func (l Locale) Meters(meters float64) string {
+	return l.Number(meters, 0) + " m"
+}
+
+// Kilometers formats a distance given in meters as kilometers with one decimal, e.g. "3,5 km"
+func (l Locale) Kilometers(meters float64) string {
+	return l.Number(meters/1000, 1) + " km"
+}
+
+// Duration formats d in hours and minutes, e.g. "1h 30m" or "1 oras 30 min"
+func (l Locale) Duration(d time.Duration) string {
+	f, ok := durationFormats[l]
+	if !ok {
+		f = durationFormats[Default]
+	}
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	m := strconv.Itoa(minutes) + f.minutes
+	if hours > 0 {
+		return l.Integer(int64(hours)) + f.hours + " " + m
+	}
+	return m
+}
@@ -0,0 +1,128 @@
+// Package i18n holds the message catalogue for the languages our caregivers work in and formats
+// numbers and durations to match. Messages are keyed by a stable code so the text can change per
+// locale while clients keep branching on the code.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+	Tagalog Locale = "tl"
+
+	Default = English
+)
+
+// Supported lists the locales with a message catalogue
+var Supported = []Locale{English, Spanish, Tagalog}
+
+// Args fills the {name} placeholders of a message
+type Args map[string]string
+
+var catalogues = map[Locale]map[string]string{
+	English: english,
+	Spanish: spanish,
+	Tagalog: tagalog,
+}
+
+// Parse maps a language tag such as "es-MX" or "fil" to a supported locale
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch tag {
+	case "en":
+		return English, true
+	case "es":
+		return Spanish, true
+	case "tl", "fil":
+		return Tagalog, true
+	default:
+		return "", false
+	}
+}
+
+// Negotiate picks the supported locale the client prefers most from an Accept-Language header
+func Negotiate(header string) (Locale, bool) {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if locale, ok := Parse(c.tag); ok {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+type contextKey struct{}
+
+// WithLocale stores the locale negotiated for a request
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the request's locale, or the default when none was negotiated
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return locale
+	}
+	return Default
+}
+
+// Lookup returns the message for key in this locale, falling back to English
+func (l Locale) Lookup(key string) (string, bool) {
+	if msg, ok := catalogues[l][key]; ok {
+		return msg, true
+	}
+	msg, ok := english[key]
+	return msg, ok
+}
+
+// T translates key and fills in its placeholders. Unknown keys are returned as is.
+func (l Locale) T(key string, args Args) string {
+	msg, ok := l.Lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+
+	pairs := make([]string, 0, len(args)*2)
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
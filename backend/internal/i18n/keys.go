@@ -0,0 +1,234 @@
+package i18n
+
+// Request validation
+const (
+	MsgInvalidRequestBody  = "invalid_request_body"
+	MsgInvalidScheduleID   = "invalid_schedule_id"
+	MsgInvalidUserID       = "invalid_user_id"
+	MsgInvalidTaskID       = "invalid_task_id"
+	MsgInvalidNoteID       = "invalid_note_id"
+	MsgInvalidAttachmentID = "invalid_attachment_id"
+	MsgInvalidTimeOffID    = "invalid_time_off_id"
+	MsgInvalidCredentialID = "invalid_credential_id"
+	MsgInvalidDays         = "invalid_days"
+	MsgInvalidGeoLocation  = "invalid_geo_location"
+	MsgTaskStatusInvalid   = "task_status_invalid"
+)
+
+// Schedules and visits
+const (
+	MsgTodaySchedulesRetrieved         = "today_schedules_retrieved"
+	MsgTodaySchedulesFailed            = "today_schedules_failed"
+	MsgSchedulesRetrieved              = "schedules_retrieved"
+	MsgSchedulesFailed                 = "schedules_failed"
+	MsgScheduleDetailsRetrieved        = "schedule_details_retrieved"
+	MsgScheduleDetailsFailed           = "schedule_details_failed"
+	MsgClockedIn                       = "clocked_in"
+	MsgClockedInWithWarnings           = "clocked_in_with_warnings"
+	MsgClockInFailed                   = "clock_in_failed"
+	MsgClockedOut                      = "clocked_out"
+	MsgClockedOutWithWarnings          = "clocked_out_with_warnings"
+	MsgClockOutFailed                  = "clock_out_failed"
+	MsgScheduleCreated                 = "schedule_created"
+	MsgScheduleCreateFailed            = "schedule_create_failed"
+	MsgScheduleAssigned                = "schedule_assigned"
+	MsgScheduleAssignFailed            = "schedule_assign_failed"
+	MsgTaskUpdated                     = "task_updated"
+	MsgTaskUpdateFailed                = "task_update_failed"
+	MsgTasksUpdated                    = "tasks_updated"
+	MsgTasksUpdateFailed               = "tasks_update_failed"
+	MsgTaskHistoryRetrieved            = "task_history_retrieved"
+	MsgTaskHistoryFailed               = "task_history_failed"
+	MsgNotesRetrieved                  = "notes_retrieved"
+	MsgNotesFailed                     = "notes_failed"
+	MsgNoteAdded                       = "note_added"
+	MsgNoteAddFailed                   = "note_add_failed"
+	MsgNoteUpdated                     = "note_updated"
+	MsgNoteUpdateFailed                = "note_update_failed"
+	MsgAttachmentsRetrieved            = "attachments_retrieved"
+	MsgAttachmentsFailed               = "attachments_failed"
+	MsgAttachmentRetrieved             = "attachment_retrieved"
+	MsgAttachmentFailed                = "attachment_failed"
+	MsgAttachmentUploaded              = "attachment_uploaded"
+	MsgAttachmentUploadFailed          = "attachment_upload_failed"
+	MsgAttachmentDeleted               = "attachment_deleted"
+	MsgAttachmentDeleteFailed          = "attachment_delete_failed"
+	MsgAttachmentDownloadFailed        = "attachment_download_failed"
+	MsgItineraryRetrieved              = "itinerary_retrieved"
+	MsgItineraryFailed                 = "itinerary_failed"
+	MsgMileageReportRetrieved          = "mileage_report_retrieved"
+	MsgMileageReportFailed             = "mileage_report_failed"
+	MsgCaregiverMatchesRetrieved       = "caregiver_matches_retrieved"
+	MsgCaregiverMatchesFailed          = "caregiver_matches_failed"
+	MsgSkillsRetrieved                 = "skills_retrieved"
+	MsgSkillsFailed                    = "skills_failed"
+	MsgSkillsUpdated                   = "skills_updated"
+	MsgSkillsUpdateFailed              = "skills_update_failed"
+	MsgServiceRequirementsRetrieved    = "service_requirements_retrieved"
+	MsgServiceRequirementsFailed       = "service_requirements_failed"
+	MsgServiceRequirementsUpdated      = "service_requirements_updated"
+	MsgServiceRequirementsUpdateFailed = "service_requirements_update_failed"
+)
+
+// Caregivers
+const (
+	MsgAvailabilityRetrieved              = "availability_retrieved"
+	MsgAvailabilityFailed                 = "availability_failed"
+	MsgAvailabilityUpdated                = "availability_updated"
+	MsgAvailabilityUpdateFailed           = "availability_update_failed"
+	MsgTimeOffsRetrieved                  = "time_offs_retrieved"
+	MsgTimeOffsFailed                     = "time_offs_failed"
+	MsgTimeOffRequested                   = "time_off_requested"
+	MsgTimeOffRequestFailed               = "time_off_request_failed"
+	MsgTimeOffReviewed                    = "time_off_reviewed"
+	MsgTimeOffReviewFailed                = "time_off_review_failed"
+	MsgCredentialTypesRetrieved           = "credential_types_retrieved"
+	MsgCredentialTypesFailed              = "credential_types_failed"
+	MsgCredentialTypeCreated              = "credential_type_created"
+	MsgCredentialTypeCreateFailed         = "credential_type_create_failed"
+	MsgCredentialsRetrieved               = "credentials_retrieved"
+	MsgCredentialsFailed                  = "credentials_failed"
+	MsgCredentialAdded                    = "credential_added"
+	MsgCredentialAddFailed                = "credential_add_failed"
+	MsgCredentialRemoved                  = "credential_removed"
+	MsgCredentialRemoveFailed             = "credential_remove_failed"
+	MsgCredentialRequirementsRetrieved    = "credential_requirements_retrieved"
+	MsgCredentialRequirementsFailed       = "credential_requirements_failed"
+	MsgCredentialRequirementsUpdated      = "credential_requirements_updated"
+	MsgCredentialRequirementsUpdateFailed = "credential_requirements_update_failed"
+	MsgExpiringCredentialsRetrieved       = "expiring_credentials_retrieved"
+	MsgExpiringCredentialsFailed          = "expiring_credentials_failed"
+	MsgLocaleUpdated                      = "locale_updated"
+	MsgLocaleUpdateFailed                 = "locale_update_failed"
+)
+
+// Reports
+const (
+	MsgComplianceReportRetrieved = "compliance_report_retrieved"
+	MsgComplianceReportFailed    = "compliance_report_failed"
+)
+
+// Webhooks
+const (
+	MsgWebhooksRetrieved     = "webhooks_retrieved"
+	MsgWebhooksFailed        = "webhooks_failed"
+	MsgWebhookRetrieved      = "webhook_retrieved"
+	MsgWebhookFailed         = "webhook_failed"
+	MsgWebhookCreated        = "webhook_created"
+	MsgWebhookCreateFailed   = "webhook_create_failed"
+	MsgWebhookUpdated        = "webhook_updated"
+	MsgWebhookUpdateFailed   = "webhook_update_failed"
+	MsgWebhookDeleted        = "webhook_deleted"
+	MsgWebhookDeleteFailed   = "webhook_delete_failed"
+	MsgWebhookPinged         = "webhook_pinged"
+	MsgWebhookPingFailed     = "webhook_ping_failed"
+	MsgDeadLettersRetrieved  = "dead_letters_retrieved"
+	MsgDeadLettersFailed     = "dead_letters_failed"
+	MsgDeadLetterRetried     = "dead_letter_retried"
+	MsgDeadLetterRetryFailed = "dead_letter_retry_failed"
+	MsgInvalidWebhookID      = "invalid_webhook_id"
+	MsgInvalidDeliveryID     = "invalid_delivery_id"
+)
+
+// Notifications
+const (
+	MsgNotificationPreferencesRetrieved    = "notification_preferences_retrieved"
+	MsgNotificationPreferencesFailed       = "notification_preferences_failed"
+	MsgNotificationPreferencesUpdated      = "notification_preferences_updated"
+	MsgNotificationPreferencesUpdateFailed = "notification_preferences_update_failed"
+
+	MsgNotifyVisitStartingTitle   = "notify_visit_starting_title"
+	MsgNotifyVisitStartingBody    = "notify_visit_starting_body"
+	MsgNotifyClockOutMissingTitle = "notify_clock_out_missing_title"
+	MsgNotifyClockOutMissingBody  = "notify_clock_out_missing_body"
+	MsgNotifyVisitReassignedTitle = "notify_visit_reassigned_title"
+	MsgNotifyVisitReassignedBody  = "notify_visit_reassigned_body"
+)
+
+// Escalation alerts
+const (
+	MsgAlertsRetrieved          = "alerts_retrieved"
+	MsgAlertsFailed             = "alerts_failed"
+	MsgAlertAcknowledged        = "alert_acknowledged"
+	MsgAlertAcknowledgeFailed   = "alert_acknowledge_failed"
+	MsgInvalidAlertID           = "invalid_alert_id"
+	MsgRiskLevelsRetrieved      = "risk_levels_retrieved"
+	MsgRiskLevelsFailed         = "risk_levels_failed"
+	MsgRiskLevelUpdated         = "risk_level_updated"
+	MsgRiskLevelUpdateFailed    = "risk_level_update_failed"
+	MsgAlertVisitLateTitle      = "alert_visit_late_title"
+	MsgAlertVisitLateBody       = "alert_visit_late_body"
+	MsgAlertVisitUnassignedBody = "alert_visit_unassigned_body"
+)
+
+// Task corrections
+const (
+	MsgCorrectionRequested     = "correction_requested"
+	MsgCorrectionRequestFailed = "correction_request_failed"
+	MsgCorrectionsRetrieved    = "corrections_retrieved"
+	MsgCorrectionsFailed       = "corrections_failed"
+	MsgCorrectionApproved      = "correction_approved"
+	MsgCorrectionRejected      = "correction_rejected"
+	MsgCorrectionReviewFailed  = "correction_review_failed"
+	MsgInvalidCorrectionID     = "invalid_correction_id"
+)
+
+// Visit stream
+const (
+	MsgStreamFailed         = "stream_failed"
+	MsgInvalidStreamFilters = "invalid_stream_filters"
+	MsgInvalidLastEventID   = "invalid_last_event_id"
+)
+
+// Exception review
+const (
+	MsgExceptionsRetrieved     = "exceptions_retrieved"
+	MsgExceptionsFailed        = "exceptions_failed"
+	MsgExceptionReviewed       = "exception_reviewed"
+	MsgExceptionReviewFailed   = "exception_review_failed"
+	MsgInvalidExceptionFilters = "invalid_exception_filters"
+)
+
+// Compliance notes and warnings
+const (
+	MsgClockInLateNote             = "clock_in_late_note"
+	MsgClockInLateWarning          = "clock_in_late_warning"
+	MsgClockInBeforeWindowNote     = "clock_in_before_window_note"
+	MsgClockInAfterWindowNote      = "clock_in_after_window_note"
+	MsgClockInOutsideWindowWarning = "clock_in_outside_window_warning"
+	MsgVisitTooShortNote           = "visit_too_short_note"
+	MsgVisitTooLongNote            = "visit_too_long_note"
+	MsgDurationVarianceNote        = "duration_variance_note"
+	MsgDurationReasonNote          = "duration_reason_note"
+	MsgVisitDurationWarning        = "visit_duration_warning"
+	MsgEarlyDepartureNote          = "early_departure_note"
+	MsgEarlyDepartureWarning       = "early_departure_warning"
+	MsgLocationExceededNote        = "location_exceeded_note"
+	MsgLocationWarningNote         = "location_warning_note"
+	MsgLocationWarning             = "location_warning"
+	MsgCredentialsMissingNote      = "credentials_missing_note"
+	MsgCredentialsMissingWarning   = "credentials_missing_warning"
+	MsgClientUnableToSignNote      = "client_unable_to_sign_note"
+	MsgAttestationMissingNote      = "attestation_missing_note"
+	MsgAttestationMissingWarning   = "attestation_missing_warning"
+	MsgTasksPendingNote            = "tasks_pending_note"
+	MsgTasksPendingWarning         = "tasks_pending_warning"
+)
+
+// Scheduling conflicts and caregiver matching
+const (
+	MsgConflictOverlap       = "conflict_overlap"
+	MsgConflictTimeOff       = "conflict_time_off"
+	MsgConflictUnavailable   = "conflict_unavailable"
+	MsgConflictMaxDailyHours = "conflict_max_daily_hours"
+	MsgConflictTravelTime    = "conflict_travel_time"
+	MsgMatchNoSkillsRequired = "match_no_skills_required"
+	MsgMatchMissingSkills    = "match_missing_skills"
+	MsgMatchHasSkills        = "match_has_skills"
+	MsgMatchAvailable        = "match_available"
+	MsgMatchConflicts        = "match_conflicts"
+	MsgMatchLoad             = "match_load"
+	MsgMatchNoEarlierVisit   = "match_no_earlier_visit"
+	MsgMatchDistance         = "match_distance"
+	MsgMatchContinuity       = "match_continuity"
+)
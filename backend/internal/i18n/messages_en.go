@@ -0,0 +1,203 @@
+package i18n
+
+// english is the fallback for keys missing from another locale. Error codes are not listed here,
+// the English text of an error is its sentinel's message in internal/models.
+var english = map[string]string{
+	MsgInvalidRequestBody:  "Invalid request body",
+	MsgInvalidScheduleID:   "Invalid schedule ID",
+	MsgInvalidUserID:       "Invalid user ID",
+	MsgInvalidTaskID:       "Invalid task ID",
+	MsgInvalidNoteID:       "Invalid note ID",
+	MsgInvalidAttachmentID: "Invalid attachment ID",
+	MsgInvalidTimeOffID:    "Invalid time off ID",
+	MsgInvalidCredentialID: "Invalid credential ID",
+	MsgInvalidDays:         "Invalid days",
+	MsgInvalidGeoLocation:  "Invalid geo location",
+	MsgTaskStatusInvalid:   "Error validating task status",
+
+	MsgTodaySchedulesRetrieved:         "Today's schedules retrieved successfully",
+	MsgTodaySchedulesFailed:            "Failed to fetch today's schedules",
+	MsgSchedulesRetrieved:              "Schedules retrieved successfully",
+	MsgSchedulesFailed:                 "Failed to fetch schedules",
+	MsgScheduleDetailsRetrieved:        "Schedule details retrieved successfully",
+	MsgScheduleDetailsFailed:           "Failed to get schedule details",
+	MsgClockedIn:                       "Clocked in successfully",
+	MsgClockedInWithWarnings:           "Clocked in with warnings",
+	MsgClockInFailed:                   "Failed to clock in",
+	MsgClockedOut:                      "Clocked out successfully",
+	MsgClockedOutWithWarnings:          "Clocked out with warnings",
+	MsgClockOutFailed:                  "Failed to clock out",
+	MsgScheduleCreated:                 "Schedule created successfully",
+	MsgScheduleCreateFailed:            "Failed to create schedule",
+	MsgScheduleAssigned:                "Schedule assigned successfully",
+	MsgScheduleAssignFailed:            "Failed to assign schedule",
+	MsgTaskUpdated:                     "Task updated successfully",
+	MsgTaskUpdateFailed:                "Failed to update task",
+	MsgTasksUpdated:                    "Tasks updated successfully",
+	MsgTasksUpdateFailed:               "Failed to update tasks",
+	MsgTaskHistoryRetrieved:            "Task history retrieved successfully",
+	MsgTaskHistoryFailed:               "Failed to get task history",
+	MsgNotesRetrieved:                  "Notes retrieved successfully",
+	MsgNotesFailed:                     "Failed to get notes",
+	MsgNoteAdded:                       "Note added successfully",
+	MsgNoteAddFailed:                   "Failed to add note",
+	MsgNoteUpdated:                     "Note updated successfully",
+	MsgNoteUpdateFailed:                "Failed to update note",
+	MsgAttachmentsRetrieved:            "Attachments retrieved successfully",
+	MsgAttachmentsFailed:               "Failed to get attachments",
+	MsgAttachmentRetrieved:             "Attachment retrieved successfully",
+	MsgAttachmentFailed:                "Failed to get attachment",
+	MsgAttachmentUploaded:              "Attachment uploaded successfully",
+	MsgAttachmentUploadFailed:          "Failed to upload attachment",
+	MsgAttachmentDeleted:               "Attachment deleted successfully",
+	MsgAttachmentDeleteFailed:          "Failed to delete attachment",
+	MsgAttachmentDownloadFailed:        "Failed to download attachment",
+	MsgItineraryRetrieved:              "Itinerary retrieved successfully",
+	MsgItineraryFailed:                 "Failed to get itinerary",
+	MsgMileageReportRetrieved:          "Mileage report retrieved successfully",
+	MsgMileageReportFailed:             "Failed to get mileage report",
+	MsgCaregiverMatchesRetrieved:       "Caregiver matches retrieved successfully",
+	MsgCaregiverMatchesFailed:          "Failed to match caregivers",
+	MsgSkillsRetrieved:                 "Skills retrieved successfully",
+	MsgSkillsFailed:                    "Failed to get skills",
+	MsgSkillsUpdated:                   "Skills updated successfully",
+	MsgSkillsUpdateFailed:              "Failed to set skills",
+	MsgServiceRequirementsRetrieved:    "Service requirements retrieved successfully",
+	MsgServiceRequirementsFailed:       "Failed to get service requirements",
+	MsgServiceRequirementsUpdated:      "Service requirements updated successfully",
+	MsgServiceRequirementsUpdateFailed: "Failed to set service requirements",
+
+	MsgAvailabilityRetrieved:              "Availability retrieved successfully",
+	MsgAvailabilityFailed:                 "Failed to get availability",
+	MsgAvailabilityUpdated:                "Availability updated successfully",
+	MsgAvailabilityUpdateFailed:           "Failed to set availability",
+	MsgTimeOffsRetrieved:                  "Time off requests retrieved successfully",
+	MsgTimeOffsFailed:                     "Failed to get time off requests",
+	MsgTimeOffRequested:                   "Time off requested successfully",
+	MsgTimeOffRequestFailed:               "Failed to request time off",
+	MsgTimeOffReviewed:                    "Time off reviewed successfully",
+	MsgTimeOffReviewFailed:                "Failed to review time off",
+	MsgCredentialTypesRetrieved:           "Credential types retrieved successfully",
+	MsgCredentialTypesFailed:              "Failed to get credential types",
+	MsgCredentialTypeCreated:              "Credential type created successfully",
+	MsgCredentialTypeCreateFailed:         "Failed to create credential type",
+	MsgCredentialsRetrieved:               "Credentials retrieved successfully",
+	MsgCredentialsFailed:                  "Failed to get credentials",
+	MsgCredentialAdded:                    "Credential added successfully",
+	MsgCredentialAddFailed:                "Failed to add credential",
+	MsgCredentialRemoved:                  "Credential removed successfully",
+	MsgCredentialRemoveFailed:             "Failed to remove credential",
+	MsgCredentialRequirementsRetrieved:    "Credential requirements retrieved successfully",
+	MsgCredentialRequirementsFailed:       "Failed to get credential requirements",
+	MsgCredentialRequirementsUpdated:      "Credential requirements updated successfully",
+	MsgCredentialRequirementsUpdateFailed: "Failed to set credential requirements",
+	MsgExpiringCredentialsRetrieved:       "Expiring credentials retrieved successfully",
+	MsgExpiringCredentialsFailed:          "Failed to get expiring credentials",
+	MsgLocaleUpdated:                      "Language preference updated successfully",
+	MsgLocaleUpdateFailed:                 "Failed to update language preference",
+
+	MsgComplianceReportRetrieved: "Compliance report retrieved successfully",
+	MsgComplianceReportFailed:    "Failed to get compliance report",
+
+	MsgWebhooksRetrieved:     "Webhooks retrieved successfully",
+	MsgWebhooksFailed:        "Failed to get webhooks",
+	MsgWebhookRetrieved:      "Webhook retrieved successfully",
+	MsgWebhookFailed:         "Failed to get webhook",
+	MsgWebhookCreated:        "Webhook created successfully",
+	MsgWebhookCreateFailed:   "Failed to create webhook",
+	MsgWebhookUpdated:        "Webhook updated successfully",
+	MsgWebhookUpdateFailed:   "Failed to update webhook",
+	MsgWebhookDeleted:        "Webhook deleted successfully",
+	MsgWebhookDeleteFailed:   "Failed to delete webhook",
+	MsgWebhookPinged:         "Test event queued for delivery",
+	MsgWebhookPingFailed:     "Failed to queue test event",
+	MsgDeadLettersRetrieved:  "Dead letters retrieved successfully",
+	MsgDeadLettersFailed:     "Failed to get dead letters",
+	MsgDeadLetterRetried:     "Delivery queued for retry",
+	MsgDeadLetterRetryFailed: "Failed to retry delivery",
+	MsgInvalidWebhookID:      "Invalid webhook ID",
+	MsgInvalidDeliveryID:     "Invalid delivery ID",
+
+	MsgNotificationPreferencesRetrieved:    "Preferences retrieved successfully",
+	MsgNotificationPreferencesFailed:       "Failed to get notification preferences",
+	MsgNotificationPreferencesUpdated:      "Preferences updated successfully",
+	MsgNotificationPreferencesUpdateFailed: "Failed to update notification preferences",
+
+	MsgNotifyVisitStartingTitle:   "Visit starts in {minutes} minutes",
+	MsgNotifyVisitStartingBody:    "Your {service} visit with {client} starts at {time} at {location}.",
+	MsgNotifyClockOutMissingTitle: "You haven't clocked out",
+	MsgNotifyClockOutMissingBody:  "Your visit with {client} ended at {time}. Please clock out now.",
+	MsgNotifyVisitReassignedTitle: "Visit assigned to you",
+	MsgNotifyVisitReassignedBody:  "You have been assigned a {service} visit with {client} on {date} at {time}, {location}.",
+
+	MsgAlertsRetrieved:        "Alerts retrieved successfully",
+	MsgAlertsFailed:           "Failed to get alerts",
+	MsgAlertAcknowledged:      "Alert acknowledged successfully",
+	MsgAlertAcknowledgeFailed: "Failed to acknowledge alert",
+	MsgInvalidAlertID:         "Invalid alert ID",
+	MsgRiskLevelsRetrieved:    "Risk levels retrieved successfully",
+	MsgRiskLevelsFailed:       "Failed to get risk levels",
+	MsgRiskLevelUpdated:       "Risk level updated successfully",
+	MsgRiskLevelUpdateFailed:  "Failed to update risk level",
+
+	MsgAlertVisitLateTitle:      "Late visit for {client} ({risk} risk)",
+	MsgAlertVisitLateBody:       "{caregiver} has not clocked in to the {service} visit with {client} at {location}, which started at {time} ({minutes} minutes ago). Acknowledge alert {id} to stop escalation.",
+	MsgAlertVisitUnassignedBody: "Nobody is assigned to the {service} visit with {client} at {location}, which started at {time} ({minutes} minutes ago). Acknowledge alert {id} to stop escalation.",
+
+	MsgCorrectionRequested:     "Correction submitted for supervisor approval",
+	MsgCorrectionRequestFailed: "Failed to request correction",
+	MsgCorrectionsRetrieved:    "Corrections retrieved successfully",
+	MsgCorrectionsFailed:       "Failed to get corrections",
+	MsgCorrectionApproved:      "Correction approved and applied",
+	MsgCorrectionRejected:      "Correction rejected",
+	MsgCorrectionReviewFailed:  "Failed to review correction",
+	MsgInvalidCorrectionID:     "Invalid correction ID",
+
+	MsgStreamFailed:         "Failed to open the visit stream",
+	MsgInvalidStreamFilters: "Invalid stream filters",
+	MsgInvalidLastEventID:   "Invalid Last-Event-ID header",
+
+	MsgExceptionsRetrieved:     "Exceptions retrieved successfully",
+	MsgExceptionsFailed:        "Failed to get exceptions",
+	MsgExceptionReviewed:       "Exception reviewed successfully",
+	MsgExceptionReviewFailed:   "Failed to review exception",
+	MsgInvalidExceptionFilters: "Invalid exception filters",
+
+	MsgClockInLateNote:             "Clocked in {late} after shift start",
+	MsgClockInLateWarning:          "You clocked in {late} late",
+	MsgClockInBeforeWindowNote:     "Clocked in {outside} before the clock-in window opened",
+	MsgClockInAfterWindowNote:      "Clocked in {outside} after the clock-in window closed",
+	MsgClockInOutsideWindowWarning: "You clocked in outside the allowed clock-in window",
+	MsgVisitTooShortNote:           "Visit lasted {actual}, under the {limit} minimum",
+	MsgVisitTooLongNote:            "Visit lasted {actual}, over the {limit} maximum",
+	MsgDurationVarianceNote:        "Visit lasted {actual} against {scheduled} scheduled (allowed variance {limit})",
+	MsgDurationReasonNote:          "reason: {reason}",
+	MsgVisitDurationWarning:        "Your visit lasted {actual} and will be reviewed",
+	MsgEarlyDepartureNote:          "Left after {actual} of {scheduled} scheduled: {reason}",
+	MsgEarlyDepartureWarning:       "Early departure recorded, the visit will be reviewed by a supervisor",
+	MsgLocationExceededNote:        "Distance from scheduled location: {distance} (exceeds {limit} limit)",
+	MsgLocationWarningNote:         "Distance from scheduled location: {distance} (warning threshold)",
+	MsgLocationWarning:             "You are {distance} away from the scheduled location",
+	MsgCredentialsMissingNote:      "Missing valid credentials for {service}: {credentials}",
+	MsgCredentialsMissingWarning:   "You are missing required credentials: {credentials}",
+	MsgClientUnableToSignNote:      "Client unable to sign: {reason}",
+	MsgAttestationMissingNote:      "Visit clocked out without client attestation",
+	MsgAttestationMissingWarning:   "Client attestation was not captured for this visit",
+	MsgTasksPendingNote:            "Required tasks left pending: {tasks}",
+	MsgTasksPendingWarning:         "{count} required task(s) were not completed",
+
+	MsgConflictOverlap:       "Overlaps with visit for {client} from {start} to {end}",
+	MsgConflictTimeOff:       "Caregiver has approved time off from {start} to {end}",
+	MsgConflictUnavailable:   "Visit falls outside the caregiver's availability windows",
+	MsgConflictMaxDailyHours: "Visits would total {total} for the day (limit {limit})",
+	MsgConflictTravelTime:    "Travel of {distance} needs about {travel} but only {gap} is available between visits",
+	MsgMatchNoSkillsRequired: "No skills required for {service}",
+	MsgMatchMissingSkills:    "Missing required skills: {skills}",
+	MsgMatchHasSkills:        "Has all required skills: {skills}",
+	MsgMatchAvailable:        "Available for the whole visit",
+	MsgMatchConflicts:        "{count} scheduling conflict(s)",
+	MsgMatchLoad:             "{booked} already booked that day",
+	MsgMatchNoEarlierVisit:   "No earlier visit that day",
+	MsgMatchDistance:         "{distance} from previous visit with {client}",
+	MsgMatchContinuity:       "{count} completed visit(s) with {client}",
+}
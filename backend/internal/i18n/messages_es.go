@@ -0,0 +1,286 @@
+package i18n
+
+var spanish = map[string]string{
+	MsgInvalidRequestBody:  "Cuerpo de la solicitud no válido",
+	MsgInvalidScheduleID:   "ID de visita no válido",
+	MsgInvalidUserID:       "ID de usuario no válido",
+	MsgInvalidTaskID:       "ID de tarea no válido",
+	MsgInvalidNoteID:       "ID de nota no válido",
+	MsgInvalidAttachmentID: "ID de archivo adjunto no válido",
+	MsgInvalidTimeOffID:    "ID de ausencia no válido",
+	MsgInvalidCredentialID: "ID de credencial no válido",
+	MsgInvalidDays:         "Número de días no válido",
+	MsgInvalidGeoLocation:  "Ubicación geográfica no válida",
+	MsgTaskStatusInvalid:   "Error al validar el estado de la tarea",
+
+	MsgTodaySchedulesRetrieved:         "Visitas de hoy obtenidas correctamente",
+	MsgTodaySchedulesFailed:            "No se pudieron obtener las visitas de hoy",
+	MsgSchedulesRetrieved:              "Visitas obtenidas correctamente",
+	MsgSchedulesFailed:                 "No se pudieron obtener las visitas",
+	MsgScheduleDetailsRetrieved:        "Detalles de la visita obtenidos correctamente",
+	MsgScheduleDetailsFailed:           "No se pudieron obtener los detalles de la visita",
+	MsgClockedIn:                       "Entrada registrada correctamente",
+	MsgClockedInWithWarnings:           "Entrada registrada con advertencias",
+	MsgClockInFailed:                   "No se pudo registrar la entrada",
+	MsgClockedOut:                      "Salida registrada correctamente",
+	MsgClockedOutWithWarnings:          "Salida registrada con advertencias",
+	MsgClockOutFailed:                  "No se pudo registrar la salida",
+	MsgScheduleCreated:                 "Visita creada correctamente",
+	MsgScheduleCreateFailed:            "No se pudo crear la visita",
+	MsgScheduleAssigned:                "Visita asignada correctamente",
+	MsgScheduleAssignFailed:            "No se pudo asignar la visita",
+	MsgTaskUpdated:                     "Tarea actualizada correctamente",
+	MsgTaskUpdateFailed:                "No se pudo actualizar la tarea",
+	MsgTasksUpdated:                    "Tareas actualizadas correctamente",
+	MsgTasksUpdateFailed:               "No se pudieron actualizar las tareas",
+	MsgTaskHistoryRetrieved:            "Historial de la tarea obtenido correctamente",
+	MsgTaskHistoryFailed:               "No se pudo obtener el historial de la tarea",
+	MsgNotesRetrieved:                  "Notas obtenidas correctamente",
+	MsgNotesFailed:                     "No se pudieron obtener las notas",
+	MsgNoteAdded:                       "Nota agregada correctamente",
+	MsgNoteAddFailed:                   "No se pudo agregar la nota",
+	MsgNoteUpdated:                     "Nota actualizada correctamente",
+	MsgNoteUpdateFailed:                "No se pudo actualizar la nota",
+	MsgAttachmentsRetrieved:            "Archivos adjuntos obtenidos correctamente",
+	MsgAttachmentsFailed:               "No se pudieron obtener los archivos adjuntos",
+	MsgAttachmentRetrieved:             "Archivo adjunto obtenido correctamente",
+	MsgAttachmentFailed:                "No se pudo obtener el archivo adjunto",
+	MsgAttachmentUploaded:              "Archivo adjunto subido correctamente",
+	MsgAttachmentUploadFailed:          "No se pudo subir el archivo adjunto",
+	MsgAttachmentDeleted:               "Archivo adjunto eliminado correctamente",
+	MsgAttachmentDeleteFailed:          "No se pudo eliminar el archivo adjunto",
+	MsgAttachmentDownloadFailed:        "No se pudo descargar el archivo adjunto",
+	MsgItineraryRetrieved:              "Itinerario obtenido correctamente",
+	MsgItineraryFailed:                 "No se pudo obtener el itinerario",
+	MsgMileageReportRetrieved:          "Informe de kilometraje obtenido correctamente",
+	MsgMileageReportFailed:             "No se pudo obtener el informe de kilometraje",
+	MsgCaregiverMatchesRetrieved:       "Cuidadores compatibles obtenidos correctamente",
+	MsgCaregiverMatchesFailed:          "No se pudieron buscar cuidadores compatibles",
+	MsgSkillsRetrieved:                 "Habilidades obtenidas correctamente",
+	MsgSkillsFailed:                    "No se pudieron obtener las habilidades",
+	MsgSkillsUpdated:                   "Habilidades actualizadas correctamente",
+	MsgSkillsUpdateFailed:              "No se pudieron actualizar las habilidades",
+	MsgServiceRequirementsRetrieved:    "Requisitos del servicio obtenidos correctamente",
+	MsgServiceRequirementsFailed:       "No se pudieron obtener los requisitos del servicio",
+	MsgServiceRequirementsUpdated:      "Requisitos del servicio actualizados correctamente",
+	MsgServiceRequirementsUpdateFailed: "No se pudieron actualizar los requisitos del servicio",
+
+	MsgAvailabilityRetrieved:              "Disponibilidad obtenida correctamente",
+	MsgAvailabilityFailed:                 "No se pudo obtener la disponibilidad",
+	MsgAvailabilityUpdated:                "Disponibilidad actualizada correctamente",
+	MsgAvailabilityUpdateFailed:           "No se pudo actualizar la disponibilidad",
+	MsgTimeOffsRetrieved:                  "Solicitudes de ausencia obtenidas correctamente",
+	MsgTimeOffsFailed:                     "No se pudieron obtener las solicitudes de ausencia",
+	MsgTimeOffRequested:                   "Ausencia solicitada correctamente",
+	MsgTimeOffRequestFailed:               "No se pudo solicitar la ausencia",
+	MsgTimeOffReviewed:                    "Ausencia revisada correctamente",
+	MsgTimeOffReviewFailed:                "No se pudo revisar la ausencia",
+	MsgCredentialTypesRetrieved:           "Tipos de credencial obtenidos correctamente",
+	MsgCredentialTypesFailed:              "No se pudieron obtener los tipos de credencial",
+	MsgCredentialTypeCreated:              "Tipo de credencial creado correctamente",
+	MsgCredentialTypeCreateFailed:         "No se pudo crear el tipo de credencial",
+	MsgCredentialsRetrieved:               "Credenciales obtenidas correctamente",
+	MsgCredentialsFailed:                  "No se pudieron obtener las credenciales",
+	MsgCredentialAdded:                    "Credencial agregada correctamente",
+	MsgCredentialAddFailed:                "No se pudo agregar la credencial",
+	MsgCredentialRemoved:                  "Credencial eliminada correctamente",
+	MsgCredentialRemoveFailed:             "No se pudo eliminar la credencial",
+	MsgCredentialRequirementsRetrieved:    "Requisitos de credenciales obtenidos correctamente",
+	MsgCredentialRequirementsFailed:       "No se pudieron obtener los requisitos de credenciales",
+	MsgCredentialRequirementsUpdated:      "Requisitos de credenciales actualizados correctamente",
+	MsgCredentialRequirementsUpdateFailed: "No se pudieron actualizar los requisitos de credenciales",
+	MsgExpiringCredentialsRetrieved:       "Credenciales por vencer obtenidas correctamente",
+	MsgExpiringCredentialsFailed:          "No se pudieron obtener las credenciales por vencer",
+	MsgLocaleUpdated:                      "Preferencia de idioma actualizada correctamente",
+	MsgLocaleUpdateFailed:                 "No se pudo actualizar la preferencia de idioma",
+
+	MsgComplianceReportRetrieved: "Informe de cumplimiento obtenido correctamente",
+	MsgComplianceReportFailed:    "No se pudo obtener el informe de cumplimiento",
+
+	MsgWebhooksRetrieved:     "Webhooks obtenidos correctamente",
+	MsgWebhooksFailed:        "No se pudieron obtener los webhooks",
+	MsgWebhookRetrieved:      "Webhook obtenido correctamente",
+	MsgWebhookFailed:         "No se pudo obtener el webhook",
+	MsgWebhookCreated:        "Webhook creado correctamente",
+	MsgWebhookCreateFailed:   "No se pudo crear el webhook",
+	MsgWebhookUpdated:        "Webhook actualizado correctamente",
+	MsgWebhookUpdateFailed:   "No se pudo actualizar el webhook",
+	MsgWebhookDeleted:        "Webhook eliminado correctamente",
+	MsgWebhookDeleteFailed:   "No se pudo eliminar el webhook",
+	MsgWebhookPinged:         "Evento de prueba en cola para su entrega",
+	MsgWebhookPingFailed:     "No se pudo poner en cola el evento de prueba",
+	MsgDeadLettersRetrieved:  "Entregas fallidas obtenidas correctamente",
+	MsgDeadLettersFailed:     "No se pudieron obtener las entregas fallidas",
+	MsgDeadLetterRetried:     "Entrega en cola para reintento",
+	MsgDeadLetterRetryFailed: "No se pudo reintentar la entrega",
+	MsgInvalidWebhookID:      "ID de webhook no válido",
+	MsgInvalidDeliveryID:     "ID de entrega no válido",
+
+	MsgNotificationPreferencesRetrieved:    "Preferencias obtenidas correctamente",
+	MsgNotificationPreferencesFailed:       "No se pudieron obtener las preferencias de notificación",
+	MsgNotificationPreferencesUpdated:      "Preferencias actualizadas correctamente",
+	MsgNotificationPreferencesUpdateFailed: "No se pudieron actualizar las preferencias de notificación",
+
+	MsgNotifyVisitStartingTitle:   "La visita empieza en {minutes} minutos",
+	MsgNotifyVisitStartingBody:    "Su visita de {service} con {client} empieza a las {time} en {location}.",
+	MsgNotifyClockOutMissingTitle: "No ha registrado su salida",
+	MsgNotifyClockOutMissingBody:  "Su visita con {client} terminó a las {time}. Registre su salida ahora.",
+	MsgNotifyVisitReassignedTitle: "Visita asignada a usted",
+	MsgNotifyVisitReassignedBody:  "Se le asignó una visita de {service} con {client} el {date} a las {time}, {location}.",
+
+	MsgAlertsRetrieved:        "Alertas obtenidas correctamente",
+	MsgAlertsFailed:           "No se pudieron obtener las alertas",
+	MsgAlertAcknowledged:      "Alerta confirmada correctamente",
+	MsgAlertAcknowledgeFailed: "No se pudo confirmar la alerta",
+	MsgInvalidAlertID:         "ID de alerta no válido",
+	MsgRiskLevelsRetrieved:    "Niveles de riesgo obtenidos correctamente",
+	MsgRiskLevelsFailed:       "No se pudieron obtener los niveles de riesgo",
+	MsgRiskLevelUpdated:       "Nivel de riesgo actualizado correctamente",
+	MsgRiskLevelUpdateFailed:  "No se pudo actualizar el nivel de riesgo",
+
+	MsgAlertVisitLateTitle:      "Visita atrasada para {client} (riesgo {risk})",
+	MsgAlertVisitLateBody:       "{caregiver} no ha registrado su entrada a la visita de {service} con {client} en {location}, que empezó a las {time} (hace {minutes} minutos). Confirme la alerta {id} para detener el escalamiento.",
+	MsgAlertVisitUnassignedBody: "Nadie está asignado a la visita de {service} con {client} en {location}, que empezó a las {time} (hace {minutes} minutos). Confirme la alerta {id} para detener el escalamiento.",
+
+	MsgCorrectionRequested:     "Corrección enviada para la aprobación de un supervisor",
+	MsgCorrectionRequestFailed: "No se pudo solicitar la corrección",
+	MsgCorrectionsRetrieved:    "Correcciones obtenidas correctamente",
+	MsgCorrectionsFailed:       "No se pudieron obtener las correcciones",
+	MsgCorrectionApproved:      "Corrección aprobada y aplicada",
+	MsgCorrectionRejected:      "Corrección rechazada",
+	MsgCorrectionReviewFailed:  "No se pudo revisar la corrección",
+	MsgInvalidCorrectionID:     "ID de corrección no válido",
+
+	MsgStreamFailed:         "No se pudo abrir el flujo de visitas",
+	MsgInvalidStreamFilters: "Filtros del flujo no válidos",
+	MsgInvalidLastEventID:   "Encabezado Last-Event-ID no válido",
+
+	MsgExceptionsRetrieved:     "Excepciones obtenidas correctamente",
+	MsgExceptionsFailed:        "No se pudieron obtener las excepciones",
+	MsgExceptionReviewed:       "Excepción revisada correctamente",
+	MsgExceptionReviewFailed:   "No se pudo revisar la excepción",
+	MsgInvalidExceptionFilters: "Filtros de excepciones no válidos",
+
+	MsgClockInLateNote:             "Entrada registrada {late} después del inicio del turno",
+	MsgClockInLateWarning:          "Registró la entrada con {late} de retraso",
+	MsgClockInBeforeWindowNote:     "Entrada registrada {outside} antes de que abriera el horario de entrada",
+	MsgClockInAfterWindowNote:      "Entrada registrada {outside} después de que cerrara el horario de entrada",
+	MsgClockInOutsideWindowWarning: "Registró la entrada fuera del horario de entrada permitido",
+	MsgVisitTooShortNote:           "La visita duró {actual}, menos del mínimo de {limit}",
+	MsgVisitTooLongNote:            "La visita duró {actual}, más del máximo de {limit}",
+	MsgDurationVarianceNote:        "La visita duró {actual} frente a {scheduled} programados (variación permitida {limit})",
+	MsgDurationReasonNote:          "motivo: {reason}",
+	MsgVisitDurationWarning:        "Su visita duró {actual} y será revisada",
+	MsgEarlyDepartureNote:          "Salió tras {actual} de {scheduled} programados: {reason}",
+	MsgEarlyDepartureWarning:       "Salida anticipada registrada, un supervisor revisará la visita",
+	MsgLocationExceededNote:        "Distancia a la ubicación programada: {distance} (supera el límite de {limit})",
+	MsgLocationWarningNote:         "Distancia a la ubicación programada: {distance} (umbral de advertencia)",
+	MsgLocationWarning:             "Está a {distance} de la ubicación programada",
+	MsgCredentialsMissingNote:      "Faltan credenciales válidas para {service}: {credentials}",
+	MsgCredentialsMissingWarning:   "Le faltan credenciales requeridas: {credentials}",
+	MsgClientUnableToSignNote:      "El cliente no pudo firmar: {reason}",
+	MsgAttestationMissingNote:      "Salida registrada sin la confirmación del cliente",
+	MsgAttestationMissingWarning:   "No se registró la confirmación del cliente para esta visita",
+	MsgTasksPendingNote:            "Tareas obligatorias pendientes: {tasks}",
+	MsgTasksPendingWarning:         "Tareas obligatorias sin completar: {count}",
+
+	MsgConflictOverlap:       "Se superpone con la visita de {client} de {start} a {end}",
+	MsgConflictTimeOff:       "El cuidador tiene una ausencia aprobada de {start} a {end}",
+	MsgConflictUnavailable:   "La visita está fuera de los horarios de disponibilidad del cuidador",
+	MsgConflictMaxDailyHours: "Las visitas sumarían {total} en el día (límite {limit})",
+	MsgConflictTravelTime:    "El traslado de {distance} requiere unos {travel}, pero solo hay {gap} entre visitas",
+	MsgMatchNoSkillsRequired: "No se requieren habilidades para {service}",
+	MsgMatchMissingSkills:    "Faltan habilidades requeridas: {skills}",
+	MsgMatchHasSkills:        "Tiene todas las habilidades requeridas: {skills}",
+	MsgMatchAvailable:        "Disponible durante toda la visita",
+	MsgMatchConflicts:        "Conflictos de horario: {count}",
+	MsgMatchLoad:             "{booked} ya reservado ese día",
+	MsgMatchNoEarlierVisit:   "Sin visitas previas ese día",
+	MsgMatchDistance:         "A {distance} de la visita anterior con {client}",
+	MsgMatchContinuity:       "Visitas completadas con {client}: {count}",
+
+	"SCHEDULE_NOT_FOUND":          "No se encontró la visita",
+	"VISIT_ALREADY_STARTED":       "La visita ya comenzó",
+	"LOCATION_TOO_FAR":            "La ubicación está demasiado lejos de la ubicación programada",
+	"CLOCK_IN_TOO_EARLY":          "Es demasiado pronto para registrar la entrada, el horario de entrada aún no ha comenzado",
+	"CLOCK_IN_TOO_LATE":           "Es demasiado tarde para registrar la entrada, el horario de entrada ya terminó",
+	"VISIT_NOT_STARTED":           "La visita no ha comenzado, no puede registrar la salida",
+	"VISIT_ALREADY_ENDED":         "La visita ya terminó",
+	"CLOCK_OUT_TOO_EARLY":         "No puede registrar la salida antes del tiempo mínimo de visita",
+	"VISIT_TOO_LONG":              "La visita supera el tiempo máximo de visita",
+	"DURATION_VARIANCE_EXCEEDED":  "La duración de la visita difiere demasiado de la duración programada",
+	"DURATION_REASON_REQUIRED":    "Se requiere un motivo para registrar la salida con esta duración de visita",
+	"INVALID_EARLY_DEPARTURE":     "La salida anticipada necesita un código de motivo válido y una nota cuando el motivo es otro",
+	"ATTESTATION_REQUIRED":        "Se requiere la confirmación del cliente para registrar la salida",
+	"INVALID_ATTESTATION":         "La confirmación necesita una firma con nombre y relación del firmante, o el motivo por el que el cliente no puede firmar",
+	"INVALID_SIGNATURE":           "La firma debe tener trazos o ser una imagen PNG en base64",
+	"SIGNATURE_TOO_LARGE":         "La imagen de la firma es demasiado grande",
+	"INVALID_SIGNER_RELATIONSHIP": "La relación del firmante debe ser cliente, familiar, tutor u otra",
+	"TASK_NOT_FOUND":              "No se encontró la tarea",
+	"INVALID_TASK_STATUS":         "Estado de tarea no válido",
+	"REASON_REQUIRED":             "Se requiere un motivo para las tareas no completadas",
+	"TASK_ALREADY_UPDATED":        "Otra solicitud actualizó la tarea, vuelva a cargar e inténtelo de nuevo",
+	"VISIT_NOT_IN_PROGRESS":       "No puede actualizar tareas, la visita no está en curso",
+	"CORRECTION_REASON_REQUIRED":  "Se requiere un motivo de corrección para cambiar el resultado de una tarea",
+	"CORRECTION_WINDOW_CLOSED":    "Ya no se puede corregir el resultado de las tareas de esta visita",
+	"APPROVAL_REQUIRED":           "Las correcciones después de la salida deben enviarse para la aprobación de un supervisor",
+	"INVALID_APPROVER":            "La corrección debe revisarla alguien distinto del cuidador que la solicitó",
+	"SUPERVISOR_REQUIRED":         "Solo los supervisores y administradores pueden revisar esto",
+	"CORRECTION_NOT_FOUND":        "Corrección de tarea no encontrada",
+	"CORRECTION_ALREADY_REVIEWED": "La corrección de tarea ya fue revisada",
+	"CORRECTION_PENDING":          "La tarea ya tiene una corrección pendiente de aprobación",
+	"CORRECTION_NOT_NEEDED":       "La visita sigue en curso, actualice la tarea directamente",
+	"INVALID_CORRECTION_STATUS":   "El estado de la corrección debe ser pendiente, aprobada o rechazada",
+	"INVALID_TASK_UPDATES":        "Una o más actualizaciones de tareas no son válidas, no se guardó nada",
+	"DUPLICATE_TASK_UPDATE":       "La tarea aparece más de una vez en la solicitud",
+	"INVALID_TASK_VALUE":          "Valor de tarea no válido",
+	"INVALID_TASK_OPTIONS":        "Opciones de tarea no válidas",
+	"REQUIRED_TASKS_PENDING":      "Debe completar o justificar las tareas obligatorias antes de registrar la salida",
+	"INVALID_SCHEDULE_TIME":       "La hora de fin de la visita debe ser posterior a la de inicio",
+	"SCHEDULE_CONFLICT":           "La visita entra en conflicto con los compromisos del cuidador",
+	"SCHEDULE_NOT_EDITABLE":       "La visita ya no se puede modificar",
+	"INVALID_SKILL":               "Los nombres de habilidades no pueden estar vacíos",
+	"INVALID_AVAILABILITY":        "Horario de disponibilidad no válido",
+	"INVALID_TIME_OFF_RANGE":      "El fin de la ausencia debe ser posterior al inicio",
+	"TIME_OFF_NOT_FOUND":          "No se encontró la solicitud de ausencia",
+	"TIME_OFF_ALREADY_REVIEWED":   "La solicitud de ausencia ya fue revisada",
+	"MISSING_CREDENTIAL":          "Al cuidador le falta una credencial válida requerida para este servicio",
+	"CREDENTIAL_TYPE_NOT_FOUND":   "No se encontró el tipo de credencial",
+	"CREDENTIAL_TYPE_EXISTS":      "El código del tipo de credencial ya existe",
+	"CREDENTIAL_NOT_FOUND":        "No se encontró la credencial",
+	"INVALID_CREDENTIAL_DATES":    "La fecha de vencimiento no puede ser anterior a la fecha de emisión",
+	"INVALID_CREDENTIAL_WINDOW":   "Los días deben estar entre 1 y 365",
+	"EXCEPTION_NOT_FOUND":         "La visita no tiene esa excepción de cumplimiento",
+	"EXCEPTION_ALREADY_RESOLVED":  "La excepción de cumplimiento ya fue aprobada o rechazada",
+	"REVIEW_NOTE_REQUIRED":        "Se requiere una nota para aprobar o rechazar una excepción",
+	"INVALID_EXCEPTION_STATUS":    "El estado debe ser unresolved, all, open, acknowledged, approved o rejected",
+	"WEBHOOK_NOT_FOUND":           "No se encontró la suscripción de webhook",
+	"INVALID_WEBHOOK_URL":         "La url del webhook debe ser una url http o https absoluta",
+	"INVALID_EVENT_TYPE":          "Los tipos de evento deben ser uno o más de visit.started, visit.ended, visit.flagged, task.updated o task.not_completed",
+	"INVALID_WEBHOOK_SECRET":      "El secreto del webhook debe tener al menos 16 caracteres",
+	"DEAD_LETTER_NOT_FOUND":       "No se encontró la entrega fallida",
+	"EVENT_NOT_FOUND":             "No se encontró el evento",
+	"ALERT_NOT_FOUND":             "No se encontró la alerta",
+	"ALERT_ALREADY_ACKNOWLEDGED":  "La alerta ya fue confirmada",
+	"INVALID_RISK_LEVEL":          "El nivel de riesgo debe ser una de las políticas de escalamiento configuradas",
+	"INVALID_CLIENT_NAME":         "El nombre del cliente no debe estar vacío",
+	"INVALID_QUIET_HOURS":         "Las horas de silencio necesitan un inicio y un fin con formato HH:MM",
+	"USER_NOT_FOUND":              "No se encontró el usuario",
+	"UNSUPPORTED_LOCALE":          "El idioma debe ser en, es o tl",
+	"INVALID_LATITUDE":            "La latitud debe estar entre -90 y 90",
+	"INVALID_LONGITUDE":           "La longitud debe estar entre -180 y 180",
+	"INVALID_TIMEZONE":            "Zona horaria no válida",
+	"INVALID_DATE":                "La fecha debe tener el formato AAAA-MM-DD",
+	"INVALID_DATE_RANGE":          "La fecha final no puede ser anterior a la fecha inicial",
+	"INVALID_GROUP_BY":            "group_by debe indicar caregiver, client, service y como máximo uno de day, week o month",
+	"PAY_PERIOD_NOT_CONFIGURED":   "El período de pago no está configurado",
+	"NOTE_NOT_FOUND":              "No se encontró la nota",
+	"NOTE_NOT_EDITABLE":           "Solo el autor puede editar la nota dentro del plazo de edición",
+	"ATTACHMENT_NOT_FOUND":        "No se encontró el archivo adjunto",
+	"FILE_REQUIRED":               "Se requiere un archivo",
+	"FILE_TOO_LARGE":              "El archivo supera el tamaño máximo permitido",
+	"UNSUPPORTED_FILE_TYPE":       "Tipo de archivo no permitido",
+	"INVALID_DOWNLOAD_URL":        "El enlace de descarga no es válido o ha caducado",
+	"TASK_NOT_IN_SCHEDULE":        "La tarea no pertenece a esta visita",
+	"ATTACHMENT_NOT_DELETABLE":    "Solo quien subió el archivo adjunto puede eliminarlo",
+}
@@ -0,0 +1,286 @@
+package i18n
+
+var tagalog = map[string]string{
+	MsgInvalidRequestBody:  "Hindi wasto ang laman ng request",
+	MsgInvalidScheduleID:   "Hindi wastong ID ng pagbisita",
+	MsgInvalidUserID:       "Hindi wastong ID ng user",
+	MsgInvalidTaskID:       "Hindi wastong ID ng gawain",
+	MsgInvalidNoteID:       "Hindi wastong ID ng tala",
+	MsgInvalidAttachmentID: "Hindi wastong ID ng attachment",
+	MsgInvalidTimeOffID:    "Hindi wastong ID ng leave",
+	MsgInvalidCredentialID: "Hindi wastong ID ng kredensyal",
+	MsgInvalidDays:         "Hindi wastong bilang ng araw",
+	MsgInvalidGeoLocation:  "Hindi wastong lokasyon",
+	MsgTaskStatusInvalid:   "May mali sa status ng gawain",
+
+	MsgTodaySchedulesRetrieved:         "Nakuha ang mga pagbisita ngayong araw",
+	MsgTodaySchedulesFailed:            "Hindi makuha ang mga pagbisita ngayong araw",
+	MsgSchedulesRetrieved:              "Nakuha ang mga pagbisita",
+	MsgSchedulesFailed:                 "Hindi makuha ang mga pagbisita",
+	MsgScheduleDetailsRetrieved:        "Nakuha ang detalye ng pagbisita",
+	MsgScheduleDetailsFailed:           "Hindi makuha ang detalye ng pagbisita",
+	MsgClockedIn:                       "Matagumpay na naka-clock in",
+	MsgClockedInWithWarnings:           "Naka-clock in na may babala",
+	MsgClockInFailed:                   "Hindi maka-clock in",
+	MsgClockedOut:                      "Matagumpay na naka-clock out",
+	MsgClockedOutWithWarnings:          "Naka-clock out na may babala",
+	MsgClockOutFailed:                  "Hindi maka-clock out",
+	MsgScheduleCreated:                 "Nagawa ang pagbisita",
+	MsgScheduleCreateFailed:            "Hindi magawa ang pagbisita",
+	MsgScheduleAssigned:                "Naitalaga ang pagbisita",
+	MsgScheduleAssignFailed:            "Hindi maitalaga ang pagbisita",
+	MsgTaskUpdated:                     "Na-update ang gawain",
+	MsgTaskUpdateFailed:                "Hindi ma-update ang gawain",
+	MsgTasksUpdated:                    "Na-update ang mga gawain",
+	MsgTasksUpdateFailed:               "Hindi ma-update ang mga gawain",
+	MsgTaskHistoryRetrieved:            "Nakuha ang kasaysayan ng gawain",
+	MsgTaskHistoryFailed:               "Hindi makuha ang kasaysayan ng gawain",
+	MsgNotesRetrieved:                  "Nakuha ang mga tala",
+	MsgNotesFailed:                     "Hindi makuha ang mga tala",
+	MsgNoteAdded:                       "Naidagdag ang tala",
+	MsgNoteAddFailed:                   "Hindi maidagdag ang tala",
+	MsgNoteUpdated:                     "Na-update ang tala",
+	MsgNoteUpdateFailed:                "Hindi ma-update ang tala",
+	MsgAttachmentsRetrieved:            "Nakuha ang mga attachment",
+	MsgAttachmentsFailed:               "Hindi makuha ang mga attachment",
+	MsgAttachmentRetrieved:             "Nakuha ang attachment",
+	MsgAttachmentFailed:                "Hindi makuha ang attachment",
+	MsgAttachmentUploaded:              "Na-upload ang attachment",
+	MsgAttachmentUploadFailed:          "Hindi ma-upload ang attachment",
+	MsgAttachmentDeleted:               "Nabura ang attachment",
+	MsgAttachmentDeleteFailed:          "Hindi mabura ang attachment",
+	MsgAttachmentDownloadFailed:        "Hindi ma-download ang attachment",
+	MsgItineraryRetrieved:              "Nakuha ang itinerary",
+	MsgItineraryFailed:                 "Hindi makuha ang itinerary",
+	MsgMileageReportRetrieved:          "Nakuha ang ulat ng mileage",
+	MsgMileageReportFailed:             "Hindi makuha ang ulat ng mileage",
+	MsgCaregiverMatchesRetrieved:       "Nakuha ang mga angkop na caregiver",
+	MsgCaregiverMatchesFailed:          "Hindi makahanap ng angkop na caregiver",
+	MsgSkillsRetrieved:                 "Nakuha ang mga kasanayan",
+	MsgSkillsFailed:                    "Hindi makuha ang mga kasanayan",
+	MsgSkillsUpdated:                   "Na-update ang mga kasanayan",
+	MsgSkillsUpdateFailed:              "Hindi ma-update ang mga kasanayan",
+	MsgServiceRequirementsRetrieved:    "Nakuha ang mga kinakailangan ng serbisyo",
+	MsgServiceRequirementsFailed:       "Hindi makuha ang mga kinakailangan ng serbisyo",
+	MsgServiceRequirementsUpdated:      "Na-update ang mga kinakailangan ng serbisyo",
+	MsgServiceRequirementsUpdateFailed: "Hindi ma-update ang mga kinakailangan ng serbisyo",
+
+	MsgAvailabilityRetrieved:              "Nakuha ang availability",
+	MsgAvailabilityFailed:                 "Hindi makuha ang availability",
+	MsgAvailabilityUpdated:                "Na-update ang availability",
+	MsgAvailabilityUpdateFailed:           "Hindi ma-update ang availability",
+	MsgTimeOffsRetrieved:                  "Nakuha ang mga request ng leave",
+	MsgTimeOffsFailed:                     "Hindi makuha ang mga request ng leave",
+	MsgTimeOffRequested:                   "Naisumite ang request ng leave",
+	MsgTimeOffRequestFailed:               "Hindi maisumite ang request ng leave",
+	MsgTimeOffReviewed:                    "Nasuri ang request ng leave",
+	MsgTimeOffReviewFailed:                "Hindi masuri ang request ng leave",
+	MsgCredentialTypesRetrieved:           "Nakuha ang mga uri ng kredensyal",
+	MsgCredentialTypesFailed:              "Hindi makuha ang mga uri ng kredensyal",
+	MsgCredentialTypeCreated:              "Nagawa ang uri ng kredensyal",
+	MsgCredentialTypeCreateFailed:         "Hindi magawa ang uri ng kredensyal",
+	MsgCredentialsRetrieved:               "Nakuha ang mga kredensyal",
+	MsgCredentialsFailed:                  "Hindi makuha ang mga kredensyal",
+	MsgCredentialAdded:                    "Naidagdag ang kredensyal",
+	MsgCredentialAddFailed:                "Hindi maidagdag ang kredensyal",
+	MsgCredentialRemoved:                  "Natanggal ang kredensyal",
+	MsgCredentialRemoveFailed:             "Hindi matanggal ang kredensyal",
+	MsgCredentialRequirementsRetrieved:    "Nakuha ang mga kinakailangang kredensyal",
+	MsgCredentialRequirementsFailed:       "Hindi makuha ang mga kinakailangang kredensyal",
+	MsgCredentialRequirementsUpdated:      "Na-update ang mga kinakailangang kredensyal",
+	MsgCredentialRequirementsUpdateFailed: "Hindi ma-update ang mga kinakailangang kredensyal",
+	MsgExpiringCredentialsRetrieved:       "Nakuha ang mga kredensyal na malapit nang mag-expire",
+	MsgExpiringCredentialsFailed:          "Hindi makuha ang mga kredensyal na malapit nang mag-expire",
+	MsgLocaleUpdated:                      "Na-update ang napiling wika",
+	MsgLocaleUpdateFailed:                 "Hindi ma-update ang napiling wika",
+
+	MsgComplianceReportRetrieved: "Nakuha ang ulat ng compliance",
+	MsgComplianceReportFailed:    "Hindi makuha ang ulat ng compliance",
+
+	MsgWebhooksRetrieved:     "Nakuha ang mga webhook",
+	MsgWebhooksFailed:        "Hindi makuha ang mga webhook",
+	MsgWebhookRetrieved:      "Nakuha ang webhook",
+	MsgWebhookFailed:         "Hindi makuha ang webhook",
+	MsgWebhookCreated:        "Nagawa ang webhook",
+	MsgWebhookCreateFailed:   "Hindi magawa ang webhook",
+	MsgWebhookUpdated:        "Na-update ang webhook",
+	MsgWebhookUpdateFailed:   "Hindi ma-update ang webhook",
+	MsgWebhookDeleted:        "Nabura ang webhook",
+	MsgWebhookDeleteFailed:   "Hindi mabura ang webhook",
+	MsgWebhookPinged:         "Nakapila na para ipadala ang test event",
+	MsgWebhookPingFailed:     "Hindi maipila ang test event",
+	MsgDeadLettersRetrieved:  "Nakuha ang mga nabigong delivery",
+	MsgDeadLettersFailed:     "Hindi makuha ang mga nabigong delivery",
+	MsgDeadLetterRetried:     "Nakapila na muli ang delivery",
+	MsgDeadLetterRetryFailed: "Hindi maulit ang delivery",
+	MsgInvalidWebhookID:      "Hindi wastong webhook ID",
+	MsgInvalidDeliveryID:     "Hindi wastong delivery ID",
+
+	MsgNotificationPreferencesRetrieved:    "Nakuha ang mga kagustuhan",
+	MsgNotificationPreferencesFailed:       "Hindi makuha ang mga kagustuhan sa notification",
+	MsgNotificationPreferencesUpdated:      "Na-update ang mga kagustuhan",
+	MsgNotificationPreferencesUpdateFailed: "Hindi ma-update ang mga kagustuhan sa notification",
+
+	MsgNotifyVisitStartingTitle:   "Magsisimula ang pagbisita sa loob ng {minutes} minuto",
+	MsgNotifyVisitStartingBody:    "Ang iyong {service} na pagbisita kay {client} ay magsisimula ng {time} sa {location}.",
+	MsgNotifyClockOutMissingTitle: "Hindi ka pa nakapag-clock out",
+	MsgNotifyClockOutMissingBody:  "Natapos ang iyong pagbisita kay {client} ng {time}. Mag-clock out na ngayon.",
+	MsgNotifyVisitReassignedTitle: "Naitalaga sa iyo ang pagbisita",
+	MsgNotifyVisitReassignedBody:  "Naitalaga sa iyo ang {service} na pagbisita kay {client} sa {date} ng {time}, {location}.",
+
+	MsgAlertsRetrieved:        "Nakuha ang mga alerto",
+	MsgAlertsFailed:           "Hindi makuha ang mga alerto",
+	MsgAlertAcknowledged:      "Nakumpirma ang alerto",
+	MsgAlertAcknowledgeFailed: "Hindi makumpirma ang alerto",
+	MsgInvalidAlertID:         "Hindi wastong alert ID",
+	MsgRiskLevelsRetrieved:    "Nakuha ang mga antas ng panganib",
+	MsgRiskLevelsFailed:       "Hindi makuha ang mga antas ng panganib",
+	MsgRiskLevelUpdated:       "Na-update ang antas ng panganib",
+	MsgRiskLevelUpdateFailed:  "Hindi ma-update ang antas ng panganib",
+
+	MsgAlertVisitLateTitle:      "Huling pagbisita para kay {client} ({risk} na panganib)",
+	MsgAlertVisitLateBody:       "Hindi pa nakapag-clock in si {caregiver} sa {service} na pagbisita kay {client} sa {location}, na nagsimula ng {time} ({minutes} minuto na ang nakalipas). Kumpirmahin ang alerto {id} para itigil ang escalation.",
+	MsgAlertVisitUnassignedBody: "Walang nakatalaga sa {service} na pagbisita kay {client} sa {location}, na nagsimula ng {time} ({minutes} minuto na ang nakalipas). Kumpirmahin ang alerto {id} para itigil ang escalation.",
+
+	MsgCorrectionRequested:     "Naisumite ang pagwawasto para sa pag-apruba ng supervisor",
+	MsgCorrectionRequestFailed: "Hindi naisumite ang pagwawasto",
+	MsgCorrectionsRetrieved:    "Matagumpay na nakuha ang mga pagwawasto",
+	MsgCorrectionsFailed:       "Hindi makuha ang mga pagwawasto",
+	MsgCorrectionApproved:      "Naaprubahan at nailapat ang pagwawasto",
+	MsgCorrectionRejected:      "Tinanggihan ang pagwawasto",
+	MsgCorrectionReviewFailed:  "Hindi masuri ang pagwawasto",
+	MsgInvalidCorrectionID:     "Di-wastong ID ng pagwawasto",
+
+	MsgStreamFailed:         "Hindi mabuksan ang stream ng mga pagbisita",
+	MsgInvalidStreamFilters: "Hindi wastong mga filter ng stream",
+	MsgInvalidLastEventID:   "Hindi wastong Last-Event-ID header",
+
+	MsgExceptionsRetrieved:     "Nakuha ang mga exception",
+	MsgExceptionsFailed:        "Hindi makuha ang mga exception",
+	MsgExceptionReviewed:       "Nasuri ang exception",
+	MsgExceptionReviewFailed:   "Hindi masuri ang exception",
+	MsgInvalidExceptionFilters: "Hindi wastong filter ng exception",
+
+	MsgClockInLateNote:             "Nag-clock in {late} matapos magsimula ang shift",
+	MsgClockInLateWarning:          "Huli ka nang {late} sa pag-clock in",
+	MsgClockInBeforeWindowNote:     "Nag-clock in {outside} bago magbukas ang oras ng clock-in",
+	MsgClockInAfterWindowNote:      "Nag-clock in {outside} matapos magsara ang oras ng clock-in",
+	MsgClockInOutsideWindowWarning: "Nag-clock in ka sa labas ng pinapayagang oras ng clock-in",
+	MsgVisitTooShortNote:           "Tumagal ang pagbisita nang {actual}, kulang sa pinakamababang {limit}",
+	MsgVisitTooLongNote:            "Tumagal ang pagbisita nang {actual}, lampas sa pinakamataas na {limit}",
+	MsgDurationVarianceNote:        "Tumagal ang pagbisita nang {actual} kumpara sa nakatakdang {scheduled} (pinapayagang pagitan {limit})",
+	MsgDurationReasonNote:          "dahilan: {reason}",
+	MsgVisitDurationWarning:        "Tumagal ang iyong pagbisita nang {actual} at ito ay susuriin",
+	MsgEarlyDepartureNote:          "Umalis matapos ang {actual} sa nakatakdang {scheduled}: {reason}",
+	MsgEarlyDepartureWarning:       "Naitala ang maagang pag-alis, susuriin ng supervisor ang pagbisita",
+	MsgLocationExceededNote:        "Layo mula sa nakatakdang lokasyon: {distance} (lampas sa limitasyong {limit})",
+	MsgLocationWarningNote:         "Layo mula sa nakatakdang lokasyon: {distance} (antas ng babala)",
+	MsgLocationWarning:             "Ikaw ay {distance} ang layo mula sa nakatakdang lokasyon",
+	MsgCredentialsMissingNote:      "Kulang ang wastong kredensyal para sa {service}: {credentials}",
+	MsgCredentialsMissingWarning:   "Kulang ka sa mga kinakailangang kredensyal: {credentials}",
+	MsgClientUnableToSignNote:      "Hindi nakapirma ang kliyente: {reason}",
+	MsgAttestationMissingNote:      "Naka-clock out ang pagbisita nang walang kumpirmasyon ng kliyente",
+	MsgAttestationMissingWarning:   "Hindi nakuha ang kumpirmasyon ng kliyente para sa pagbisitang ito",
+	MsgTasksPendingNote:            "Mga kinakailangang gawain na hindi pa tapos: {tasks}",
+	MsgTasksPendingWarning:         "Mga kinakailangang gawain na hindi natapos: {count}",
+
+	MsgConflictOverlap:       "Kasabay ng pagbisita kay {client} mula {start} hanggang {end}",
+	MsgConflictTimeOff:       "May aprubadong leave ang caregiver mula {start} hanggang {end}",
+	MsgConflictUnavailable:   "Ang pagbisita ay labas sa oras ng availability ng caregiver",
+	MsgConflictMaxDailyHours: "Aabot sa {total} ang mga pagbisita sa araw na iyon (limitasyon {limit})",
+	MsgConflictTravelTime:    "Ang biyahe na {distance} ay aabot ng mga {travel} pero {gap} lang ang pagitan ng mga pagbisita",
+	MsgMatchNoSkillsRequired: "Walang kinakailangang kasanayan para sa {service}",
+	MsgMatchMissingSkills:    "Kulang sa mga kinakailangang kasanayan: {skills}",
+	MsgMatchHasSkills:        "Taglay ang lahat ng kinakailangang kasanayan: {skills}",
+	MsgMatchAvailable:        "Available sa buong pagbisita",
+	MsgMatchConflicts:        "Mga salungatan sa iskedyul: {count}",
+	MsgMatchLoad:             "{booked} na ang naka-book sa araw na iyon",
+	MsgMatchNoEarlierVisit:   "Walang naunang pagbisita sa araw na iyon",
+	MsgMatchDistance:         "{distance} mula sa naunang pagbisita kay {client}",
+	MsgMatchContinuity:       "Mga natapos na pagbisita kay {client}: {count}",
+
+	"SCHEDULE_NOT_FOUND":          "Hindi nahanap ang pagbisita",
+	"VISIT_ALREADY_STARTED":       "Nagsimula na ang pagbisita",
+	"LOCATION_TOO_FAR":            "Masyadong malayo ang lokasyon mula sa nakatakdang lokasyon",
+	"CLOCK_IN_TOO_EARLY":          "Masyado pang maaga para mag-clock in, hindi pa bukas ang oras ng clock-in",
+	"CLOCK_IN_TOO_LATE":           "Huli na para mag-clock in, sarado na ang oras ng clock-in",
+	"VISIT_NOT_STARTED":           "Hindi pa nagsisimula ang pagbisita, hindi maaaring mag-clock out",
+	"VISIT_ALREADY_ENDED":         "Tapos na ang pagbisita",
+	"CLOCK_OUT_TOO_EARLY":         "Hindi maaaring mag-clock out bago ang pinakamaikling oras ng pagbisita",
+	"VISIT_TOO_LONG":              "Lampas ang pagbisita sa pinakamahabang oras ng pagbisita",
+	"DURATION_VARIANCE_EXCEEDED":  "Masyadong malayo ang tagal ng pagbisita sa nakatakdang tagal",
+	"DURATION_REASON_REQUIRED":    "Kailangan ng dahilan para mag-clock out sa ganitong tagal ng pagbisita",
+	"INVALID_EARLY_DEPARTURE":     "Kailangan ng maagang pag-alis ang wastong code ng dahilan, at isang tala kapag iba ang dahilan",
+	"ATTESTATION_REQUIRED":        "Kailangan ang kumpirmasyon ng kliyente bago mag-clock out",
+	"INVALID_ATTESTATION":         "Kailangan ng kumpirmasyon ang pirma kasama ang pangalan at kaugnayan ng pumirma, o ang dahilan kung bakit hindi makapirma ang kliyente",
+	"INVALID_SIGNATURE":           "Ang pirma ay dapat may mga guhit o isang base64 na PNG",
+	"SIGNATURE_TOO_LARGE":         "Masyadong malaki ang larawan ng pirma",
+	"INVALID_SIGNER_RELATIONSHIP": "Ang kaugnayan ng pumirma ay dapat kliyente, kapamilya, tagapag-alaga o iba pa",
+	"TASK_NOT_FOUND":              "Hindi nahanap ang gawain",
+	"INVALID_TASK_STATUS":         "Hindi wastong status ng gawain",
+	"REASON_REQUIRED":             "Kailangan ng dahilan para sa mga hindi natapos na gawain",
+	"TASK_ALREADY_UPDATED":        "Na-update ng ibang request ang gawain, i-reload at subukang muli",
+	"VISIT_NOT_IN_PROGRESS":       "Hindi ma-update ang mga gawain, hindi kasalukuyang nagaganap ang pagbisita",
+	"CORRECTION_REASON_REQUIRED":  "Kailangan ng dahilan ng pagwawasto para baguhin ang resulta ng gawain",
+	"CORRECTION_WINDOW_CLOSED":    "Hindi na maaaring iwasto ang resulta ng mga gawain sa pagbisitang ito",
+	"APPROVAL_REQUIRED":           "Ang mga pagwawasto matapos mag-clock out ay dapat isumite para sa pag-apruba ng supervisor",
+	"INVALID_APPROVER":            "Ang pagwawasto ay dapat suriin ng iba bukod sa caregiver na humiling nito",
+	"SUPERVISOR_REQUIRED":         "Mga supervisor at admin lamang ang maaaring magsuri nito",
+	"CORRECTION_NOT_FOUND":        "Hindi nahanap ang pagwawasto ng gawain",
+	"CORRECTION_ALREADY_REVIEWED": "Nasuri na ang pagwawasto ng gawain",
+	"CORRECTION_PENDING":          "May pagwawasto na ang gawain na naghihintay ng pag-apruba",
+	"CORRECTION_NOT_NEEDED":       "Kasalukuyan pa ang pagbisita, direktang i-update ang gawain",
+	"INVALID_CORRECTION_STATUS":   "Ang status ng pagwawasto ay dapat pending, approved o rejected",
+	"INVALID_TASK_UPDATES":        "May hindi wastong update sa mga gawain, walang na-save",
+	"DUPLICATE_TASK_UPDATE":       "Higit sa isang beses lumabas ang gawain sa request",
+	"INVALID_TASK_VALUE":          "Hindi wastong halaga ng gawain",
+	"INVALID_TASK_OPTIONS":        "Hindi wastong mga opsyon ng gawain",
+	"REQUIRED_TASKS_PENDING":      "Kailangang tapusin o ipaliwanag ang mga kinakailangang gawain bago mag-clock out",
+	"INVALID_SCHEDULE_TIME":       "Ang oras ng pagtatapos ng pagbisita ay dapat pagkatapos ng oras ng pagsisimula",
+	"SCHEDULE_CONFLICT":           "Sumasalungat ang pagbisita sa mga naunang pangako ng caregiver",
+	"SCHEDULE_NOT_EDITABLE":       "Hindi na mababago ang pagbisita",
+	"INVALID_SKILL":               "Hindi maaaring walang laman ang pangalan ng kasanayan",
+	"INVALID_AVAILABILITY":        "Hindi wastong oras ng availability",
+	"INVALID_TIME_OFF_RANGE":      "Ang pagtatapos ng leave ay dapat pagkatapos ng pagsisimula",
+	"TIME_OFF_NOT_FOUND":          "Hindi nahanap ang request ng leave",
+	"TIME_OFF_ALREADY_REVIEWED":   "Nasuri na ang request ng leave",
+	"MISSING_CREDENTIAL":          "Kulang ang caregiver ng wastong kredensyal na kailangan sa serbisyong ito",
+	"CREDENTIAL_TYPE_NOT_FOUND":   "Hindi nahanap ang uri ng kredensyal",
+	"CREDENTIAL_TYPE_EXISTS":      "Mayroon nang ganitong code ng uri ng kredensyal",
+	"CREDENTIAL_NOT_FOUND":        "Hindi nahanap ang kredensyal",
+	"INVALID_CREDENTIAL_DATES":    "Ang petsa ng pag-expire ay hindi dapat mas maaga sa petsa ng pag-isyu",
+	"INVALID_CREDENTIAL_WINDOW":   "Ang bilang ng araw ay dapat mula 1 hanggang 365",
+	"EXCEPTION_NOT_FOUND":         "Walang ganitong compliance exception ang pagbisita",
+	"EXCEPTION_ALREADY_RESOLVED":  "Naaprubahan o natanggihan na ang compliance exception",
+	"REVIEW_NOTE_REQUIRED":        "Kailangan ng tala para aprubahan o tanggihan ang exception",
+	"INVALID_EXCEPTION_STATUS":    "Ang status ay dapat unresolved, all, open, acknowledged, approved o rejected",
+	"WEBHOOK_NOT_FOUND":           "Hindi nahanap ang webhook subscription",
+	"INVALID_WEBHOOK_URL":         "Ang url ng webhook ay dapat buong http o https na url",
+	"INVALID_EVENT_TYPE":          "Ang mga uri ng event ay dapat isa o higit pa sa visit.started, visit.ended, visit.flagged, task.updated o task.not_completed",
+	"INVALID_WEBHOOK_SECRET":      "Ang secret ng webhook ay dapat hindi bababa sa 16 na karakter",
+	"DEAD_LETTER_NOT_FOUND":       "Hindi nahanap ang nabigong delivery",
+	"EVENT_NOT_FOUND":             "Hindi nahanap ang event",
+	"ALERT_NOT_FOUND":             "Hindi nahanap ang alerto",
+	"ALERT_ALREADY_ACKNOWLEDGED":  "Nakumpirma na ang alerto",
+	"INVALID_RISK_LEVEL":          "Ang antas ng panganib ay dapat isa sa mga naka-configure na escalation policy",
+	"INVALID_CLIENT_NAME":         "Hindi dapat walang laman ang pangalan ng kliyente",
+	"INVALID_QUIET_HOURS":         "Ang tahimik na oras ay kailangan ng simula at katapusan na nasa anyong HH:MM",
+	"USER_NOT_FOUND":              "Hindi nahanap ang user",
+	"UNSUPPORTED_LOCALE":          "Ang wika ay dapat en, es o tl",
+	"INVALID_LATITUDE":            "Ang latitude ay dapat mula -90 hanggang 90",
+	"INVALID_LONGITUDE":           "Ang longitude ay dapat mula -180 hanggang 180",
+	"INVALID_TIMEZONE":            "Hindi wastong timezone",
+	"INVALID_DATE":                "Ang petsa ay dapat nasa format na YYYY-MM-DD",
+	"INVALID_DATE_RANGE":          "Hindi maaaring mauna ang petsa ng to sa petsa ng from",
+	"INVALID_GROUP_BY":            "Ang group_by ay dapat caregiver, client, service at hindi hihigit sa isa sa day, week o month",
+	"PAY_PERIOD_NOT_CONFIGURED":   "Hindi pa naka-configure ang pay period",
+	"NOTE_NOT_FOUND":              "Hindi nahanap ang tala",
+	"NOTE_NOT_EDITABLE":           "Ang may-akda lang ang maaaring mag-edit ng tala sa loob ng itinakdang oras",
+	"ATTACHMENT_NOT_FOUND":        "Hindi nahanap ang attachment",
+	"FILE_REQUIRED":               "Kailangan ng file",
+	"FILE_TOO_LARGE":              "Lampas ang file sa pinakamalaking laki na maaaring i-upload",
+	"UNSUPPORTED_FILE_TYPE":       "Hindi pinapayagan ang ganitong uri ng file",
+	"INVALID_DOWNLOAD_URL":        "Hindi wasto o expired na ang download link",
+	"TASK_NOT_IN_SCHEDULE":        "Hindi kabilang ang gawain sa pagbisitang ito",
+	"ATTACHMENT_NOT_DELETABLE":    "Ang nag-upload lang ang maaaring magbura ng attachment",
+}
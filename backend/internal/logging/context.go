@@ -0,0 +1,49 @@
+package logging
+
+import "context"
+
+// Field names added to records from the context
+const (
+	KeyRequestID  = "request_id"
+	KeyUserID     = "user_id"
+	KeyScheduleID = "schedule_id"
+)
+
+type contextKey struct{}
+
+type fields struct {
+	requestID  string
+	userID     int64
+	scheduleID int64
+}
+
+func fromContext(ctx context.Context) fields {
+	f, _ := ctx.Value(contextKey{}).(fields)
+	return f
+}
+
+// WithRequestID stores the ID that correlates everything logged while serving one request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	f := fromContext(ctx)
+	f.requestID = requestID
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// RequestID returns the request's ID, or an empty string outside of a request
+func RequestID(ctx context.Context) string {
+	return fromContext(ctx).requestID
+}
+
+// WithUserID stores the user acting in the request; nothing sets it until requests are authenticated
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	f := fromContext(ctx)
+	f.userID = userID
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// WithScheduleID stores the visit the request or job is about
+func WithScheduleID(ctx context.Context, scheduleID int64) context.Context {
+	f := fromContext(ctx)
+	f.scheduleID = scheduleID
+	return context.WithValue(ctx, contextKey{}, f)
+}
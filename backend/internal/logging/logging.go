@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	redacted = "[redacted]"
+)
+
+// New builds the JSON logger for the environment. Every record logged with a context carries the
+// request ID, user ID and schedule ID stored in it, and production logs never show coordinates.
+func New(cfg config.LogConfig, env string, w io.Writer) *slog.Logger {
+	production := env == "production"
+
+	level := slog.LevelDebug
+	if production {
+		level = slog.LevelInfo
+	}
+	if cfg.Level != "" {
+		var parsed slog.Level
+		if err := parsed.UnmarshalText([]byte(cfg.Level)); err == nil {
+			level = parsed
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	if cfg.RedactCoordinates || production {
+		opts.ReplaceAttr = redactCoordinates
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, FormatText) {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// contextHandler adds the fields kept in the record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	f := fromContext(ctx)
+	if f.requestID != "" {
+		r.AddAttrs(slog.String(KeyRequestID, f.requestID))
+	}
+	if f.userID != 0 {
+		r.AddAttrs(slog.Int64(KeyUserID, f.userID))
+	}
+	if f.scheduleID != 0 {
+		r.AddAttrs(slog.Int64(KeyScheduleID, f.scheduleID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redactCoordinates hides latitudes and longitudes, which locate clients' homes and caregivers
+func redactCoordinates(_ []string, a slog.Attr) slog.Attr {
+	if isCoordinate(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+func isCoordinate(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "lat", "lng", "lon":
+		return true
+	}
+	return strings.Contains(key, "latitude") || strings.Contains(key, "longitude")
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+	"github.com/gin-gonic/gin"
+)
+
+// TODO: hardcoded user id because there is no auth yet, will implement later
+var defaultUserID int64 = 1
+
+// infrastructureRoutes answer scrapers and probes, which have no user and no use for translations
+var infrastructureRoutes = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+}
+
+// UseLocale picks the language for the request's messages: the ?lang query parameter, then the
+// Accept-Language header, then the user's saved preference, then the configured default.
+// The user is only looked up when the request names no language itself.
+func UseLocale(defaultLocale string, userRepo user.Repository) gin.HandlerFunc {
+	fallback, ok := i18n.Parse(defaultLocale)
+	if !ok {
+		fallback = i18n.Default
+	}
+
+	return func(c *gin.Context) {
+		if infrastructureRoutes[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		locale, ok := i18n.Parse(c.Query("lang"))
+		if !ok {
+			locale, ok = i18n.Negotiate(c.GetHeader("Accept-Language"))
+		}
+		if !ok {
+			locale, ok = savedLocale(c, userRepo)
+		}
+		if !ok {
+			locale = fallback
+		}
+
+		c.Header("Content-Language", string(locale))
+		c.Request = c.Request.WithContext(i18n.WithLocale(ctx, locale))
+		c.Next()
+	}
+}
+
+func savedLocale(c *gin.Context, userRepo user.Repository) (i18n.Locale, bool) {
+	u, err := userRepo.GetByID(c.Request.Context(), defaultUserID)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to load language preference", "error", err)
+		return "", false
+	}
+	if !u.Locale.Valid {
+		return "", false
+	}
+	return i18n.Parse(u.Locale.String)
+}
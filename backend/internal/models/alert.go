@@ -0,0 +1,89 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Escalation contacts, in the order a policy usually escalates through them
+const (
+	EscalationContactOnCall = "on_call"
+	EscalationContactBackup = "backup"
+	EscalationContactAdmin  = "admin"
+)
+
+func IsValidEscalationContact(contact string) bool {
+	switch contact {
+	case EscalationContactOnCall, EscalationContactBackup, EscalationContactAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// OverdueVisit is a visit past its start that nobody has clocked into and nobody has acknowledged an alert for
+type OverdueVisit struct {
+	ScheduleID    int64          `db:"schedule_id"`
+	UserID        sql.NullInt64  `db:"user_id"`
+	CaregiverName sql.NullString `db:"caregiver_name"`
+	ClientName    string         `db:"client_name"`
+	ServiceName   string         `db:"service_name"`
+	Location      string         `db:"location"`
+	StartTime     time.Time      `db:"start_time"`
+	RiskLevel     string         `db:"risk_level"`
+	AlertsSent    int            `db:"alerts_sent"`
+}
+
+type VisitAlert struct {
+	ID                  int64          `db:"id"`
+	ScheduleID          int64          `db:"schedule_id"`
+	Step                int            `db:"step"`
+	Contact             string         `db:"contact"`
+	RecipientID         int64          `db:"recipient_id"`
+	RecipientName       string         `db:"recipient_name"`
+	RiskLevel           string         `db:"risk_level"`
+	MinutesLate         int64          `db:"minutes_late"`
+	DeliveredChannels   pq.StringArray `db:"delivered_channels"`
+	DeliveryError       sql.NullString `db:"delivery_error"`
+	SentAt              time.Time      `db:"sent_at"`
+	AcknowledgedBy      sql.NullInt64  `db:"acknowledged_by"`
+	AcknowledgedAt      sql.NullTime   `db:"acknowledged_at"`
+	AcknowledgementNote sql.NullString `db:"acknowledgement_note"`
+}
+
+func (a *VisitAlert) IsAcknowledged() bool {
+	return a.AcknowledgedAt.Valid
+}
+
+func (a *VisitAlert) ToVisitAlertResponse() VisitAlertResponse {
+	resp := VisitAlertResponse{
+		ID:                  a.ID,
+		ScheduleID:          a.ScheduleID,
+		Step:                a.Step,
+		Contact:             a.Contact,
+		RecipientID:         a.RecipientID,
+		RecipientName:       a.RecipientName,
+		RiskLevel:           a.RiskLevel,
+		MinutesLate:         a.MinutesLate,
+		DeliveredChannels:   a.DeliveredChannels,
+		DeliveryError:       a.DeliveryError.String,
+		SentAt:              a.SentAt,
+		AcknowledgedBy:      a.AcknowledgedBy.Int64,
+		AcknowledgementNote: a.AcknowledgementNote.String,
+	}
+	if resp.DeliveredChannels == nil {
+		resp.DeliveredChannels = []string{}
+	}
+	if a.AcknowledgedAt.Valid {
+		resp.AcknowledgedAt = &a.AcknowledgedAt.Time
+	}
+	return resp
+}
+
+type ClientRiskLevel struct {
+	ClientName string    `db:"client_name" json:"client_name"`
+	RiskLevel  string    `db:"risk_level" json:"risk_level"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Attestation struct {
+	ID                       int64          `json:"id" db:"id"`
+	ScheduleID               int64          `json:"schedule_id" db:"schedule_id"`
+	SignerName               sql.NullString `json:"signer_name,omitempty" db:"signer_name"`
+	SignerRelationship       sql.NullString `json:"signer_relationship,omitempty" db:"signer_relationship"`
+	SignatureFormat          sql.NullString `json:"signature_format,omitempty" db:"signature_format"`
+	SignatureData            sql.NullString `json:"signature_data,omitempty" db:"signature_data"`
+	UnableToSignReason       sql.NullString `json:"unable_to_sign_reason,omitempty" db:"unable_to_sign_reason"`
+	CaregiverSignatureFormat sql.NullString `json:"caregiver_signature_format,omitempty" db:"caregiver_signature_format"`
+	CaregiverSignatureData   sql.NullString `json:"caregiver_signature_data,omitempty" db:"caregiver_signature_data"`
+	CapturedAt               time.Time      `json:"captured_at" db:"captured_at"`
+	CreatedAt                time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+func (a *Attestation) ToAttestationResponse() *AttestationResponse {
+	return &AttestationResponse{
+		SignerName:               a.SignerName.String,
+		SignerRelationship:       a.SignerRelationship.String,
+		SignatureFormat:          a.SignatureFormat.String,
+		SignatureData:            a.SignatureData.String,
+		UnableToSignReason:       a.UnableToSignReason.String,
+		CaregiverSignatureFormat: a.CaregiverSignatureFormat.String,
+		CaregiverSignatureData:   a.CaregiverSignatureData.String,
+		CapturedAt:               a.CapturedAt,
+	}
+}
+
+func (a *Attestation) IsSigned() bool {
+	return a.SignatureData.Valid
+}
+
+const (
+	SignatureFormatStrokes = "strokes"
+	SignatureFormatPNG     = "png"
+)
+
+const (
+	SignerRelationshipClient   = "client"
+	SignerRelationshipFamily   = "family"
+	SignerRelationshipGuardian = "guardian"
+	SignerRelationshipOther    = "other"
+)
+
+func IsValidSignerRelationship(relationship string) bool {
+	switch relationship {
+	case SignerRelationshipClient, SignerRelationshipFamily, SignerRelationshipGuardian, SignerRelationshipOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Attestation policies decide what happens when a visit is clocked out without attestation
+const (
+	AttestationPolicyOff   = "off"
+	AttestationPolicyFlag  = "flag"
+	AttestationPolicyBlock = "block"
+)
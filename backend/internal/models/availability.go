@@ -0,0 +1,129 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Availability struct {
+	ID        int64     `json:"id" db:"id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	DayOfWeek int       `json:"day_of_week" db:"day_of_week"`
+	StartTime string    `json:"start_time" db:"start_time"`
+	EndTime   string    `json:"end_time" db:"end_time"`
+	Timezone  string    `json:"timezone" db:"timezone"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (a *Availability) ToAvailabilityResponse() AvailabilityResponse {
+	return AvailabilityResponse{
+		ID:        a.ID,
+		DayOfWeek: a.DayOfWeek,
+		StartTime: a.StartTime,
+		EndTime:   a.EndTime,
+		Timezone:  a.Timezone,
+	}
+}
+
+// Covers reports whether the window fully contains the given time range.
+// Start and end times are stored as "15:04" in the window's own timezone.
+func (a *Availability) Covers(start, end time.Time) bool {
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return false
+	}
+
+	localStart := start.In(loc)
+	if int(localStart.Weekday()) != a.DayOfWeek {
+		return false
+	}
+
+	windowStart, err := time.ParseInLocation(AvailabilityTimeLayout, a.StartTime, loc)
+	if err != nil {
+		return false
+	}
+	windowEnd, err := time.ParseInLocation(AvailabilityTimeLayout, a.EndTime, loc)
+	if err != nil {
+		return false
+	}
+
+	day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, loc)
+	from := day.Add(time.Duration(windowStart.Hour())*time.Hour + time.Duration(windowStart.Minute())*time.Minute)
+	to := day.Add(time.Duration(windowEnd.Hour())*time.Hour + time.Duration(windowEnd.Minute())*time.Minute)
+
+	return !start.Before(from) && !end.After(to)
+}
+
+const AvailabilityTimeLayout = "15:04"
+
+type TimeOff struct {
+	ID         int64          `json:"id" db:"id"`
+	UserID     int64          `json:"user_id" db:"user_id"`
+	StartTime  time.Time      `json:"start_time" db:"start_time"`
+	EndTime    time.Time      `json:"end_time" db:"end_time"`
+	Reason     sql.NullString `json:"reason,omitempty" db:"reason"`
+	Status     string         `json:"status" db:"status"`
+	ReviewedBy sql.NullInt64  `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote sql.NullString `json:"review_note,omitempty" db:"review_note"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+func (t *TimeOff) ToTimeOffResponse() TimeOffResponse {
+	return TimeOffResponse{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		StartTime:  t.StartTime,
+		EndTime:    t.EndTime,
+		Reason:     t.Reason.String,
+		Status:     t.Status,
+		ReviewedBy: t.ReviewedBy.Int64,
+		ReviewedAt: t.ReviewedAt.Time,
+		ReviewNote: t.ReviewNote.String,
+	}
+}
+
+const (
+	TimeOffStatusPending  = "pending"
+	TimeOffStatusApproved = "approved"
+	TimeOffStatusRejected = "rejected"
+)
+
+func (t *TimeOff) CanReview() bool {
+	return t.Status == TimeOffStatusPending
+}
+
+func (t *TimeOff) Overlaps(start, end time.Time) bool {
+	return t.StartTime.Before(end) && t.EndTime.After(start)
+}
+
+const (
+	ConflictOverlap       = "OVERLAP"
+	ConflictTimeOff       = "TIME_OFF"
+	ConflictUnavailable   = "UNAVAILABLE"
+	ConflictMaxDailyHours = "MAX_DAILY_HOURS"
+	ConflictTravelTime    = "TRAVEL_TIME"
+)
+
+// Conflict describes a single reason a caregiver cannot take a visit
+type Conflict struct {
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+	ScheduleID int64  `json:"schedule_id,omitempty"`
+	TimeOffID  int64  `json:"time_off_id,omitempty"`
+}
+
+// ScheduleConflictError carries the conflicts found while creating or reassigning a schedule
+type ScheduleConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return ErrScheduleConflict.Error()
+}
+
+func (e *ScheduleConflictError) Unwrap() error {
+	return ErrScheduleConflict
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type CredentialType struct {
+	ID          int64          `json:"id" db:"id"`
+	Code        string         `json:"code" db:"code"`
+	Name        string         `json:"name" db:"name"`
+	Description sql.NullString `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+func (t *CredentialType) ToCredentialTypeResponse() CredentialTypeResponse {
+	return CredentialTypeResponse{
+		ID:          t.ID,
+		Code:        t.Code,
+		Name:        t.Name,
+		Description: t.Description.String,
+	}
+}
+
+type UserCredential struct {
+	ID               int64          `json:"id" db:"id"`
+	UserID           int64          `json:"user_id" db:"user_id"`
+	CredentialTypeID int64          `json:"credential_type_id" db:"credential_type_id"`
+	CredentialNumber sql.NullString `json:"credential_number,omitempty" db:"credential_number"`
+	IssuedAt         time.Time      `json:"issued_at" db:"issued_at"`
+	ExpiresAt        sql.NullTime   `json:"expires_at,omitempty" db:"expires_at"`
+	DocumentRef      sql.NullString `json:"document_ref,omitempty" db:"document_ref"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+
+	// joined from credential_types and users
+	TypeCode string `json:"type_code" db:"type_code"`
+	TypeName string `json:"type_name" db:"type_name"`
+	UserName string `json:"user_name,omitempty" db:"user_name"`
+}
+
+func (c *UserCredential) ToUserCredentialResponse(now time.Time) UserCredentialResponse {
+	resp := UserCredentialResponse{
+		ID:               c.ID,
+		UserID:           c.UserID,
+		UserName:         c.UserName,
+		CredentialTypeID: c.CredentialTypeID,
+		TypeCode:         c.TypeCode,
+		TypeName:         c.TypeName,
+		CredentialNumber: c.CredentialNumber.String,
+		IssuedAt:         c.IssuedAt.Format(CredentialDateLayout),
+		DocumentRef:      c.DocumentRef.String,
+		IsValid:          c.IsValidAt(now),
+	}
+
+	if c.ExpiresAt.Valid {
+		resp.ExpiresAt = c.ExpiresAt.Time.Format(CredentialDateLayout)
+		days := int(c.ExpiresAt.Time.Sub(now).Hours() / 24)
+		resp.DaysUntilExpiry = &days
+	}
+
+	return resp
+}
+
+// IsValidAt reports whether the credential has been issued and has not expired at t.
+// Credentials stay valid through the whole expiry date.
+func (c *UserCredential) IsValidAt(t time.Time) bool {
+	if t.Before(c.IssuedAt) {
+		return false
+	}
+	if !c.ExpiresAt.Valid {
+		return true
+	}
+	return t.Before(c.ExpiresAt.Time.Add(24 * time.Hour))
+}
+
+const CredentialDateLayout = "2006-01-02"
+
+const (
+	CredentialEnforcementBlock = "block"
+	CredentialEnforcementFlag  = "flag"
+)
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+)
+
+// Codes for errors that are not in the catalogue, chosen by HTTP status
+const (
+	ErrorCodeInvalidRequest = "INVALID_REQUEST"
+	ErrorCodeNotFound       = "NOT_FOUND"
+	ErrorCodeForbidden      = "FORBIDDEN"
+	ErrorCodeConflict       = "CONFLICT"
+	ErrorCodeInternal       = "INTERNAL_ERROR"
+)
+
+// ErrorDefinition gives a sentinel error a stable code and HTTP status for API clients.
+// The sentinel's text is the user-safe message.
+type ErrorDefinition struct {
+	Err    error
+	Code   string
+	Status int
+	// Detailed sentinels are wrapped with a user-safe explanation, e.g. which field failed validation,
+	// so the full error text is shown instead of the sentinel's alone
+	Detailed bool
+}
+
+// Message returns the user-safe message for err, which matched this definition
+func (d ErrorDefinition) Message(err error) string {
+	if d.Detailed {
+		return err.Error()
+	}
+	return d.Err.Error()
+}
+
+// LocalizedMessage translates the sentinel's text; for detailed errors the explanation wrapped
+// around it comes from user input or validation rules and is kept as is
+func (d ErrorDefinition) LocalizedMessage(locale i18n.Locale, err error) string {
+	msg, ok := locale.Lookup(d.Code)
+	if !ok {
+		return d.Message(err)
+	}
+	if d.Detailed {
+		msg += strings.TrimPrefix(err.Error(), d.Err.Error())
+	}
+	return msg
+}
+
+// DataError is implemented by errors that carry structured data for the client, e.g. the conflicts found
+type DataError interface {
+	error
+	ErrorData() interface{}
+}
+
+func (e *ScheduleConflictError) ErrorData() interface{} {
+	return e.Conflicts
+}
+
+func (e *RequiredTasksPendingError) ErrorData() interface{} {
+	return e.Tasks
+}
+
+func (e *TaskUpdateItemsError) ErrorData() interface{} {
+	return e.Items
+}
+
+var errorCatalog = []ErrorDefinition{
+	// clock in
+	{Err: ErrScheduleNotFound, Code: "SCHEDULE_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrVisitAlreadyStarted, Code: "VISIT_ALREADY_STARTED", Status: http.StatusConflict},
+	{Err: ErrLocationTooFar, Code: "LOCATION_TOO_FAR", Status: http.StatusBadRequest},
+	{Err: ErrClockInTooEarly, Code: "CLOCK_IN_TOO_EARLY", Status: http.StatusBadRequest},
+	{Err: ErrClockInTooLate, Code: "CLOCK_IN_TOO_LATE", Status: http.StatusBadRequest},
+
+	// clock out
+	{Err: ErrVisitNotStarted, Code: "VISIT_NOT_STARTED", Status: http.StatusBadRequest},
+	{Err: ErrVisitAlreadyEnded, Code: "VISIT_ALREADY_ENDED", Status: http.StatusConflict},
+	{Err: ErrClockOutTooEarly, Code: "CLOCK_OUT_TOO_EARLY", Status: http.StatusBadRequest},
+	{Err: ErrVisitTooLong, Code: "VISIT_TOO_LONG", Status: http.StatusBadRequest},
+	{Err: ErrDurationVariance, Code: "DURATION_VARIANCE_EXCEEDED", Status: http.StatusBadRequest},
+	{Err: ErrDurationReasonRequired, Code: "DURATION_REASON_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrInvalidEarlyDeparture, Code: "INVALID_EARLY_DEPARTURE", Status: http.StatusBadRequest},
+	{Err: ErrAttestationRequired, Code: "ATTESTATION_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrInvalidAttestation, Code: "INVALID_ATTESTATION", Status: http.StatusBadRequest},
+	{Err: ErrInvalidSignature, Code: "INVALID_SIGNATURE", Status: http.StatusBadRequest},
+	{Err: ErrSignatureTooLarge, Code: "SIGNATURE_TOO_LARGE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidSignerRelationship, Code: "INVALID_SIGNER_RELATIONSHIP", Status: http.StatusBadRequest},
+
+	// tasks
+	{Err: ErrTaskNotFound, Code: "TASK_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrInvalidTaskStatus, Code: "INVALID_TASK_STATUS", Status: http.StatusBadRequest},
+	{Err: ErrReasonRequired, Code: "REASON_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrTaskAlreadyUpdated, Code: "TASK_ALREADY_UPDATED", Status: http.StatusConflict},
+	{Err: ErrVisitNotInProgress, Code: "VISIT_NOT_IN_PROGRESS", Status: http.StatusBadRequest},
+	{Err: ErrCorrectionReasonRequired, Code: "CORRECTION_REASON_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrCorrectionWindowClosed, Code: "CORRECTION_WINDOW_CLOSED", Status: http.StatusConflict},
+	{Err: ErrApprovalRequired, Code: "APPROVAL_REQUIRED", Status: http.StatusForbidden},
+	{Err: ErrInvalidApprover, Code: "INVALID_APPROVER", Status: http.StatusForbidden},
+	{Err: ErrSupervisorRequired, Code: "SUPERVISOR_REQUIRED", Status: http.StatusForbidden},
+	{Err: ErrCorrectionNotFound, Code: "CORRECTION_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrCorrectionAlreadyReviewed, Code: "CORRECTION_ALREADY_REVIEWED", Status: http.StatusConflict},
+	{Err: ErrCorrectionPending, Code: "CORRECTION_PENDING", Status: http.StatusConflict},
+	{Err: ErrCorrectionNotNeeded, Code: "CORRECTION_NOT_NEEDED", Status: http.StatusConflict},
+	{Err: ErrInvalidCorrectionStatus, Code: "INVALID_CORRECTION_STATUS", Status: http.StatusBadRequest},
+	{Err: ErrInvalidTaskUpdates, Code: "INVALID_TASK_UPDATES", Status: http.StatusUnprocessableEntity},
+	{Err: ErrDuplicateTaskUpdate, Code: "DUPLICATE_TASK_UPDATE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidTaskValue, Code: "INVALID_TASK_VALUE", Status: http.StatusBadRequest, Detailed: true},
+	{Err: ErrInvalidTaskOptions, Code: "INVALID_TASK_OPTIONS", Status: http.StatusBadRequest, Detailed: true},
+	{Err: ErrRequiredTasksPending, Code: "REQUIRED_TASKS_PENDING", Status: http.StatusConflict},
+
+	// scheduling
+	{Err: ErrInvalidScheduleTime, Code: "INVALID_SCHEDULE_TIME", Status: http.StatusBadRequest},
+	{Err: ErrScheduleConflict, Code: "SCHEDULE_CONFLICT", Status: http.StatusConflict},
+	{Err: ErrScheduleNotEditable, Code: "SCHEDULE_NOT_EDITABLE", Status: http.StatusConflict},
+	{Err: ErrInvalidSkill, Code: "INVALID_SKILL", Status: http.StatusBadRequest},
+
+	// availability
+	{Err: ErrInvalidAvailability, Code: "INVALID_AVAILABILITY", Status: http.StatusBadRequest},
+	{Err: ErrInvalidTimeOffRange, Code: "INVALID_TIME_OFF_RANGE", Status: http.StatusBadRequest},
+	{Err: ErrTimeOffNotFound, Code: "TIME_OFF_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrTimeOffAlreadyReviewed, Code: "TIME_OFF_ALREADY_REVIEWED", Status: http.StatusConflict},
+
+	// credentials
+	{Err: ErrMissingCredential, Code: "MISSING_CREDENTIAL", Status: http.StatusForbidden, Detailed: true},
+	{Err: ErrCredentialTypeNotFound, Code: "CREDENTIAL_TYPE_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrCredentialTypeExists, Code: "CREDENTIAL_TYPE_EXISTS", Status: http.StatusConflict},
+	{Err: ErrCredentialNotFound, Code: "CREDENTIAL_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrInvalidCredentialDates, Code: "INVALID_CREDENTIAL_DATES", Status: http.StatusBadRequest},
+	{Err: ErrInvalidCredentialWindow, Code: "INVALID_CREDENTIAL_WINDOW", Status: http.StatusBadRequest},
+
+	// exception review
+	{Err: ErrExceptionNotFound, Code: "EXCEPTION_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrExceptionAlreadyResolved, Code: "EXCEPTION_ALREADY_RESOLVED", Status: http.StatusConflict},
+	{Err: ErrReviewNoteRequired, Code: "REVIEW_NOTE_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrInvalidExceptionStatus, Code: "INVALID_EXCEPTION_STATUS", Status: http.StatusBadRequest},
+
+	// webhooks
+	{Err: ErrWebhookNotFound, Code: "WEBHOOK_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrInvalidWebhookURL, Code: "INVALID_WEBHOOK_URL", Status: http.StatusBadRequest},
+	{Err: ErrInvalidEventType, Code: "INVALID_EVENT_TYPE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidWebhookSecret, Code: "INVALID_WEBHOOK_SECRET", Status: http.StatusBadRequest},
+	{Err: ErrDeadLetterNotFound, Code: "DEAD_LETTER_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrEventNotFound, Code: "EVENT_NOT_FOUND", Status: http.StatusNotFound},
+
+	// escalation alerts
+	{Err: ErrAlertNotFound, Code: "ALERT_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrAlertAlreadyAcknowledged, Code: "ALERT_ALREADY_ACKNOWLEDGED", Status: http.StatusConflict},
+	{Err: ErrInvalidRiskLevel, Code: "INVALID_RISK_LEVEL", Status: http.StatusBadRequest},
+	{Err: ErrInvalidClientName, Code: "INVALID_CLIENT_NAME", Status: http.StatusBadRequest},
+
+	// users
+	{Err: ErrUserNotFound, Code: "USER_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrUnsupportedLocale, Code: "UNSUPPORTED_LOCALE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidQuietHours, Code: "INVALID_QUIET_HOURS", Status: http.StatusBadRequest},
+
+	// input formats and configuration
+	{Err: ErrInvalidLatitude, Code: "INVALID_LATITUDE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidLongitude, Code: "INVALID_LONGITUDE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidTimezone, Code: "INVALID_TIMEZONE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidDate, Code: "INVALID_DATE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidDateRange, Code: "INVALID_DATE_RANGE", Status: http.StatusBadRequest},
+	{Err: ErrInvalidGroupBy, Code: "INVALID_GROUP_BY", Status: http.StatusBadRequest},
+	{Err: ErrInvalidPayPeriod, Code: "PAY_PERIOD_NOT_CONFIGURED", Status: http.StatusInternalServerError},
+
+	// notes and attachments
+	{Err: ErrNoteNotFound, Code: "NOTE_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrNoteNotEditable, Code: "NOTE_NOT_EDITABLE", Status: http.StatusForbidden},
+	{Err: ErrAttachmentNotFound, Code: "ATTACHMENT_NOT_FOUND", Status: http.StatusNotFound},
+	{Err: ErrFileRequired, Code: "FILE_REQUIRED", Status: http.StatusBadRequest},
+	{Err: ErrFileTooLarge, Code: "FILE_TOO_LARGE", Status: http.StatusRequestEntityTooLarge},
+	{Err: ErrUnsupportedFileType, Code: "UNSUPPORTED_FILE_TYPE", Status: http.StatusUnsupportedMediaType},
+	{Err: ErrInvalidDownloadURL, Code: "INVALID_DOWNLOAD_URL", Status: http.StatusForbidden},
+	{Err: ErrTaskNotInSchedule, Code: "TASK_NOT_IN_SCHEDULE", Status: http.StatusBadRequest},
+	{Err: ErrAttachmentNotDeletable, Code: "ATTACHMENT_NOT_DELETABLE", Status: http.StatusForbidden},
+}
+
+// LookupError finds the catalogue entry for the first sentinel err wraps
+func LookupError(err error) (ErrorDefinition, bool) {
+	for _, def := range errorCatalog {
+		if errors.Is(err, def.Err) {
+			return def, true
+		}
+	}
+	return ErrorDefinition{}, false
+}
+
+// ErrorCodeForStatus is the generic code for errors outside the catalogue
+func ErrorCodeForStatus(status int) string {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status == http.StatusForbidden:
+		return ErrorCodeForbidden
+	case status == http.StatusConflict:
+		return ErrorCodeConflict
+	case status >= http.StatusInternalServerError:
+		return ErrorCodeInternal
+	default:
+		return ErrorCodeInvalidRequest
+	}
+}
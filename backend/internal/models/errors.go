@@ -6,20 +6,127 @@ var (
 	ErrScheduleNotFound    = errors.New("schedule not found")
 	ErrVisitAlreadyStarted = errors.New("visit already started")
 	ErrLocationTooFar      = errors.New("location too far from scheduled location")
-	ErrClockInTooEarly     = errors.New("cannot clock in more than 15 minutes early")
-	ErrClockInTooLate      = errors.New("cannot clock in more than 30 minutes after shift start")
+	ErrClockInTooEarly     = errors.New("too early to clock in, the clock-in window has not opened yet")
+	ErrClockInTooLate      = errors.New("too late to clock in, the clock-in window has closed")
 )
 
 var (
 	ErrVisitNotStarted   = errors.New("visit not started - cannot clock out")
 	ErrVisitAlreadyEnded = errors.New("visit already ended")
 	ErrClockOutTooEarly  = errors.New("cannot clock out before minimum visit time")
+	ErrVisitTooLong      = errors.New("visit is longer than the maximum visit time")
+	ErrDurationVariance  = errors.New("visit duration differs too much from the scheduled duration")
+
+	ErrDurationReasonRequired = errors.New("a reason is required to clock out with this visit duration")
+	ErrInvalidEarlyDeparture  = errors.New("early departure needs a valid reason code, and a note when the reason is other")
+
+	ErrAttestationRequired       = errors.New("client attestation is required to clock out")
+	ErrInvalidAttestation        = errors.New("attestation needs a signature with signer name and relationship, or a reason the client is unable to sign")
+	ErrInvalidSignature          = errors.New("signature must be non-empty strokes or a base64 encoded PNG")
+	ErrSignatureTooLarge         = errors.New("signature image is too large")
+	ErrInvalidSignerRelationship = errors.New("signer relationship must be client, family, guardian or other")
 )
 
 var (
 	ErrTaskNotFound       = errors.New("task not found")
 	ErrInvalidTaskStatus  = errors.New("invalid task status")
 	ErrReasonRequired     = errors.New("reason is required for not completed tasks")
-	ErrTaskAlreadyUpdated = errors.New("task already completed or marked as not completed")
+	ErrTaskAlreadyUpdated = errors.New("task was updated by another request, reload and try again")
 	ErrVisitNotInProgress = errors.New("cannot update tasks - visit not in progress")
+
+	ErrCorrectionReasonRequired  = errors.New("a correction reason is required to change a task's outcome")
+	ErrCorrectionWindowClosed    = errors.New("task outcome can no longer be corrected for this visit")
+	ErrApprovalRequired          = errors.New("corrections after clock-out must be requested for supervisor approval")
+	ErrInvalidApprover           = errors.New("a correction must be reviewed by someone other than the caregiver who requested it")
+	ErrSupervisorRequired        = errors.New("only supervisors and admins can review this")
+	ErrCorrectionNotFound        = errors.New("task correction not found")
+	ErrCorrectionAlreadyReviewed = errors.New("task correction has already been reviewed")
+	ErrCorrectionPending         = errors.New("task already has a correction waiting for approval")
+	ErrCorrectionNotNeeded       = errors.New("visit is still in progress, update the task directly")
+	ErrInvalidCorrectionStatus   = errors.New("correction status must be pending, approved or rejected")
+	ErrInvalidTaskUpdates        = errors.New("one or more task updates are invalid, nothing was saved")
+	ErrDuplicateTaskUpdate       = errors.New("task appears more than once in the request")
+)
+
+var (
+	ErrInvalidScheduleTime = errors.New("schedule end time must be after start time")
+	ErrScheduleConflict    = errors.New("schedule conflicts with caregiver's existing commitments")
+	ErrScheduleNotEditable = errors.New("schedule can no longer be changed")
+	ErrInvalidSkill        = errors.New("skill names must not be empty")
+)
+
+var (
+	ErrInvalidAvailability    = errors.New("invalid availability window")
+	ErrInvalidTimeOffRange    = errors.New("time off end must be after start")
+	ErrTimeOffNotFound        = errors.New("time off request not found")
+	ErrTimeOffAlreadyReviewed = errors.New("time off request already reviewed")
+)
+
+var (
+	ErrMissingCredential       = errors.New("caregiver lacks a valid credential required for this service")
+	ErrCredentialTypeNotFound  = errors.New("credential type not found")
+	ErrCredentialTypeExists    = errors.New("credential type code already exists")
+	ErrCredentialNotFound      = errors.New("credential not found")
+	ErrInvalidCredentialDates  = errors.New("credential expiry date must not be before issue date")
+	ErrInvalidCredentialWindow = errors.New("days must be between 1 and 365")
+)
+
+var (
+	ErrExceptionNotFound        = errors.New("visit has no such compliance exception")
+	ErrExceptionAlreadyResolved = errors.New("compliance exception already approved or rejected")
+	ErrReviewNoteRequired       = errors.New("a note is required to approve or reject an exception")
+	ErrInvalidExceptionStatus   = errors.New("status must be unresolved, all, open, acknowledged, approved or rejected")
+)
+
+var (
+	ErrWebhookNotFound      = errors.New("webhook subscription not found")
+	ErrInvalidWebhookURL    = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEventType     = errors.New("event types must be one or more of visit.started, visit.ended, visit.flagged, task.updated or task.not_completed")
+	ErrInvalidWebhookSecret = errors.New("webhook secret must be at least 16 characters")
+	ErrDeadLetterNotFound   = errors.New("dead letter not found")
+	ErrEventNotFound        = errors.New("event not found")
+)
+
+var (
+	ErrAlertNotFound            = errors.New("alert not found")
+	ErrAlertAlreadyAcknowledged = errors.New("alert has already been acknowledged")
+	ErrInvalidRiskLevel         = errors.New("risk level must be one of the configured escalation policies")
+	ErrInvalidClientName        = errors.New("client name must not be empty")
+)
+
+var (
+	ErrInvalidQuietHours = errors.New("quiet hours need both a start and an end formatted as HH:MM")
+)
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUnsupportedLocale = errors.New("locale must be one of en, es or tl")
+)
+
+var (
+	ErrInvalidLatitude  = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude = errors.New("longitude must be between -180 and 180")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidDate      = errors.New("date must be formatted as YYYY-MM-DD")
+	ErrInvalidDateRange = errors.New("to date must not be before from date")
+	ErrInvalidGroupBy   = errors.New("group_by must list caregiver, client, service and at most one of day, week or month")
+	ErrInvalidPayPeriod = errors.New("pay period is not configured")
+)
+
+var (
+	ErrNoteNotFound           = errors.New("note not found")
+	ErrNoteNotEditable        = errors.New("note can only be edited by its author within the edit window")
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrFileRequired           = errors.New("file is required")
+	ErrFileTooLarge           = errors.New("file exceeds the maximum upload size")
+	ErrUnsupportedFileType    = errors.New("file type is not allowed")
+	ErrInvalidDownloadURL     = errors.New("download link is invalid or has expired")
+	ErrTaskNotInSchedule      = errors.New("task does not belong to this schedule")
+	ErrAttachmentNotDeletable = errors.New("attachment can only be deleted by its uploader")
+)
+
+var (
+	ErrInvalidTaskValue     = errors.New("invalid task value")
+	ErrInvalidTaskOptions   = errors.New("invalid task options")
+	ErrRequiredTasksPending = errors.New("required tasks must be completed or explained before clocking out")
 )
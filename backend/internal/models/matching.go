@@ -0,0 +1,23 @@
+package models
+
+// VisitCount is the number of visits a caregiver has had, used for continuity of care
+type VisitCount struct {
+	UserID int64 `db:"user_id"`
+	Count  int64 `db:"count"`
+}
+
+const (
+	MatchFactorSkills       = "SKILLS"
+	MatchFactorAvailability = "AVAILABILITY"
+	MatchFactorLoad         = "LOAD"
+	MatchFactorDistance     = "DISTANCE"
+	MatchFactorContinuity   = "CONTINUITY"
+)
+
+// Maximum points each factor contributes to a caregiver's match score
+const (
+	MatchWeightSkills     = 30.0
+	MatchWeightLoad       = 25.0
+	MatchWeightDistance   = 25.0
+	MatchWeightContinuity = 20.0
+)
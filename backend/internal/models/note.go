@@ -0,0 +1,64 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type VisitNote struct {
+	ID         int64     `json:"id" db:"id"`
+	ScheduleID int64     `json:"schedule_id" db:"schedule_id"`
+	UserID     int64     `json:"user_id" db:"user_id"`
+	Body       string    `json:"body" db:"body"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+
+	// joined from users
+	AuthorName string `json:"author_name" db:"author_name"`
+}
+
+// IsEditableBy reports whether userID may still change the note at now
+func (n *VisitNote) IsEditableBy(userID int64, now time.Time, window time.Duration) bool {
+	return n.UserID == userID && now.Before(n.CreatedAt.Add(window))
+}
+
+func (n *VisitNote) ToNoteResponse(userID int64, now time.Time, window time.Duration) NoteResponse {
+	return NoteResponse{
+		ID:         n.ID,
+		ScheduleID: n.ScheduleID,
+		AuthorID:   n.UserID,
+		AuthorName: n.AuthorName,
+		Body:       n.Body,
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+		Edited:     n.UpdatedAt.After(n.CreatedAt),
+		Editable:   n.IsEditableBy(userID, now, window),
+	}
+}
+
+type VisitAttachment struct {
+	ID          int64         `json:"id" db:"id"`
+	ScheduleID  int64         `json:"schedule_id" db:"schedule_id"`
+	TaskID      sql.NullInt64 `json:"task_id,omitempty" db:"task_id"`
+	UserID      int64         `json:"user_id" db:"user_id"`
+	StorageKey  string        `json:"storage_key" db:"storage_key"`
+	FileName    string        `json:"file_name" db:"file_name"`
+	ContentType string        `json:"content_type" db:"content_type"`
+	SizeBytes   int64         `json:"size_bytes" db:"size_bytes"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+}
+
+func (a *VisitAttachment) ToAttachmentResponse(url string, expiresAt time.Time) AttachmentResponse {
+	return AttachmentResponse{
+		ID:           a.ID,
+		ScheduleID:   a.ScheduleID,
+		TaskID:       a.TaskID.Int64,
+		UploadedBy:   a.UserID,
+		FileName:     a.FileName,
+		ContentType:  a.ContentType,
+		SizeBytes:    a.SizeBytes,
+		URL:          url,
+		URLExpiresAt: expiresAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Notification kinds
+const (
+	NotificationVisitStarting   = "visit_starting"
+	NotificationClockOutMissing = "clock_out_missing"
+	NotificationVisitReassigned = "visit_reassigned"
+)
+
+// Notification channels
+const (
+	ChannelPush  = "push"
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+const (
+	NotificationStatusPending = "pending"
+	NotificationStatusSent    = "sent"
+	NotificationStatusFailed  = "failed"
+	NotificationStatusSkipped = "skipped"
+)
+
+// QuietHoursLayout is the HH:MM format of quiet hours in requests and responses
+const QuietHoursLayout = "15:04"
+
+type NotificationPreferences struct {
+	UserID          int64          `db:"user_id"`
+	PushEnabled     bool           `db:"push_enabled"`
+	SMSEnabled      bool           `db:"sms_enabled"`
+	EmailEnabled    bool           `db:"email_enabled"`
+	PushToken       sql.NullString `db:"push_token"`
+	QuietHoursStart sql.NullString `db:"quiet_hours_start"`
+	QuietHoursEnd   sql.NullString `db:"quiet_hours_end"`
+	Timezone        sql.NullString `db:"timezone"`
+}
+
+// DefaultNotificationPreferences applies to users who never saved any
+func DefaultNotificationPreferences(userID int64) NotificationPreferences {
+	return NotificationPreferences{UserID: userID, PushEnabled: true, EmailEnabled: true}
+}
+
+func (p *NotificationPreferences) ToNotificationPreferencesResponse() NotificationPreferencesResponse {
+	return NotificationPreferencesResponse{
+		UserID:          p.UserID,
+		PushEnabled:     p.PushEnabled,
+		SMSEnabled:      p.SMSEnabled,
+		EmailEnabled:    p.EmailEnabled,
+		HasPushToken:    p.PushToken.Valid && p.PushToken.String != "",
+		QuietHoursStart: clockTime(p.QuietHoursStart),
+		QuietHoursEnd:   clockTime(p.QuietHoursEnd),
+		Timezone:        p.Timezone.String,
+	}
+}
+
+// QuietUntil reports when the quiet hours around t end, if t falls inside them.
+// Quiet hours may wrap past midnight (e.g. 22:00 to 07:00) and are wall-clock times in loc.
+func (p *NotificationPreferences) QuietUntil(t time.Time, loc *time.Location) (time.Time, bool) {
+	start, okStart := minuteOfDay(p.QuietHoursStart)
+	end, okEnd := minuteOfDay(p.QuietHoursEnd)
+	if !okStart || !okEnd || start == end {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+	endOn := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, end/60, end%60, 0, 0, loc)
+	}
+
+	switch {
+	case start < end && now >= start && now < end:
+		return endOn(0), true
+	case start > end && now >= start:
+		return endOn(1), true
+	case start > end && now < end:
+		return endOn(0), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// minuteOfDay reads an HH:MM time of day as minutes since midnight
+func minuteOfDay(v sql.NullString) (int, bool) {
+	if !v.Valid || len(v.String) < 5 {
+		return 0, false
+	}
+	t, err := time.Parse(QuietHoursLayout, v.String[:5])
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func clockTime(v sql.NullString) string {
+	if !v.Valid || len(v.String) < 5 {
+		return ""
+	}
+	return v.String[:5]
+}
+
+// Notification is a queued message with what is needed to render and address it
+type Notification struct {
+	ID         int64          `db:"id"`
+	UserID     int64          `db:"user_id"`
+	ScheduleID int64          `db:"schedule_id"`
+	Kind       string         `db:"kind"`
+	Channel    string         `db:"channel"`
+	Status     string         `db:"status"`
+	SendAfter  time.Time      `db:"send_after"`
+	ExpiresAt  sql.NullTime   `db:"expires_at"`
+	Attempts   int            `db:"attempts"`
+	LastError  sql.NullString `db:"last_error"`
+	SentAt     sql.NullTime   `db:"sent_at"`
+
+	// joined from users, notification_preferences and schedules
+	UserName    string                  `db:"user_name"`
+	Email       string                  `db:"email"`
+	Phone       sql.NullString          `db:"phone"`
+	Locale      sql.NullString          `db:"locale"`
+	ClientName  string                  `db:"client_name"`
+	ServiceName string                  `db:"service_name"`
+	Location    string                  `db:"location"`
+	StartTime   time.Time               `db:"start_time"`
+	EndTime     time.Time               `db:"end_time"`
+	Preferences NotificationPreferences `db:"preferences"`
+}
+
+// Address is where the notification goes on its channel; empty when the caregiver has none
+func (n *Notification) Address() string {
+	switch n.Channel {
+	case ChannelPush:
+		return n.Preferences.PushToken.String
+	case ChannelSMS:
+		return n.Phone.String
+	case ChannelEmail:
+		return n.Email
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql"
+	"math"
+)
+
+const ReportDateLayout = "2006-01-02"
+
+// Dimensions a compliance report can be grouped by; at most one of the period dimensions is allowed
+const (
+	ReportGroupCaregiver = "caregiver"
+	ReportGroupClient    = "client"
+	ReportGroupService   = "service"
+	ReportGroupDay       = "day"
+	ReportGroupWeek      = "week"
+	ReportGroupMonth     = "month"
+)
+
+func IsReportPeriod(groupBy string) bool {
+	return groupBy == ReportGroupDay || groupBy == ReportGroupWeek || groupBy == ReportGroupMonth
+}
+
+func IsValidReportGroup(groupBy string) bool {
+	switch groupBy {
+	case ReportGroupCaregiver, ReportGroupClient, ReportGroupService:
+		return true
+	default:
+		return IsReportPeriod(groupBy)
+	}
+}
+
+// ComplianceStats holds the summed visit facts of one report group; unused dimensions stay NULL
+type ComplianceStats struct {
+	UserID        sql.NullInt64  `db:"user_id"`
+	CaregiverName sql.NullString `db:"caregiver_name"`
+	ClientName    sql.NullString `db:"client_name"`
+	ServiceName   sql.NullString `db:"service_name"`
+	Period        sql.NullTime   `db:"period"`
+
+	TotalVisits        int64 `db:"total_visits"`
+	ClockedInVisits    int64 `db:"clocked_in_visits"`
+	OnTimeClockIns     int64 `db:"on_time_clock_ins"`
+	LocationExceptions int64 `db:"location_exceptions"`
+	MissedVisits       int64 `db:"missed_visits"`
+	TasksTotal         int64 `db:"tasks_total"`
+	TasksCompleted     int64 `db:"tasks_completed"`
+	TimedVisits        int64 `db:"timed_visits"`
+	ActualMinutes      int64 `db:"actual_minutes"`
+	ScheduledMinutes   int64 `db:"scheduled_minutes"`
+}
+
+// Add sums other into s, used to build the report totals
+func (s *ComplianceStats) Add(other ComplianceStats) {
+	s.TotalVisits += other.TotalVisits
+	s.ClockedInVisits += other.ClockedInVisits
+	s.OnTimeClockIns += other.OnTimeClockIns
+	s.LocationExceptions += other.LocationExceptions
+	s.MissedVisits += other.MissedVisits
+	s.TasksTotal += other.TasksTotal
+	s.TasksCompleted += other.TasksCompleted
+	s.TimedVisits += other.TimedVisits
+	s.ActualMinutes += other.ActualMinutes
+	s.ScheduledMinutes += other.ScheduledMinutes
+}
+
+// ToComplianceReportRow turns the sums into rates (percentages) and per-visit averages
+func (s *ComplianceStats) ToComplianceReportRow() ComplianceReportRow {
+	row := ComplianceReportRow{
+		CaregiverID:           s.UserID.Int64,
+		CaregiverName:         s.CaregiverName.String,
+		ClientName:            s.ClientName.String,
+		ServiceName:           s.ServiceName.String,
+		TotalVisits:           s.TotalVisits,
+		ClockedInVisits:       s.ClockedInVisits,
+		MissedVisits:          s.MissedVisits,
+		OnTimeClockInRate:     percent(s.OnTimeClockIns, s.ClockedInVisits),
+		LocationExceptionRate: percent(s.LocationExceptions, s.ClockedInVisits),
+		MissedVisitRate:       percent(s.MissedVisits, s.TotalVisits),
+		TaskCompletionRate:    percent(s.TasksCompleted, s.TasksTotal),
+	}
+	if s.Period.Valid {
+		row.Period = s.Period.Time.Format(ReportDateLayout)
+	}
+	if s.TimedVisits > 0 {
+		row.AvgActualMinutes = roundTenth(float64(s.ActualMinutes) / float64(s.TimedVisits))
+		row.AvgScheduledMinutes = roundTenth(float64(s.ScheduledMinutes) / float64(s.TimedVisits))
+		row.AvgVarianceMinutes = roundTenth(float64(s.ActualMinutes-s.ScheduledMinutes) / float64(s.TimedVisits))
+	}
+
+	return row
+}
+
+func percent(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return roundTenth(float64(part) * 100 / float64(whole))
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
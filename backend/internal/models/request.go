@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ClockInOutRequest struct {
 	Latitude  float64    `json:"latitude" binding:"required"`
@@ -8,6 +11,42 @@ type ClockInOutRequest struct {
 	Timestamp *time.Time `json:"timestamp,omitempty"`
 }
 
+type ClockOutRequest struct {
+	ClockInOutRequest
+	Attestation    *AttestationRequest    `json:"attestation,omitempty"`
+	DurationReason string                 `json:"duration_reason,omitempty"`
+	EarlyDeparture *EarlyDepartureRequest `json:"early_departure,omitempty"`
+}
+
+// EarlyDepartureRequest explains a visit ended early, e.g. because the client sent the caregiver home
+type EarlyDepartureRequest struct {
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note,omitempty"`
+}
+
+type SignaturePoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	T int64   `json:"t,omitempty"`
+}
+
+// SignatureRequest carries either vector strokes or a base64 encoded PNG image
+type SignatureRequest struct {
+	Format  string             `json:"format" binding:"required"`
+	Strokes [][]SignaturePoint `json:"strokes,omitempty"`
+	PNG     string             `json:"png,omitempty"`
+}
+
+// AttestationRequest is the client's confirmation that the visit happened,
+// either a signature with the signer's details or the reason the client could not sign
+type AttestationRequest struct {
+	Signature          *SignatureRequest `json:"signature,omitempty"`
+	SignerName         string            `json:"signer_name,omitempty"`
+	SignerRelationship string            `json:"signer_relationship,omitempty"`
+	UnableToSignReason string            `json:"unable_to_sign_reason,omitempty"`
+	CaregiverSignature *SignatureRequest `json:"caregiver_signature,omitempty"`
+}
+
 type ClockInResponse struct {
 	ClockInTime    time.Time `json:"clock_in_time"`
 	CanProceed     bool      `json:"can_proceed"`
@@ -15,20 +54,189 @@ type ClockInResponse struct {
 }
 
 type ClockOutResponse struct {
-	ClockInTime   time.Time `json:"clock_in_time"`
-	ClockOutTime  time.Time `json:"clock_out_time"`
-	TotalDuration string    `json:"total_duration"`
-	Date          string    `json:"date"`
+	ClockInTime      time.Time `json:"clock_in_time"`
+	ClockOutTime     time.Time `json:"clock_out_time"`
+	TotalDuration    string    `json:"total_duration"`
+	ActualMinutes    int64     `json:"actual_minutes"`
+	ScheduledMinutes int64     `json:"scheduled_minutes"`
+	VarianceMinutes  int64     `json:"variance_minutes"`
+	Date             string    `json:"date"`
+	WarningMessage   string    `json:"warning_message,omitempty"`
 }
 
 type UpdateTaskRequest struct {
-	Status string `json:"status" binding:"required"`
-	Reason string `json:"reason,omitempty"`
+	Status string          `json:"status" binding:"required"`
+	Reason string          `json:"reason,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+
+	// required when changing a task that already has an outcome
+	CorrectionReason string `json:"correction_reason,omitempty"`
+}
+
+type BulkTaskUpdate struct {
+	TaskID int64 `json:"task_id" binding:"required"`
+	UpdateTaskRequest
+}
+
+type BulkUpdateTasksRequest struct {
+	Tasks []BulkTaskUpdate `json:"tasks" binding:"required,dive"`
+}
+
+type CreateTaskRequest struct {
+	Name        string       `json:"name" binding:"required"`
+	Description string       `json:"description,omitempty"`
+	Required    bool         `json:"required"`
+	TaskType    string       `json:"task_type,omitempty"`
+	Options     *TaskOptions `json:"options,omitempty"`
+}
+
+type CreateScheduleRequest struct {
+	UserID       int64     `json:"user_id,omitempty"`
+	ClientName   string    `json:"client_name" binding:"required"`
+	ServiceName  string    `json:"service_name" binding:"required"`
+	ServiceNotes string    `json:"service_notes,omitempty"`
+	Location     string    `json:"location" binding:"required"`
+	Latitude     float64   `json:"latitude" binding:"required"`
+	Longitude    float64   `json:"longitude" binding:"required"`
+	StartTime    time.Time `json:"start_time" binding:"required"`
+	EndTime      time.Time `json:"end_time" binding:"required"`
+
+	Tasks []CreateTaskRequest `json:"tasks,omitempty" binding:"dive"`
+}
+
+type AssignScheduleRequest struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
+
+type AvailabilityWindowRequest struct {
+	DayOfWeek int    `json:"day_of_week" binding:"min=0,max=6"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+	Timezone  string `json:"timezone,omitempty"`
+}
+
+type SetAvailabilityRequest struct {
+	Windows []AvailabilityWindowRequest `json:"windows"`
+}
+
+type CreateTimeOffRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+type ReviewTimeOffRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ExceptionQueryRequest holds the review queue filters; from and to are inclusive YYYY-MM-DD dates
+type ExceptionQueryRequest struct {
+	Flag        string
+	CaregiverID int64
+	Client      string
+	From        string
+	To          string
+	Status      string
+}
+
+// StreamQueryRequest holds the visit stream filters; types is a comma separated list of event types
+type StreamQueryRequest struct {
+	CaregiverID int64
+	ScheduleID  int64
+	Client      string
+	Types       string
+}
+
+// ComplianceReportRequest holds the report query; from and to are inclusive YYYY-MM-DD dates
+type ComplianceReportRequest struct {
+	From    string
+	To      string
+	GroupBy []string
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+// UpdateWebhookRequest replaces a subscription's URL and event types; a new secret rotates it
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+type AcknowledgeAlertRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type ReviewTaskCorrectionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type SetClientRiskLevelRequest struct {
+	ClientName string `json:"client_name" binding:"required"`
+	RiskLevel  string `json:"risk_level" binding:"required"`
+}
+
+type ReviewExceptionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type SetSkillsRequest struct {
+	Skills []string `json:"skills"`
+}
+
+type CreateCredentialTypeRequest struct {
+	Code        string `json:"code" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+type CreateUserCredentialRequest struct {
+	CredentialTypeID int64  `json:"credential_type_id" binding:"required"`
+	CredentialNumber string `json:"credential_number,omitempty"`
+	IssuedAt         string `json:"issued_at" binding:"required"`
+	ExpiresAt        string `json:"expires_at,omitempty"`
+	DocumentRef      string `json:"document_ref,omitempty"`
+}
+
+type SetCredentialRequirementsRequest struct {
+	CredentialTypeIDs []int64 `json:"credential_type_ids"`
 }
 
 const (
-	ComplianceLocationError = "LOCATION_ERROR"
-	ComplianceLocationWarn  = "LOCATION_WARNING"
-	ComplianceTimeError     = "TIME_ERROR"
-	ComplianceTimeWarning   = "TIME_WARNING"
+	ComplianceLocationError      = "LOCATION_ERROR"
+	ComplianceLocationWarn       = "LOCATION_WARNING"
+	ComplianceTimeError          = "TIME_ERROR"
+	ComplianceTimeWarning        = "TIME_WARNING"
+	ComplianceCredentialWarn     = "CREDENTIAL_WARNING"
+	ComplianceAttestationMissing = "ATTESTATION_MISSING"
+	ComplianceTasksPending       = "REQUIRED_TASKS_PENDING"
+	ComplianceDurationShort      = "DURATION_TOO_SHORT"
+	ComplianceDurationLong       = "DURATION_TOO_LONG"
+	ComplianceDurationVariance   = "DURATION_VARIANCE"
+	ComplianceEarlyDeparture     = "EARLY_DEPARTURE"
 )
+
+type NoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// UpdateNotificationPreferencesRequest replaces a caregiver's preferences; quiet hours are HH:MM in their timezone
+// (the agency's when empty), and an omitted push token keeps the registered one
+type UpdateNotificationPreferencesRequest struct {
+	PushEnabled     bool    `json:"push_enabled"`
+	SMSEnabled      bool    `json:"sms_enabled"`
+	EmailEnabled    bool    `json:"email_enabled"`
+	PushToken       *string `json:"push_token,omitempty"`
+	QuietHoursStart string  `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string  `json:"quiet_hours_end,omitempty"`
+	Timezone        string  `json:"timezone,omitempty"`
+}
+
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale" binding:"required"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ListScheduleResponse struct {
 	Stats     StatsResponse      `json:"stats,omitempty"`
@@ -15,6 +18,7 @@ type StatsResponse struct {
 
 type ScheduleResponse struct {
 	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id,omitempty"`
 	ClientName  string    `json:"client_name"`
 	ServiceName string    `json:"service_name"`
 	Location    string    `json:"location"`
@@ -28,8 +32,10 @@ type ScheduleResponse struct {
 	ClockOutTime     time.Time `json:"clock_out_time,omitempty"`
 	ClockInLocation  string    `json:"clock_in_location,omitempty"`
 	ClockOutLocation string    `json:"clock_out_location,omitempty"`
+	ReviewStatus     string    `json:"review_status,omitempty"`
 
-	Tasks []TaskResponse `json:"tasks,omitempty"`
+	Tasks       []TaskResponse       `json:"tasks,omitempty"`
+	Attestation *AttestationResponse `json:"attestation,omitempty"`
 
 	// TODO: evaluate if returning lat & long is necessary instead of directly return location name
 	// ClockInLatitude  string `json:"clock_in_latitude"`
@@ -38,14 +44,304 @@ type ScheduleResponse struct {
 	// ClockOutLongitude string `json:"clock_out_longitude"`
 }
 
+// ClockWindowResponse is returned with a refused clock-in; outside_by_minutes is rounded up
+type ClockWindowResponse struct {
+	OpensAt          time.Time `json:"opens_at"`
+	ClosesAt         time.Time `json:"closes_at"`
+	AttemptedAt      time.Time `json:"attempted_at"`
+	OutsideBySeconds int64     `json:"outside_by_seconds"`
+	OutsideByMinutes int64     `json:"outside_by_minutes"`
+}
+
+// VisitDurationResponse is returned with a refused clock-out; violation is the compliance flag that was breached
+type VisitDurationResponse struct {
+	Violation        string `json:"violation"`
+	ActualMinutes    int64  `json:"actual_minutes"`
+	ScheduledMinutes int64  `json:"scheduled_minutes"`
+	VarianceMinutes  int64  `json:"variance_minutes"`
+	LimitMinutes     int64  `json:"limit_minutes"`
+}
+
+type AttestationResponse struct {
+	SignerName               string    `json:"signer_name,omitempty"`
+	SignerRelationship       string    `json:"signer_relationship,omitempty"`
+	SignatureFormat          string    `json:"signature_format,omitempty"`
+	SignatureData            string    `json:"signature_data,omitempty"`
+	UnableToSignReason       string    `json:"unable_to_sign_reason,omitempty"`
+	CaregiverSignatureFormat string    `json:"caregiver_signature_format,omitempty"`
+	CaregiverSignatureData   string    `json:"caregiver_signature_data,omitempty"`
+	CapturedAt               time.Time `json:"captured_at"`
+}
+
 type TaskResponse struct {
-	ID          int64     `json:"id"`
-	ScheduleID  int64     `json:"schedule_id" `
-	Name        string    `json:"name" `
-	Description string    `json:"description,omitempty" `
-	Status      string    `json:"status"`
-	Reason      string    `json:"reason,omitempty"`
-	CompletedAt time.Time `json:"completed_at,omitempty"`
+	ID          int64           `json:"id"`
+	ScheduleID  int64           `json:"schedule_id" `
+	Name        string          `json:"name" `
+	Description string          `json:"description,omitempty" `
+	Status      string          `json:"status"`
+	Reason      string          `json:"reason,omitempty"`
+	CompletedAt time.Time       `json:"completed_at,omitempty"`
+	IsRequired  bool            `json:"is_required"`
+	SortOrder   int             `json:"sort_order"`
+	TaskType    string          `json:"task_type"`
+	Options     json.RawMessage `json:"options,omitempty"`
+	Value       json.RawMessage `json:"value,omitempty"`
+}
+
+type TaskUpdateItemError struct {
+	Index  int    `json:"index"`
+	TaskID int64  `json:"task_id"`
+	Code   string `json:"code"`
+	Error  string `json:"error"`
+}
+
+type TaskStatusChangeResponse struct {
+	ID           int64           `json:"id"`
+	TaskID       int64           `json:"task_id"`
+	ChangedBy    int64           `json:"changed_by"`
+	ApprovedBy   int64           `json:"approved_by,omitempty"`
+	FromStatus   string          `json:"from_status"`
+	ToStatus     string          `json:"to_status"`
+	FromReason   string          `json:"from_reason,omitempty"`
+	ToReason     string          `json:"to_reason,omitempty"`
+	FromValue    json.RawMessage `json:"from_value,omitempty"`
+	ToValue      json.RawMessage `json:"to_value,omitempty"`
+	ChangeReason string          `json:"change_reason,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type TaskCorrectionResponse struct {
+	ID               int64           `json:"id"`
+	TaskID           int64           `json:"task_id"`
+	ScheduleID       int64           `json:"schedule_id"`
+	RequestedBy      int64           `json:"requested_by"`
+	FromStatus       string          `json:"from_status"`
+	ToStatus         string          `json:"to_status"`
+	ToReason         string          `json:"to_reason,omitempty"`
+	ToValue          json.RawMessage `json:"to_value,omitempty"`
+	CorrectionReason string          `json:"correction_reason"`
+	Status           string          `json:"status"`
+	ReviewedBy       int64           `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewNote       string          `json:"review_note,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type AvailabilityResponse struct {
+	ID        int64  `json:"id"`
+	DayOfWeek int    `json:"day_of_week"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Timezone  string `json:"timezone"`
+}
+
+type TimeOffResponse struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Reason     string    `json:"reason,omitempty"`
+	Status     string    `json:"status"`
+	ReviewedBy int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string    `json:"review_note,omitempty"`
+}
+
+type MatchFactorResponse struct {
+	Factor string  `json:"factor"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+type CaregiverMatchResponse struct {
+	UserID    int64                 `json:"user_id"`
+	Name      string                `json:"name"`
+	Eligible  bool                  `json:"eligible"`
+	Score     float64               `json:"score"`
+	Factors   []MatchFactorResponse `json:"factors"`
+	Conflicts []Conflict            `json:"conflicts,omitempty"`
+}
+
+type ScheduleMatchResponse struct {
+	ScheduleID     int64                    `json:"schedule_id"`
+	RequiredSkills []string                 `json:"required_skills"`
+	Candidates     []CaregiverMatchResponse `json:"candidates"`
+}
+
+type UserSkillsResponse struct {
+	UserID int64    `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type UserLocaleResponse struct {
+	UserID int64  `json:"user_id"`
+	Locale string `json:"locale"`
+}
+
+type ServiceRequirementsResponse struct {
+	ServiceName string   `json:"service_name"`
+	Skills      []string `json:"skills"`
+}
+
+type CredentialTypeResponse struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type UserCredentialResponse struct {
+	ID               int64  `json:"id"`
+	UserID           int64  `json:"user_id"`
+	UserName         string `json:"user_name,omitempty"`
+	CredentialTypeID int64  `json:"credential_type_id"`
+	TypeCode         string `json:"type_code"`
+	TypeName         string `json:"type_name"`
+	CredentialNumber string `json:"credential_number,omitempty"`
+	IssuedAt         string `json:"issued_at"`
+	ExpiresAt        string `json:"expires_at,omitempty"`
+	DaysUntilExpiry  *int   `json:"days_until_expiry,omitempty"`
+	DocumentRef      string `json:"document_ref,omitempty"`
+	IsValid          bool   `json:"is_valid"`
+}
+
+type CredentialRequirementsResponse struct {
+	ServiceName     string                   `json:"service_name"`
+	CredentialTypes []CredentialTypeResponse `json:"credential_types"`
+}
+
+type ItineraryLegResponse struct {
+	FromScheduleID int64   `json:"from_schedule_id"`
+	ToScheduleID   int64   `json:"to_schedule_id"`
+	DistanceKm     float64 `json:"distance_km"`
+	TravelMinutes  float64 `json:"travel_minutes"`
+	GapMinutes     float64 `json:"gap_minutes"`
+	Feasible       bool    `json:"feasible"`
+}
+
+type ItineraryResponse struct {
+	UserID             int64                  `json:"user_id"`
+	Date               string                 `json:"date"`
+	Provider           string                 `json:"provider"`
+	Visits             []ScheduleResponse     `json:"visits"`
+	Legs               []ItineraryLegResponse `json:"legs"`
+	TotalDistanceKm    float64                `json:"total_distance_km"`
+	TotalTravelMinutes float64                `json:"total_travel_minutes"`
+	HasInfeasibleLegs  bool                   `json:"has_infeasible_legs"`
+}
+
+type MileageDayResponse struct {
+	Date       string  `json:"date"`
+	Legs       int     `json:"legs"`
+	DistanceKm float64 `json:"distance_km"`
+	Miles      float64 `json:"miles"`
+}
+
+type MileageReportResponse struct {
+	UserID          int64                `json:"user_id"`
+	PeriodStart     string               `json:"period_start"`
+	PeriodEnd       string               `json:"period_end"`
+	Days            []MileageDayResponse `json:"days"`
+	TotalDistanceKm float64              `json:"total_distance_km"`
+	TotalMiles      float64              `json:"total_miles"`
+	RatePerMile     float64              `json:"rate_per_mile"`
+	Reimbursement   float64              `json:"reimbursement"`
+}
+
+type VisitExceptionResponse struct {
+	ScheduleID      int64     `json:"schedule_id"`
+	UserID          int64     `json:"user_id"`
+	CaregiverName   string    `json:"caregiver_name"`
+	ClientName      string    `json:"client_name"`
+	ServiceName     string    `json:"service_name"`
+	StartTime       time.Time `json:"start_time"`
+	ShiftDate       string    `json:"shift_date"`
+	Flag            string    `json:"flag"`
+	ValidationNotes string    `json:"validation_notes,omitempty"`
+	Status          string    `json:"status"`
+	ReviewedBy      int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt      time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote      string    `json:"review_note,omitempty"`
+}
+
+// ComplianceReportRow is one group of a compliance report; rates are percentages and averages cover completed visits
+type ComplianceReportRow struct {
+	CaregiverID   int64  `json:"caregiver_id,omitempty"`
+	CaregiverName string `json:"caregiver_name,omitempty"`
+	ClientName    string `json:"client_name,omitempty"`
+	ServiceName   string `json:"service_name,omitempty"`
+	Period        string `json:"period,omitempty"`
+
+	TotalVisits           int64   `json:"total_visits"`
+	ClockedInVisits       int64   `json:"clocked_in_visits"`
+	MissedVisits          int64   `json:"missed_visits"`
+	OnTimeClockInRate     float64 `json:"on_time_clock_in_rate"`
+	LocationExceptionRate float64 `json:"location_exception_rate"`
+	MissedVisitRate       float64 `json:"missed_visit_rate"`
+	TaskCompletionRate    float64 `json:"task_completion_rate"`
+	AvgActualMinutes      float64 `json:"avg_actual_minutes"`
+	AvgScheduledMinutes   float64 `json:"avg_scheduled_minutes"`
+	AvgVarianceMinutes    float64 `json:"avg_variance_minutes"`
+}
+
+type ComplianceReportResponse struct {
+	From    string                `json:"from"`
+	To      string                `json:"to"`
+	GroupBy []string              `json:"group_by"`
+	Rows    []ComplianceReportRow `json:"rows"`
+	Totals  ComplianceReportRow   `json:"totals"`
+}
+
+type WebhookSubscriptionResponse struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	SubscriptionID int64           `json:"subscription_id"`
+	URL            string          `json:"url"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int64           `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type NotificationPreferencesResponse struct {
+	UserID          int64  `json:"user_id"`
+	PushEnabled     bool   `json:"push_enabled"`
+	SMSEnabled      bool   `json:"sms_enabled"`
+	EmailEnabled    bool   `json:"email_enabled"`
+	HasPushToken    bool   `json:"has_push_token"`
+	QuietHoursStart string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string `json:"quiet_hours_end,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+}
+
+type VisitAlertResponse struct {
+	ID                  int64      `json:"id"`
+	ScheduleID          int64      `json:"schedule_id"`
+	Step                int        `json:"step"`
+	Contact             string     `json:"contact"`
+	RecipientID         int64      `json:"recipient_id"`
+	RecipientName       string     `json:"recipient_name"`
+	RiskLevel           string     `json:"risk_level"`
+	MinutesLate         int64      `json:"minutes_late"`
+	DeliveredChannels   []string   `json:"delivered_channels"`
+	DeliveryError       string     `json:"delivery_error,omitempty"`
+	SentAt              time.Time  `json:"sent_at"`
+	AcknowledgedBy      int64      `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt      *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgementNote string     `json:"acknowledgement_note,omitempty"`
 }
 
 type APIResponse struct {
@@ -55,8 +351,8 @@ type APIResponse struct {
 	Error   *APIError   `json:"error,omitempty"`
 }
 
-// TODO: implement error code for easier and better debugging
 type APIError struct {
+	Code    string `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail,omitempty"`
 }
@@ -69,12 +365,38 @@ func NewSuccessResponse(message string, data interface{}) APIResponse {
 	}
 }
 
-func NewErrorResponse(message, detail string) APIResponse {
+func NewErrorResponse(code, message, detail string) APIResponse {
 	return APIResponse{
 		Success: false,
 		Error: &APIError{
+			Code:    code,
 			Message: message,
 			Detail:  detail,
 		},
 	}
 }
+
+type NoteResponse struct {
+	ID         int64     `json:"id"`
+	ScheduleID int64     `json:"schedule_id"`
+	AuthorID   int64     `json:"author_id"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Edited     bool      `json:"edited"`
+	Editable   bool      `json:"editable"`
+}
+
+type AttachmentResponse struct {
+	ID           int64     `json:"id"`
+	ScheduleID   int64     `json:"schedule_id"`
+	TaskID       int64     `json:"task_id,omitempty"`
+	UploadedBy   int64     `json:"uploaded_by"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	URL          string    `json:"url"`
+	URLExpiresAt time.Time `json:"url_expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+)
+
+// VisitException is one compliance flag raised on a visit, together with the supervisor's review if there is one
+type VisitException struct {
+	ScheduleID      int64          `json:"schedule_id" db:"schedule_id"`
+	UserID          int64          `json:"user_id" db:"user_id"`
+	ClientName      string         `json:"client_name" db:"client_name"`
+	ServiceName     string         `json:"service_name" db:"service_name"`
+	StartTime       time.Time      `json:"start_time" db:"start_time"`
+	Flag            string         `json:"flag" db:"flag"`
+	ValidationNotes sql.NullString `json:"validation_notes,omitempty" db:"validation_notes"`
+	Status          string         `json:"status" db:"status"`
+	ReviewedBy      sql.NullInt64  `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      sql.NullTime   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote      sql.NullString `json:"review_note,omitempty" db:"review_note"`
+
+	// joined from users
+	CaregiverName string `json:"caregiver_name" db:"caregiver_name"`
+}
+
+func (e *VisitException) ToVisitExceptionResponse() VisitExceptionResponse {
+	return VisitExceptionResponse{
+		ScheduleID:      e.ScheduleID,
+		UserID:          e.UserID,
+		CaregiverName:   e.CaregiverName,
+		ClientName:      e.ClientName,
+		ServiceName:     e.ServiceName,
+		StartTime:       e.StartTime,
+		ShiftDate:       helpers.FormatShiftDate(e.StartTime),
+		Flag:            e.Flag,
+		ValidationNotes: e.ValidationNotes.String,
+		Status:          e.Status,
+		ReviewedBy:      e.ReviewedBy.Int64,
+		ReviewedAt:      e.ReviewedAt.Time,
+		ReviewNote:      e.ReviewNote.String,
+	}
+}
+
+// IsResolved reports whether a supervisor has approved or rejected the exception; acknowledged ones stay in the queue
+func (e *VisitException) IsResolved() bool {
+	return e.Status == ExceptionStatusApproved || e.Status == ExceptionStatusRejected
+}
+
+const (
+	ExceptionStatusOpen         = "open"
+	ExceptionStatusAcknowledged = "acknowledged"
+	ExceptionStatusApproved     = "approved"
+	ExceptionStatusRejected     = "rejected"
+
+	// ExceptionStatusUnresolved filters the queue to open and acknowledged exceptions
+	ExceptionStatusUnresolved = "unresolved"
+	ExceptionStatusAll        = "all"
+)
+
+func IsValidExceptionStatusFilter(status string) bool {
+	switch status {
+	case ExceptionStatusUnresolved, ExceptionStatusAll, ExceptionStatusOpen, ExceptionStatusAcknowledged,
+		ExceptionStatusApproved, ExceptionStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Review statuses of a whole visit, as exposed to payroll and aggregator exports through visit_review_status
+const (
+	VisitReviewClear    = "clear"
+	VisitReviewPending  = "pending"
+	VisitReviewApproved = "approved"
+	VisitReviewRejected = "rejected"
+)
+
+// ExceptionFilter narrows the review queue; zero values are not filtered on
+type ExceptionFilter struct {
+	Flag       string
+	UserID     int64
+	ClientName string
+	From       time.Time
+	To         time.Time
+	Status     string
+}
+
+// ExceptionReview is a supervisor's decision on one compliance flag of a visit
+type ExceptionReview struct {
+	ScheduleID int64          `db:"schedule_id"`
+	Flag       string         `db:"flag"`
+	Status     string         `db:"status"`
+	ReviewedBy int64          `db:"reviewed_by"`
+	Note       sql.NullString `db:"note"`
+	ReviewedAt time.Time      `db:"reviewed_at"`
+}
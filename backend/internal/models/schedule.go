@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+	"github.com/erizkiatama/bluehorntech/pkg/routing"
 	"github.com/lib/pq"
 )
 
@@ -26,10 +27,21 @@ type Schedule struct {
 	ClockInLongitude  sql.NullFloat64 `json:"clock_in_longitude,omitempty" db:"clock_in_longitude"`
 	ClockOutLatitude  sql.NullFloat64 `json:"clock_out_latitude,omitempty" db:"clock_out_latitude"`
 	ClockOutLongitude sql.NullFloat64 `json:"clock_out_longitude,omitempty" db:"clock_out_longitude"`
+	ClockInAddress    sql.NullString  `json:"clock_in_address,omitempty" db:"clock_in_address"`
+	ClockOutAddress   sql.NullString  `json:"clock_out_address,omitempty" db:"clock_out_address"`
 	ComplianceFlags   pq.StringArray  `json:"compliance_flags,omitempty" db:"compliance_flags"`
 	ValidationNotes   sql.NullString  `json:"validation_notes,omitempty" db:"validation_notes"`
-	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+	ActualMinutes     sql.NullInt64   `json:"actual_minutes,omitempty" db:"actual_minutes"`
+	ScheduledMinutes  sql.NullInt64   `json:"scheduled_minutes,omitempty" db:"scheduled_minutes"`
+	VarianceMinutes   sql.NullInt64   `json:"variance_minutes,omitempty" db:"variance_minutes"`
+
+	EarlyDepartureReason sql.NullString `json:"early_departure_reason,omitempty" db:"early_departure_reason"`
+	EarlyDepartureNote   sql.NullString `json:"early_departure_note,omitempty" db:"early_departure_note"`
+
+	// joined from visit_review_status
+	ReviewStatus string    `json:"review_status,omitempty" db:"review_status"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 
 	Tasks []Task `json:"tasks,omitempty"`
 }
@@ -37,6 +49,7 @@ type Schedule struct {
 func (s *Schedule) ToScheduleResponse() ScheduleResponse {
 	return ScheduleResponse{
 		ID:          s.ID,
+		UserID:      s.UserID,
 		ClientName:  s.ClientName,
 		ServiceName: s.ServiceName,
 		Location:    s.Location,
@@ -50,10 +63,17 @@ func (s *Schedule) ToScheduleResponse() ScheduleResponse {
 func (s *Schedule) ToScheduleDetailResponse(tasks []Task) *ScheduleResponse {
 	var clockInLocation, clockOutLocation string
 
-	if s.ClockInLatitude.Valid && s.ClockInLongitude.Valid {
+	// addresses are resolved at clock time; visits recorded before that only have coordinates
+	switch {
+	case s.ClockInAddress.Valid:
+		clockInLocation = s.ClockInAddress.String
+	case s.ClockInLatitude.Valid && s.ClockInLongitude.Valid:
 		clockInLocation = helpers.GetLocationDetail(s.ClockInLatitude.Float64, s.ClockInLongitude.Float64)
 	}
-	if s.ClockOutLatitude.Valid && s.ClockOutLongitude.Valid {
+	switch {
+	case s.ClockOutAddress.Valid:
+		clockOutLocation = s.ClockOutAddress.String
+	case s.ClockOutLatitude.Valid && s.ClockOutLongitude.Valid:
 		clockOutLocation = helpers.GetLocationDetail(s.ClockOutLatitude.Float64, s.ClockOutLongitude.Float64)
 	}
 
@@ -63,6 +83,7 @@ func (s *Schedule) ToScheduleDetailResponse(tasks []Task) *ScheduleResponse {
 	resp.ClockOutTime = s.ClockOutTime.Time
 	resp.ClockInLocation = clockInLocation
 	resp.ClockOutLocation = clockOutLocation
+	resp.ReviewStatus = s.ReviewStatus
 
 	for _, t := range tasks {
 		tPointer := &t
@@ -91,6 +112,22 @@ func (s *Schedule) CanEnd() bool {
 	return s.Status == StatusInProgress
 }
 
+func (s *Schedule) Point() routing.Point {
+	return routing.Point{Latitude: s.Latitude, Longitude: s.Longitude}
+}
+
+func (s *Schedule) IsAssigned() bool {
+	return s.UserID != 0
+}
+
+func (s *Schedule) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+func (s *Schedule) Overlaps(start, end time.Time) bool {
+	return s.StartTime.Before(end) && s.EndTime.After(start)
+}
+
 func IsValidScheduleStatus(status string) bool {
 	switch status {
 	case StatusScheduled, StatusInProgress, StatusCompleted, StatusCancelled:
@@ -100,8 +137,112 @@ func IsValidScheduleStatus(status string) bool {
 	}
 }
 
+const (
+	ClockInAnchorStart = "start"
+	ClockInAnchorEnd   = "end"
+)
+
+const (
+	ClockInEnforcementBlock = "block"
+	ClockInEnforcementFlag  = "flag"
+)
+
+const (
+	DurationActionBlock  = "block"
+	DurationActionFlag   = "flag"
+	DurationActionReason = "reason"
+)
+
+// Early departure reasons a caregiver can give when a visit ends before its scheduled end
+const (
+	EarlyDepartureClientRequest      = "client_request"
+	EarlyDepartureClientUnavailable  = "client_unavailable"
+	EarlyDepartureClientHospitalized = "client_hospitalized"
+	EarlyDepartureUnsafeEnvironment  = "unsafe_environment"
+	EarlyDepartureCaregiverEmergency = "caregiver_emergency"
+	EarlyDepartureOther              = "other"
+)
+
+func IsValidEarlyDepartureReason(reason string) bool {
+	switch reason {
+	case EarlyDepartureClientRequest, EarlyDepartureClientUnavailable, EarlyDepartureClientHospitalized,
+		EarlyDepartureUnsafeEnvironment, EarlyDepartureCaregiverEmergency, EarlyDepartureOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// DurationMinutes rounds d to whole minutes, the unit visit durations are reported in
+func DurationMinutes(d time.Duration) int64 {
+	return int64(d.Round(time.Minute) / time.Minute)
+}
+
 type ComplianceResult struct {
 	Flags          string
 	Notes          string
 	WarningMessage string
 }
+
+// ClockWindowError reports a clock-in outside the allowed window, with the window itself
+// so the app can tell the caregiver when they can clock in
+type ClockWindowError struct {
+	Err         error
+	OpensAt     time.Time
+	ClosesAt    time.Time
+	AttemptedAt time.Time
+}
+
+func (e *ClockWindowError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ClockWindowError) Unwrap() error {
+	return e.Err
+}
+
+// OutsideBy is how long before the window opened or after it closed the attempt was
+func (e *ClockWindowError) OutsideBy() time.Duration {
+	if e.AttemptedAt.Before(e.OpensAt) {
+		return e.OpensAt.Sub(e.AttemptedAt)
+	}
+	return e.AttemptedAt.Sub(e.ClosesAt)
+}
+
+func (e *ClockWindowError) ErrorData() interface{} {
+	outside := e.OutsideBy()
+	return ClockWindowResponse{
+		OpensAt:          e.OpensAt,
+		ClosesAt:         e.ClosesAt,
+		AttemptedAt:      e.AttemptedAt,
+		OutsideBySeconds: int64(outside / time.Second),
+		OutsideByMinutes: int64((outside + time.Minute - 1) / time.Minute),
+	}
+}
+
+// VisitDurationError reports a clock-out refused by the visit duration policy, with the durations that were compared
+type VisitDurationError struct {
+	Err       error
+	Violation string
+	Actual    time.Duration
+	Scheduled time.Duration
+	Limit     time.Duration
+}
+
+func (e *VisitDurationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *VisitDurationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *VisitDurationError) ErrorData() interface{} {
+	return VisitDurationResponse{
+		Violation:        e.Violation,
+		ActualMinutes:    DurationMinutes(e.Actual),
+		ScheduledMinutes: DurationMinutes(e.Scheduled),
+		VarianceMinutes:  DurationMinutes(e.Actual) - DurationMinutes(e.Scheduled),
+		LimitMinutes:     DurationMinutes(e.Limit),
+	}
+}
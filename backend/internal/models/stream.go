@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// StreamEventReset tells a reconnecting client it missed more events than can be replayed,
+// so it should re-fetch the schedules instead of waiting for them
+const StreamEventReset = "reset"
+
+// StreamEvent is an outbox event as broadcast to connected coordinators
+type StreamEvent struct {
+	ID         int64
+	Type       string
+	ScheduleID int64
+	UserID     int64
+	ClientName string
+	CreatedAt  time.Time
+	Data       json.RawMessage
+}
+
+func (e *StreamEvent) ToWebhookEnvelope() WebhookEnvelope {
+	return WebhookEnvelope{
+		ID:        e.ID,
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Data:      e.Data,
+	}
+}
+
+// StreamFilter narrows a stream to some visits; zero values match everything
+type StreamFilter struct {
+	UserID     int64
+	ScheduleID int64
+	ClientName string
+	Types      map[string]bool
+}
+
+func (f *StreamFilter) Matches(e StreamEvent) bool {
+	if f.UserID != 0 && e.UserID != f.UserID {
+		return false
+	}
+	if f.ScheduleID != 0 && e.ScheduleID != f.ScheduleID {
+		return false
+	}
+	if f.ClientName != "" && !strings.Contains(strings.ToLower(e.ClientName), strings.ToLower(f.ClientName)) {
+		return false
+	}
+	if len(f.Types) > 0 && !f.Types[e.Type] {
+		return false
+	}
+	return true
+}
@@ -2,19 +2,27 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
+
+	"github.com/jmoiron/sqlx/types"
 )
 
 type Task struct {
-	ID          int64          `json:"id" db:"id"`
-	ScheduleID  int64          `json:"schedule_id" db:"schedule_id"`
-	Name        string         `json:"name" db:"name"`
-	Description sql.NullString `json:"description,omitempty" db:"description"`
-	Status      string         `json:"status" db:"status"`
-	Reason      sql.NullString `json:"reason,omitempty" db:"reason"`
-	CompletedAt sql.NullTime   `json:"completed_at,omitempty" db:"completed_at"`
-	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+	ID          int64              `json:"id" db:"id"`
+	ScheduleID  int64              `json:"schedule_id" db:"schedule_id"`
+	Name        string             `json:"name" db:"name"`
+	Description sql.NullString     `json:"description,omitempty" db:"description"`
+	Status      string             `json:"status" db:"status"`
+	Reason      sql.NullString     `json:"reason,omitempty" db:"reason"`
+	CompletedAt sql.NullTime       `json:"completed_at,omitempty" db:"completed_at"`
+	IsRequired  bool               `json:"is_required" db:"is_required"`
+	SortOrder   int                `json:"sort_order" db:"sort_order"`
+	TaskType    string             `json:"task_type" db:"task_type"`
+	Options     types.NullJSONText `json:"options,omitempty" db:"options"`
+	Value       types.NullJSONText `json:"value,omitempty" db:"value"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
 }
 
 func (t *Task) ToTaskResponse() TaskResponse {
@@ -26,7 +34,19 @@ func (t *Task) ToTaskResponse() TaskResponse {
 		Status:      t.Status,
 		Reason:      t.Reason.String,
 		CompletedAt: t.CompletedAt.Time,
+		IsRequired:  t.IsRequired,
+		SortOrder:   t.SortOrder,
+		TaskType:    t.TaskType,
+		Options:     nullJSON(t.Options),
+		Value:       nullJSON(t.Value),
+	}
+}
+
+func nullJSON(j types.NullJSONText) json.RawMessage {
+	if !j.Valid || len(j.JSONText) == 0 {
+		return nil
 	}
+	return json.RawMessage(j.JSONText)
 }
 
 const (
@@ -55,6 +75,21 @@ func (t *Task) RequiresReason() bool {
 	return t.Status == TaskStatusNotCompleted
 }
 
+// ValidateTaskStatus checks an outcome submitted by a caregiver: completed, or not completed with a reason
+func ValidateTaskStatus(status, reason string) error {
+	switch status {
+	case TaskStatusCompleted:
+		return nil
+	case TaskStatusNotCompleted:
+		if reason == "" {
+			return ErrReasonRequired
+		}
+		return nil
+	default:
+		return ErrInvalidTaskStatus
+	}
+}
+
 func IsValidTaskStatus(status string) bool {
 	switch status {
 	case TaskStatusPending, TaskStatusCompleted, TaskStatusNotCompleted:
@@ -63,3 +98,156 @@ func IsValidTaskStatus(status string) bool {
 		return false
 	}
 }
+
+// TaskStatusChange records who changed a task's outcome, when and why
+type TaskStatusChange struct {
+	ID           int64              `json:"id" db:"id"`
+	TaskID       int64              `json:"task_id" db:"task_id"`
+	ScheduleID   int64              `json:"schedule_id" db:"schedule_id"`
+	ChangedBy    int64              `json:"changed_by" db:"changed_by"`
+	ApprovedBy   sql.NullInt64      `json:"approved_by,omitempty" db:"approved_by"`
+	FromStatus   string             `json:"from_status" db:"from_status"`
+	ToStatus     string             `json:"to_status" db:"to_status"`
+	FromReason   sql.NullString     `json:"from_reason,omitempty" db:"from_reason"`
+	ToReason     sql.NullString     `json:"to_reason,omitempty" db:"to_reason"`
+	FromValue    types.NullJSONText `json:"from_value,omitempty" db:"from_value"`
+	ToValue      types.NullJSONText `json:"to_value,omitempty" db:"to_value"`
+	ChangeReason sql.NullString     `json:"change_reason,omitempty" db:"change_reason"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+}
+
+func (c *TaskStatusChange) ToTaskStatusChangeResponse() TaskStatusChangeResponse {
+	return TaskStatusChangeResponse{
+		ID:           c.ID,
+		TaskID:       c.TaskID,
+		ChangedBy:    c.ChangedBy,
+		ApprovedBy:   c.ApprovedBy.Int64,
+		FromStatus:   c.FromStatus,
+		ToStatus:     c.ToStatus,
+		FromReason:   c.FromReason.String,
+		ToReason:     c.ToReason.String,
+		FromValue:    nullJSON(c.FromValue),
+		ToValue:      nullJSON(c.ToValue),
+		ChangeReason: c.ChangeReason.String,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+// TaskCorrection is a change to a task's outcome requested after clock-out,
+// applied only once a supervisor approves it
+type TaskCorrection struct {
+	ID               int64              `json:"id" db:"id"`
+	TaskID           int64              `json:"task_id" db:"task_id"`
+	ScheduleID       int64              `json:"schedule_id" db:"schedule_id"`
+	RequestedBy      int64              `json:"requested_by" db:"requested_by"`
+	FromStatus       string             `json:"from_status" db:"from_status"`
+	ToStatus         string             `json:"to_status" db:"to_status"`
+	ToReason         sql.NullString     `json:"to_reason,omitempty" db:"to_reason"`
+	ToValue          types.NullJSONText `json:"to_value,omitempty" db:"to_value"`
+	CorrectionReason string             `json:"correction_reason" db:"correction_reason"`
+	Status           string             `json:"status" db:"status"`
+	ReviewedBy       sql.NullInt64      `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt       sql.NullTime       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote       sql.NullString     `json:"review_note,omitempty" db:"review_note"`
+	CreatedAt        time.Time          `json:"created_at" db:"created_at"`
+}
+
+const (
+	CorrectionStatusPending  = "pending"
+	CorrectionStatusApproved = "approved"
+	CorrectionStatusRejected = "rejected"
+)
+
+func IsValidCorrectionStatus(status string) bool {
+	switch status {
+	case CorrectionStatusPending, CorrectionStatusApproved, CorrectionStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Request rebuilds the task update the caregiver asked for, to be applied on approval
+func (c *TaskCorrection) Request() *UpdateTaskRequest {
+	return &UpdateTaskRequest{
+		Status:           c.ToStatus,
+		Reason:           c.ToReason.String,
+		Value:            nullJSON(c.ToValue),
+		CorrectionReason: c.CorrectionReason,
+	}
+}
+
+func (c *TaskCorrection) ToTaskCorrectionResponse() TaskCorrectionResponse {
+	resp := TaskCorrectionResponse{
+		ID:               c.ID,
+		TaskID:           c.TaskID,
+		ScheduleID:       c.ScheduleID,
+		RequestedBy:      c.RequestedBy,
+		FromStatus:       c.FromStatus,
+		ToStatus:         c.ToStatus,
+		ToReason:         c.ToReason.String,
+		ToValue:          nullJSON(c.ToValue),
+		CorrectionReason: c.CorrectionReason,
+		Status:           c.Status,
+		ReviewedBy:       c.ReviewedBy.Int64,
+		ReviewNote:       c.ReviewNote.String,
+		CreatedAt:        c.CreatedAt,
+	}
+	if c.ReviewedAt.Valid {
+		resp.ReviewedAt = &c.ReviewedAt.Time
+	}
+	return resp
+}
+
+// Required task policies decide what happens when a visit is clocked out with required tasks still pending
+const (
+	RequiredTaskPolicyBlock = "block"
+	RequiredTaskPolicyFlag  = "flag"
+)
+
+// PendingRequired returns the required tasks that have not been completed or explained yet
+func PendingRequired(tasks []Task) []Task {
+	var pending []Task
+	for _, t := range tasks {
+		if t.IsRequired && t.IsPending() {
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
+
+// RequiredTasksPendingError carries the required tasks blocking a clock-out
+type RequiredTasksPendingError struct {
+	Tasks []TaskResponse
+}
+
+func (e *RequiredTasksPendingError) Error() string {
+	return ErrRequiredTasksPending.Error()
+}
+
+func (e *RequiredTasksPendingError) Unwrap() error {
+	return ErrRequiredTasksPending
+}
+
+// TaskUpdate is one task outcome to save together with its audit record
+type TaskUpdate struct {
+	Task   *Task
+	Change *TaskStatusChange
+	Events []OutboxEvent
+
+	// the visit status the change was allowed for; the update is refused if the visit moved on since
+	ScheduleStatus string
+}
+
+// TaskUpdateItemsError carries the invalid items of a bulk task update
+type TaskUpdateItemsError struct {
+	Items []TaskUpdateItemError
+}
+
+func (e *TaskUpdateItemsError) Error() string {
+	return ErrInvalidTaskUpdates.Error()
+}
+
+func (e *TaskUpdateItemsError) Unwrap() error {
+	return ErrInvalidTaskUpdates
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	TaskTypeGeneral       = "general"
+	TaskTypeBloodPressure = "blood_pressure"
+	TaskTypeTemperature   = "temperature"
+	TaskTypeMedication    = "medication"
+	TaskTypeChecklist     = "checklist"
+)
+
+const (
+	TemperatureUnitCelsius    = "C"
+	TemperatureUnitFahrenheit = "F"
+)
+
+func IsValidTaskType(taskType string) bool {
+	switch taskType {
+	case TaskTypeGeneral, TaskTypeBloodPressure, TaskTypeTemperature, TaskTypeMedication, TaskTypeChecklist:
+		return true
+	default:
+		return false
+	}
+}
+
+// TaskOptions configures a typed task when it is planned, e.g. the checklist items or the prescribed medication
+type TaskOptions struct {
+	Items      []string `json:"items,omitempty"`
+	Medication string   `json:"medication,omitempty"`
+	Dose       float64  `json:"dose,omitempty"`
+	DoseUnit   string   `json:"dose_unit,omitempty"`
+}
+
+type BloodPressureValue struct {
+	Systolic  int `json:"systolic"`
+	Diastolic int `json:"diastolic"`
+	Pulse     int `json:"pulse,omitempty"`
+}
+
+type TemperatureValue struct {
+	Reading float64 `json:"reading"`
+	Unit    string  `json:"unit"`
+}
+
+type MedicationValue struct {
+	Medication string  `json:"medication"`
+	Dose       float64 `json:"dose"`
+	DoseUnit   string  `json:"dose_unit"`
+	Route      string  `json:"route,omitempty"`
+}
+
+type ChecklistItemValue struct {
+	Label   string `json:"label"`
+	Checked bool   `json:"checked"`
+}
+
+type ChecklistValue struct {
+	Items []ChecklistItemValue `json:"items"`
+}
+
+// ValidateTaskOptions checks the options given to a typed task when it is created
+func ValidateTaskOptions(taskType string, options *TaskOptions) error {
+	if !IsValidTaskType(taskType) {
+		return fmt.Errorf("%w: unknown task type %q", ErrInvalidTaskOptions, taskType)
+	}
+
+	switch taskType {
+	case TaskTypeChecklist:
+		if options == nil || len(options.Items) == 0 {
+			return fmt.Errorf("%w: checklist tasks need at least one item", ErrInvalidTaskOptions)
+		}
+		seen := make(map[string]bool, len(options.Items))
+		for _, item := range options.Items {
+			label := strings.TrimSpace(item)
+			if label == "" || seen[label] {
+				return fmt.Errorf("%w: checklist items must be unique and non-empty", ErrInvalidTaskOptions)
+			}
+			seen[label] = true
+		}
+	case TaskTypeMedication:
+		if options == nil || strings.TrimSpace(options.Medication) == "" {
+			return fmt.Errorf("%w: medication tasks need the prescribed medication", ErrInvalidTaskOptions)
+		}
+		if options.Dose < 0 {
+			return fmt.Errorf("%w: prescribed dose must not be negative", ErrInvalidTaskOptions)
+		}
+	}
+
+	return nil
+}
+
+// ValidateValue checks a captured value against the task's type and options.
+// Typed tasks need a value to be completed; general tasks take none.
+func (t *Task) ValidateValue(status string, raw json.RawMessage) error {
+	hasValue := len(bytes.TrimSpace(raw)) > 0 && !bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+
+	if t.TaskType == TaskTypeGeneral || t.TaskType == "" {
+		if hasValue {
+			return fmt.Errorf("%w: general tasks do not take a value", ErrInvalidTaskValue)
+		}
+		return nil
+	}
+	if !hasValue {
+		if status == TaskStatusCompleted {
+			return fmt.Errorf("%w: a %s value is required to complete this task", ErrInvalidTaskValue, t.TaskType)
+		}
+		return nil
+	}
+
+	var options TaskOptions
+	if t.Options.Valid {
+		if err := json.Unmarshal(t.Options.JSONText, &options); err != nil {
+			return fmt.Errorf("failed to read task options: %w", err)
+		}
+	}
+
+	switch t.TaskType {
+	case TaskTypeBloodPressure:
+		var v BloodPressureValue
+		if err := decodeStrict(raw, &v); err != nil {
+			return err
+		}
+		return v.validate()
+	case TaskTypeTemperature:
+		var v TemperatureValue
+		if err := decodeStrict(raw, &v); err != nil {
+			return err
+		}
+		return v.validate()
+	case TaskTypeMedication:
+		var v MedicationValue
+		if err := decodeStrict(raw, &v); err != nil {
+			return err
+		}
+		return v.validate(options)
+	case TaskTypeChecklist:
+		var v ChecklistValue
+		if err := decodeStrict(raw, &v); err != nil {
+			return err
+		}
+		return v.validate(options, status)
+	default:
+		return fmt.Errorf("%w: unknown task type %q", ErrInvalidTaskValue, t.TaskType)
+	}
+}
+
+func (v BloodPressureValue) validate() error {
+	if v.Systolic < 50 || v.Systolic > 260 {
+		return fmt.Errorf("%w: systolic must be between 50 and 260 mmHg", ErrInvalidTaskValue)
+	}
+	if v.Diastolic < 30 || v.Diastolic > 160 {
+		return fmt.Errorf("%w: diastolic must be between 30 and 160 mmHg", ErrInvalidTaskValue)
+	}
+	if v.Systolic <= v.Diastolic {
+		return fmt.Errorf("%w: systolic must be higher than diastolic", ErrInvalidTaskValue)
+	}
+	if v.Pulse != 0 && (v.Pulse < 20 || v.Pulse > 250) {
+		return fmt.Errorf("%w: pulse must be between 20 and 250 bpm", ErrInvalidTaskValue)
+	}
+	return nil
+}
+
+func (v TemperatureValue) validate() error {
+	switch v.Unit {
+	case TemperatureUnitCelsius:
+		if v.Reading < 30 || v.Reading > 45 {
+			return fmt.Errorf("%w: temperature must be between 30 and 45 °C", ErrInvalidTaskValue)
+		}
+	case TemperatureUnitFahrenheit:
+		if v.Reading < 86 || v.Reading > 113 {
+			return fmt.Errorf("%w: temperature must be between 86 and 113 °F", ErrInvalidTaskValue)
+		}
+	default:
+		return fmt.Errorf("%w: temperature unit must be C or F", ErrInvalidTaskValue)
+	}
+	return nil
+}
+
+// validate records the dose actually given, so when a prescription is set it must be the prescribed
+// medication and unit, and a partial dose is accepted but more than the prescribed dose is not
+func (v MedicationValue) validate(options TaskOptions) error {
+	if strings.TrimSpace(v.Medication) == "" || strings.TrimSpace(v.DoseUnit) == "" {
+		return fmt.Errorf("%w: medication and dose unit are required", ErrInvalidTaskValue)
+	}
+	if v.Dose <= 0 {
+		return fmt.Errorf("%w: dose must be greater than zero", ErrInvalidTaskValue)
+	}
+	if options.Medication != "" && !strings.EqualFold(strings.TrimSpace(v.Medication), strings.TrimSpace(options.Medication)) {
+		return fmt.Errorf("%w: medication does not match the prescribed %s", ErrInvalidTaskValue, options.Medication)
+	}
+	if options.DoseUnit != "" && !strings.EqualFold(strings.TrimSpace(v.DoseUnit), strings.TrimSpace(options.DoseUnit)) {
+		return fmt.Errorf("%w: dose unit must be %s", ErrInvalidTaskValue, options.DoseUnit)
+	}
+	if options.Dose > 0 && v.Dose > options.Dose {
+		return fmt.Errorf("%w: dose exceeds the prescribed %g %s", ErrInvalidTaskValue, options.Dose, options.DoseUnit)
+	}
+	return nil
+}
+
+// validate requires every configured item exactly once, all checked for a completed task
+func (v ChecklistValue) validate(options TaskOptions, status string) error {
+	if len(v.Items) != len(options.Items) {
+		return fmt.Errorf("%w: checklist must report all %d items", ErrInvalidTaskValue, len(options.Items))
+	}
+
+	expected := make(map[string]bool, len(options.Items))
+	for _, item := range options.Items {
+		expected[item] = true
+	}
+	for _, item := range v.Items {
+		if !expected[item.Label] {
+			return fmt.Errorf("%w: unknown or repeated checklist item %q", ErrInvalidTaskValue, item.Label)
+		}
+		delete(expected, item.Label)
+
+		if status == TaskStatusCompleted && !item.Checked {
+			return fmt.Errorf("%w: checklist item %q is not checked", ErrInvalidTaskValue, item.Label)
+		}
+	}
+	return nil
+}
+
+func decodeStrict(raw json.RawMessage, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTaskValue, err)
+	}
+	return nil
+}
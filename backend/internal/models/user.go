@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type User struct {
+	ID        int64          `json:"id" db:"id"`
+	Name      string         `json:"name" db:"name"`
+	Phone     sql.NullString `json:"phone,omitempty" db:"phone"`
+	Email     string         `json:"email" db:"email"`
+	Locale    sql.NullString `json:"locale,omitempty" db:"locale"`
+	Role      string         `json:"role" db:"role"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+const (
+	RoleCaregiver  = "caregiver"
+	RoleSupervisor = "supervisor"
+	RoleAdmin      = "admin"
+)
+
+// CanReview reports whether the user may approve or reject what caregivers submit,
+// such as task corrections and compliance exceptions
+func (u *User) CanReview() bool {
+	return u.Role == RoleSupervisor || u.Role == RoleAdmin
+}
+
+type UserSkill struct {
+	ID     int64  `json:"id" db:"id"`
+	UserID int64  `json:"user_id" db:"user_id"`
+	Skill  string `json:"skill" db:"skill"`
+}
+
+// SkillsByUser groups skills into a per-user set
+func SkillsByUser(skills []UserSkill) map[int64]map[string]bool {
+	result := make(map[int64]map[string]bool)
+	for _, s := range skills {
+		if result[s.UserID] == nil {
+			result[s.UserID] = make(map[string]bool)
+		}
+		result[s.UserID][s.Skill] = true
+	}
+	return result
+}
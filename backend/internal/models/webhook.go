@@ -0,0 +1,172 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+)
+
+// Event types published to webhook subscribers
+const (
+	EventVisitStarted     = "visit.started"
+	EventVisitEnded       = "visit.ended"
+	EventVisitFlagged     = "visit.flagged"
+	EventTaskUpdated      = "task.updated"
+	EventTaskNotCompleted = "task.not_completed"
+	EventWebhookPing      = "webhook.ping"
+)
+
+func IsValidEventType(eventType string) bool {
+	switch eventType {
+	case EventVisitStarted, EventVisitEnded, EventVisitFlagged, EventTaskUpdated, EventTaskNotCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// OutboxEvent is a domain event stored with the change that raised it, then published to subscribers
+type OutboxEvent struct {
+	ID         int64          `db:"id"`
+	EventType  string         `db:"event_type"`
+	ScheduleID sql.NullInt64  `db:"schedule_id"`
+	Payload    types.JSONText `db:"payload"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func NewOutboxEvent(eventType string, scheduleID int64, data interface{}) (OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return OutboxEvent{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	return OutboxEvent{
+		EventType:  eventType,
+		ScheduleID: sql.NullInt64{Int64: scheduleID, Valid: scheduleID != 0},
+		Payload:    payload,
+	}, nil
+}
+
+// VisitEventData is the data of visit.started, visit.ended and visit.flagged events
+type VisitEventData struct {
+	ScheduleID      int64      `json:"schedule_id"`
+	UserID          int64      `json:"user_id"`
+	ClientName      string     `json:"client_name"`
+	ServiceName     string     `json:"service_name"`
+	Status          string     `json:"status"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         time.Time  `json:"end_time"`
+	ClockInTime     *time.Time `json:"clock_in_time,omitempty"`
+	ClockOutTime    *time.Time `json:"clock_out_time,omitempty"`
+	ComplianceFlags []string   `json:"compliance_flags"`
+	NewFlags        []string   `json:"new_flags,omitempty"`
+	ActualMinutes   *int64     `json:"actual_minutes,omitempty"`
+}
+
+// TaskEventData is the data of task.updated and task.not_completed events
+type TaskEventData struct {
+	TaskID         int64  `json:"task_id"`
+	ScheduleID     int64  `json:"schedule_id"`
+	UserID         int64  `json:"user_id"`
+	ClientName     string `json:"client_name"`
+	Name           string `json:"name"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+	ChangedBy      int64  `json:"changed_by"`
+}
+
+// WebhookEnvelope is the JSON body posted to subscribers
+type WebhookEnvelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// ToStreamEvent reads the caregiver and client the event is about from its payload, for stream filters
+func (e *OutboxEvent) ToStreamEvent() StreamEvent {
+	var about struct {
+		UserID     int64  `json:"user_id"`
+		ClientName string `json:"client_name"`
+	}
+	_ = json.Unmarshal(e.Payload, &about)
+
+	return StreamEvent{
+		ID:         e.ID,
+		Type:       e.EventType,
+		ScheduleID: e.ScheduleID.Int64,
+		UserID:     about.UserID,
+		ClientName: about.ClientName,
+		CreatedAt:  e.CreatedAt,
+		Data:       json.RawMessage(e.Payload),
+	}
+}
+
+type WebhookSubscription struct {
+	ID         int64          `db:"id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// ToWebhookSubscriptionResponse leaves the secret out; it is only shown when it is created or rotated
+func (w *WebhookSubscription) ToWebhookSubscriptionResponse() WebhookSubscriptionResponse {
+	return WebhookSubscriptionResponse{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+type WebhookDelivery struct {
+	ID             int64          `db:"id"`
+	EventID        int64          `db:"event_id"`
+	SubscriptionID int64          `db:"subscription_id"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastStatusCode sql.NullInt64  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+
+	// joined from outbox_events and webhook_subscriptions
+	EventType      string         `db:"event_type"`
+	Payload        types.JSONText `db:"payload"`
+	EventCreatedAt time.Time      `db:"event_created_at"`
+	URL            string         `db:"url"`
+	Secret         string         `db:"secret"`
+}
+
+func (d *WebhookDelivery) ToWebhookDeliveryResponse() WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		SubscriptionID: d.SubscriptionID,
+		URL:            d.URL,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode.Int64,
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt,
+		Payload:        json.RawMessage(d.Payload),
+	}
+}
@@ -0,0 +1,105 @@
+package monitoring
+
+import (
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/pkg/metrics"
+	"github.com/jmoiron/sqlx"
+)
+
+// Compliance flags are counted by the visit event that raised them
+const (
+	EventClockIn  = "clock_in"
+	EventClockOut = "clock_out"
+)
+
+// Metrics are the HTTP, database and visit metrics exposed on /metrics. Each call to New
+// builds its own registry, so a test can create one and assert on the values it records.
+type Metrics struct {
+	Registry *metrics.Registry
+
+	HTTPRequestDuration *metrics.Histogram
+	ClockIns            *metrics.Counter
+	ClockOuts           *metrics.Counter
+	ComplianceFlags     *metrics.Counter
+	RejectedClockIns    *metrics.Counter
+	TaskOutcomes        *metrics.Counter
+}
+
+// New registers the metrics, with the connection pool stats of db when it is not nil
+func New(db *sqlx.DB) *Metrics {
+	registry := metrics.NewRegistry()
+
+	m := &Metrics{
+		Registry: registry,
+		HTTPRequestDuration: registry.NewHistogram("http_request_duration_seconds",
+			"Time taken to serve HTTP requests, by route template and status.", nil, "method", "route", "status"),
+		ClockIns: registry.NewCounter("visit_clock_ins_total",
+			"Visits clocked into."),
+		ClockOuts: registry.NewCounter("visit_clock_outs_total",
+			"Visits clocked out of."),
+		ComplianceFlags: registry.NewCounter("visit_compliance_flags_total",
+			"Compliance flags raised on visits, by flag code and the event that raised them.", "code", "event"),
+		RejectedClockIns: registry.NewCounter("visit_clock_ins_rejected_total",
+			"Clock-ins refused, by error code.", "error"),
+		TaskOutcomes: registry.NewCounter("task_outcomes_total",
+			"Task outcomes recorded by caregivers, by status.", "status"),
+	}
+
+	if db != nil {
+		registerPoolStats(registry, db)
+	}
+
+	return m
+}
+
+func registerPoolStats(registry *metrics.Registry, db *sqlx.DB) {
+	registry.NewGaugeFunc("db_max_open_connections", "Maximum number of open connections to the database.", func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	registry.NewGaugeFunc("db_open_connections", "Established connections, both in use and idle.", func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	registry.NewGaugeFunc("db_in_use_connections", "Connections currently in use.", func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	registry.NewGaugeFunc("db_idle_connections", "Idle connections.", func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	registry.NewCounterFunc("db_wait_count_total", "Connections waited for because the pool was exhausted.", func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	registry.NewCounterFunc("db_wait_duration_seconds_total", "Time spent waiting for a connection.", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+}
+
+// ClockedIn counts a clock-in and the compliance flags it raised
+func (m *Metrics) ClockedIn(flags []string) {
+	m.ClockIns.Inc()
+	m.countFlags(flags, EventClockIn)
+}
+
+// ClockedOut counts a clock-out and the compliance flags it raised
+func (m *Metrics) ClockedOut(flags []string) {
+	m.ClockOuts.Inc()
+	m.countFlags(flags, EventClockOut)
+}
+
+// ClockInRejected counts a refused clock-in by the code the client received for err
+func (m *Metrics) ClockInRejected(err error) {
+	code := models.ErrorCodeInternal
+	if def, ok := models.LookupError(err); ok {
+		code = def.Code
+	}
+	m.RejectedClockIns.Inc(code)
+}
+
+func (m *Metrics) TaskOutcome(status string) {
+	m.TaskOutcomes.Inc(status)
+}
+
+func (m *Metrics) countFlags(flags []string, event string) {
+	for _, flag := range flags {
+		m.ComplianceFlags.Inc(flag, event)
+	}
+}
@@ -0,0 +1,92 @@
+package monitoring
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+)
+
+func TestClockedIn(t *testing.T) {
+	m := New(nil)
+	m.ClockedIn(nil)
+	m.ClockedIn([]string{"TOO_FAR", "EARLY_CLOCK_IN"})
+
+	if got := m.ClockIns.Value(); got != 2 {
+		t.Errorf("ClockIns = %v, want 2", got)
+	}
+	if got := m.ClockOuts.Value(); got != 0 {
+		t.Errorf("ClockOuts = %v, want 0", got)
+	}
+	for _, flag := range []string{"TOO_FAR", "EARLY_CLOCK_IN"} {
+		if got := m.ComplianceFlags.Value(flag, EventClockIn); got != 1 {
+			t.Errorf("ComplianceFlags(%s, clock_in) = %v, want 1", flag, got)
+		}
+		if got := m.ComplianceFlags.Value(flag, EventClockOut); got != 0 {
+			t.Errorf("ComplianceFlags(%s, clock_out) = %v, want 0", flag, got)
+		}
+	}
+}
+
+func TestClockedOut(t *testing.T) {
+	m := New(nil)
+	m.ClockedOut([]string{"LATE_CLOCK_OUT"})
+
+	if got := m.ClockOuts.Value(); got != 1 {
+		t.Errorf("ClockOuts = %v, want 1", got)
+	}
+	if got := m.ClockIns.Value(); got != 0 {
+		t.Errorf("ClockIns = %v, want 0", got)
+	}
+	if got := m.ComplianceFlags.Value("LATE_CLOCK_OUT", EventClockOut); got != 1 {
+		t.Errorf("ComplianceFlags(LATE_CLOCK_OUT, clock_out) = %v, want 1", got)
+	}
+}
+
+func TestClockInRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{name: "catalogue error", err: models.ErrScheduleNotFound, code: "SCHEDULE_NOT_FOUND"},
+		{name: "wrapped catalogue error", err: fmt.Errorf("clock in: %w", models.ErrScheduleNotFound), code: "SCHEDULE_NOT_FOUND"},
+		{name: "infrastructure error", err: errors.New("connection refused"), code: models.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(nil)
+			m.ClockInRejected(tt.err)
+
+			if got := m.RejectedClockIns.Value(tt.code); got != 1 {
+				t.Errorf("RejectedClockIns(%s) = %v, want 1", tt.code, got)
+			}
+			if got := m.ClockIns.Value(); got != 0 {
+				t.Errorf("ClockIns = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestTaskOutcome(t *testing.T) {
+	m := New(nil)
+	m.TaskOutcome(models.TaskStatusCompleted)
+	m.TaskOutcome(models.TaskStatusCompleted)
+	m.TaskOutcome(models.TaskStatusNotCompleted)
+
+	tests := []struct {
+		status string
+		want   float64
+	}{
+		{status: models.TaskStatusCompleted, want: 2},
+		{status: models.TaskStatusNotCompleted, want: 1},
+		{status: models.TaskStatusPending, want: 0},
+	}
+	for _, tt := range tests {
+		if got := m.TaskOutcomes.Value(tt.status); got != tt.want {
+			t.Errorf("TaskOutcomes(%s) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitAttachment, error)
+	GetByID(ctx context.Context, attachmentID int64) (*models.VisitAttachment, error)
+	GetByKey(ctx context.Context, storageKey string) (*models.VisitAttachment, error)
+	Create(ctx context.Context, data *models.VisitAttachment) (int64, error)
+	Delete(ctx context.Context, attachmentID int64) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const selectAttachments = `
+		SELECT id, schedule_id, task_id, user_id, storage_key, file_name, content_type, size_bytes, created_at
+		FROM visit_attachments`
+
+func (r *repository) GetBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitAttachment, error) {
+	query := selectAttachments + `
+		WHERE schedule_id = ?
+		ORDER BY created_at, id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get attachments statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var attachments []models.VisitAttachment
+	err = stmt.SelectContext(ctx, &attachments, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, attachmentID int64) (*models.VisitAttachment, error) {
+	query := selectAttachments + `
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get attachment by id statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var attachment models.VisitAttachment
+	err = stmt.GetContext(ctx, &attachment, attachmentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment by id: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *repository) GetByKey(ctx context.Context, storageKey string) (*models.VisitAttachment, error) {
+	query := selectAttachments + `
+		WHERE storage_key = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get attachment by key statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var attachment models.VisitAttachment
+	err = stmt.GetContext(ctx, &attachment, storageKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment by key: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *repository) Create(ctx context.Context, data *models.VisitAttachment) (int64, error) {
+	query := `
+		INSERT INTO visit_attachments (schedule_id, task_id, user_id, storage_key, file_name, content_type, size_bytes)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare create attachment statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var id int64
+	err = stmt.QueryRowxContext(ctx,
+		data.ScheduleID, data.TaskID, data.UserID, data.StorageKey, data.FileName, data.ContentType, data.SizeBytes,
+	).Scan(&id, &data.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) Delete(ctx context.Context, attachmentID int64) error {
+	query := `DELETE FROM visit_attachments WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare delete attachment statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	_, err = stmt.ExecContext(ctx, attachmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
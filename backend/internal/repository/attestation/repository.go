@@ -0,0 +1,84 @@
+package attestation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetBySchedule(ctx context.Context, scheduleID int64) (*models.Attestation, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetBySchedule returns the visit's attestation, or nil when none was captured
+func (r *repository) GetBySchedule(ctx context.Context, scheduleID int64) (*models.Attestation, error) {
+	query := `
+		SELECT id, schedule_id, signer_name, signer_relationship, signature_format, signature_data,
+			unable_to_sign_reason, caregiver_signature_format, caregiver_signature_data, captured_at,
+			created_at, updated_at
+		FROM visit_attestations
+		WHERE schedule_id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get attestation statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var attestation models.Attestation
+	err = stmt.GetContext(ctx, &attestation, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestation: %w", err)
+	}
+
+	return &attestation, nil
+}
+
+// Upsert stores the visit's attestation in the caller's clock-out transaction,
+// replacing one left behind by an earlier attestation of the same visit
+func Upsert(ctx context.Context, tx *sqlx.Tx, data *models.Attestation) error {
+	query := `
+		INSERT INTO visit_attestations (
+			schedule_id, signer_name, signer_relationship, signature_format, signature_data,
+			unable_to_sign_reason, caregiver_signature_format, caregiver_signature_data, captured_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (schedule_id) DO UPDATE SET
+			signer_name = EXCLUDED.signer_name,
+			signer_relationship = EXCLUDED.signer_relationship,
+			signature_format = EXCLUDED.signature_format,
+			signature_data = EXCLUDED.signature_data,
+			unable_to_sign_reason = EXCLUDED.unable_to_sign_reason,
+			caregiver_signature_format = EXCLUDED.caregiver_signature_format,
+			caregiver_signature_data = EXCLUDED.caregiver_signature_data,
+			captured_at = EXCLUDED.captured_at,
+			updated_at = ?`
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query),
+		data.ScheduleID, data.SignerName, data.SignerRelationship, data.SignatureFormat, data.SignatureData,
+		data.UnableToSignReason, data.CaregiverSignatureFormat, data.CaregiverSignatureData, data.CapturedAt,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert attestation: %w", err)
+	}
+
+	return nil
+}
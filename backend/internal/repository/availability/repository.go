@@ -0,0 +1,240 @@
+package availability
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetAvailability(ctx context.Context, userID int64) ([]models.Availability, error)
+	ReplaceAvailability(ctx context.Context, userID int64, windows []models.Availability) error
+	GetTimeOffs(ctx context.Context, userID int64) ([]models.TimeOff, error)
+	GetTimeOffByID(ctx context.Context, timeOffID int64) (*models.TimeOff, error)
+	GetTimeOffsBetween(ctx context.Context, userID int64, from, to time.Time, status string) ([]models.TimeOff, error)
+	CreateTimeOff(ctx context.Context, data *models.TimeOff) (int64, error)
+	UpdateTimeOffStatus(ctx context.Context, data *models.TimeOff) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetAvailability(ctx context.Context, userID int64) ([]models.Availability, error) {
+	query := `
+		SELECT id, user_id, day_of_week, to_char(start_time, 'HH24:MI') AS start_time,
+			to_char(end_time, 'HH24:MI') AS end_time, timezone
+		FROM user_availability
+		WHERE user_id = ?
+		ORDER BY day_of_week, start_time`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get availability statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var windows []models.Availability
+	err = stmt.SelectContext(ctx, &windows, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+
+	return windows, nil
+}
+
+// ReplaceAvailability swaps the user's whole weekly availability in a single transaction
+func (r *repository) ReplaceAvailability(ctx context.Context, userID int64, windows []models.Availability) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin replace availability transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM user_availability WHERE user_id = ?`), userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete availability: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_availability (user_id, day_of_week, start_time, end_time, timezone)
+		VALUES (?, ?, ?, ?, ?)`
+
+	stmt, err := tx.PreparexContext(ctx, tx.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert availability statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, w := range windows {
+		_, err = stmt.ExecContext(ctx, userID, w.DayOfWeek, w.StartTime, w.EndTime, w.Timezone)
+		if err != nil {
+			return fmt.Errorf("failed to insert availability: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit replace availability: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetTimeOffs(ctx context.Context, userID int64) ([]models.TimeOff, error) {
+	query := `
+		SELECT id, user_id, start_time, end_time, reason, status, reviewed_by, reviewed_at, review_note,
+			created_at, updated_at
+		FROM time_off_requests
+		WHERE user_id = ?
+		ORDER BY start_time DESC`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get time offs statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var timeOffs []models.TimeOff
+	err = stmt.SelectContext(ctx, &timeOffs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time offs: %w", err)
+	}
+
+	return timeOffs, nil
+}
+
+func (r *repository) GetTimeOffByID(ctx context.Context, timeOffID int64) (*models.TimeOff, error) {
+	query := `
+		SELECT id, user_id, start_time, end_time, reason, status, reviewed_by, reviewed_at, review_note,
+			created_at, updated_at
+		FROM time_off_requests
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get time off by id statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var timeOff models.TimeOff
+	err = stmt.GetContext(ctx, &timeOff, timeOffID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrTimeOffNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time off by id: %w", err)
+	}
+
+	return &timeOff, nil
+}
+
+// GetTimeOffsBetween returns the user's time off overlapping [from, to); an empty status matches any status
+func (r *repository) GetTimeOffsBetween(ctx context.Context, userID int64, from, to time.Time, status string) ([]models.TimeOff, error) {
+	query := `
+		SELECT id, user_id, start_time, end_time, reason, status, reviewed_by, reviewed_at, review_note,
+			created_at, updated_at
+		FROM time_off_requests
+		WHERE user_id = ? AND start_time < ? AND end_time > ?`
+	args := []any{userID, to, from}
+
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY start_time ASC"
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get time offs between statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var timeOffs []models.TimeOff
+	err = stmt.SelectContext(ctx, &timeOffs, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time offs between: %w", err)
+	}
+
+	return timeOffs, nil
+}
+
+func (r *repository) CreateTimeOff(ctx context.Context, data *models.TimeOff) (int64, error) {
+	query := `
+		INSERT INTO time_off_requests (user_id, start_time, end_time, reason, status)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare create time off statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var id int64
+	err = stmt.QueryRowxContext(ctx, data.UserID, data.StartTime, data.EndTime, data.Reason, data.Status).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create time off: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateTimeOffStatus records the review of a pending request, returning ErrTimeOffAlreadyReviewed when it no longer is
+func (r *repository) UpdateTimeOffStatus(ctx context.Context, data *models.TimeOff) error {
+	query := `
+		UPDATE time_off_requests
+		SET
+			status = ?,
+			reviewed_by = ?,
+			reviewed_at = ?,
+			review_note = ?,
+			updated_at = ?
+		WHERE id = ? AND status = 'pending'`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare update time off status statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	res, err := stmt.ExecContext(ctx, data.Status, data.ReviewedBy, data.ReviewedAt, data.ReviewNote, time.Now().UTC(), data.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update time off status: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated time off count: %w", err)
+	}
+	if affected == 0 {
+		// reviewed by someone else since it was read
+		return models.ErrTimeOffAlreadyReviewed
+	}
+
+	return nil
+}
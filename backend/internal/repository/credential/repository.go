@@ -0,0 +1,323 @@
+package credential
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetTypes(ctx context.Context) ([]models.CredentialType, error)
+	GetTypeByID(ctx context.Context, typeID int64) (*models.CredentialType, error)
+	GetTypeByCode(ctx context.Context, code string) (*models.CredentialType, error)
+	CreateType(ctx context.Context, data *models.CredentialType) (int64, error)
+	GetByUser(ctx context.Context, userID int64) ([]models.UserCredential, error)
+	GetValidForUser(ctx context.Context, userID int64, at time.Time) ([]models.UserCredential, error)
+	GetExpiring(ctx context.Context, from, to time.Time) ([]models.UserCredential, error)
+	Create(ctx context.Context, data *models.UserCredential) (int64, error)
+	Delete(ctx context.Context, userID, credentialID int64) (bool, error)
+	GetRequiredTypes(ctx context.Context, serviceName string) ([]models.CredentialType, error)
+	ReplaceRequiredTypes(ctx context.Context, serviceName string, typeIDs []int64) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const selectUserCredentials = `
+		SELECT uc.id, uc.user_id, uc.credential_type_id, uc.credential_number, uc.issued_at, uc.expires_at,
+			uc.document_ref, uc.created_at, uc.updated_at, ct.code AS type_code, ct.name AS type_name,
+			u.name AS user_name
+		FROM user_credentials uc
+		JOIN credential_types ct ON ct.id = uc.credential_type_id
+		JOIN users u ON u.id = uc.user_id`
+
+func (r *repository) GetTypes(ctx context.Context) ([]models.CredentialType, error) {
+	query := `
+		SELECT id, code, name, description, created_at, updated_at
+		FROM credential_types
+		ORDER BY code`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get credential types statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var types []models.CredentialType
+	err = stmt.SelectContext(ctx, &types)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential types: %w", err)
+	}
+
+	return types, nil
+}
+
+func (r *repository) GetTypeByID(ctx context.Context, typeID int64) (*models.CredentialType, error) {
+	query := `
+		SELECT id, code, name, description, created_at, updated_at
+		FROM credential_types
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get credential type by id statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var credentialType models.CredentialType
+	err = stmt.GetContext(ctx, &credentialType, typeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrCredentialTypeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential type by id: %w", err)
+	}
+
+	return &credentialType, nil
+}
+
+func (r *repository) GetTypeByCode(ctx context.Context, code string) (*models.CredentialType, error) {
+	query := `
+		SELECT id, code, name, description, created_at, updated_at
+		FROM credential_types
+		WHERE code = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get credential type by code statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var credentialType models.CredentialType
+	err = stmt.GetContext(ctx, &credentialType, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrCredentialTypeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential type by code: %w", err)
+	}
+
+	return &credentialType, nil
+}
+
+func (r *repository) CreateType(ctx context.Context, data *models.CredentialType) (int64, error) {
+	query := `
+		INSERT INTO credential_types (code, name, description)
+		VALUES (?, ?, ?)
+		RETURNING id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare create credential type statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var id int64
+	err = stmt.QueryRowxContext(ctx, data.Code, data.Name, data.Description).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credential type: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetByUser(ctx context.Context, userID int64) ([]models.UserCredential, error) {
+	query := selectUserCredentials + `
+		WHERE uc.user_id = ?
+		ORDER BY ct.code, uc.expires_at DESC NULLS FIRST`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get user credentials statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var credentials []models.UserCredential
+	err = stmt.SelectContext(ctx, &credentials, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// GetValidForUser returns the user's credentials that were issued and not yet expired on at's date
+func (r *repository) GetValidForUser(ctx context.Context, userID int64, at time.Time) ([]models.UserCredential, error) {
+	query := selectUserCredentials + `
+		WHERE uc.user_id = ? AND uc.issued_at <= ?::date AND (uc.expires_at IS NULL OR uc.expires_at >= ?::date)`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get valid credentials statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	day := at.UTC().Format(models.CredentialDateLayout)
+	var credentials []models.UserCredential
+	err = stmt.SelectContext(ctx, &credentials, userID, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get valid credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// GetExpiring returns every credential expiring between from and to, soonest first
+func (r *repository) GetExpiring(ctx context.Context, from, to time.Time) ([]models.UserCredential, error) {
+	query := selectUserCredentials + `
+		WHERE uc.expires_at >= ?::date AND uc.expires_at <= ?::date
+		ORDER BY uc.expires_at ASC, u.name ASC`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get expiring credentials statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var credentials []models.UserCredential
+	err = stmt.SelectContext(ctx, &credentials,
+		from.UTC().Format(models.CredentialDateLayout), to.UTC().Format(models.CredentialDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (r *repository) Create(ctx context.Context, data *models.UserCredential) (int64, error) {
+	query := `
+		INSERT INTO user_credentials (user_id, credential_type_id, credential_number, issued_at, expires_at, document_ref)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare create credential statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var id int64
+	err = stmt.QueryRowxContext(ctx,
+		data.UserID, data.CredentialTypeID, data.CredentialNumber, data.IssuedAt, data.ExpiresAt, data.DocumentRef,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete removes a user's credential and reports whether it existed
+func (r *repository) Delete(ctx context.Context, userID, credentialID int64) (bool, error) {
+	query := `DELETE FROM user_credentials WHERE id = ? AND user_id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare delete credential statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	res, err := stmt.ExecContext(ctx, credentialID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get deleted credential count: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *repository) GetRequiredTypes(ctx context.Context, serviceName string) ([]models.CredentialType, error) {
+	query := `
+		SELECT ct.id, ct.code, ct.name, ct.description, ct.created_at, ct.updated_at
+		FROM service_credential_requirements scr
+		JOIN credential_types ct ON ct.id = scr.credential_type_id
+		WHERE scr.service_name = ?
+		ORDER BY ct.code`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get required credential types statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var types []models.CredentialType
+	err = stmt.SelectContext(ctx, &types, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required credential types: %w", err)
+	}
+
+	return types, nil
+}
+
+// ReplaceRequiredTypes swaps the credential types required for a service in a single transaction
+func (r *repository) ReplaceRequiredTypes(ctx context.Context, serviceName string, typeIDs []int64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin replace credential requirements transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM service_credential_requirements WHERE service_name = ?`), serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential requirements: %w", err)
+	}
+
+	stmt, err := tx.PreparexContext(ctx, tx.Rebind(`
+		INSERT INTO service_credential_requirements (service_name, credential_type_id)
+		VALUES (?, ?)
+		ON CONFLICT DO NOTHING`))
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert credential requirement statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, typeID := range typeIDs {
+		if _, err = stmt.ExecContext(ctx, serviceName, typeID); err != nil {
+			return fmt.Errorf("failed to insert credential requirement: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit replace credential requirements: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package escalation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetOverdueVisits(ctx context.Context, startedAfter, startedBefore time.Time, defaultRiskLevel string) ([]models.OverdueVisit, error)
+	CreateAlert(ctx context.Context, alert *models.VisitAlert) (bool, error)
+	RecordDelivery(ctx context.Context, alert *models.VisitAlert) error
+	GetAlert(ctx context.Context, alertID int64) (*models.VisitAlert, error)
+	GetAlertsBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitAlert, error)
+	Acknowledge(ctx context.Context, alertID, userID int64, note string, now time.Time) error
+	GetRiskLevels(ctx context.Context) ([]models.ClientRiskLevel, error)
+	SetRiskLevel(ctx context.Context, clientName, riskLevel string) (*models.ClientRiskLevel, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const alertColumns = `
+	a.id, a.schedule_id, a.step, a.contact, a.recipient_id, u.name AS recipient_name, a.risk_level,
+	a.minutes_late, a.delivered_channels, a.delivery_error, a.sent_at,
+	a.acknowledged_by, a.acknowledged_at, a.acknowledgement_note`
+
+// GetOverdueVisits returns the scheduled visits that started in the window without being clocked into,
+// unless one of their alerts has already been acknowledged, with how many alerts each has had so far
+func (r *repository) GetOverdueVisits(ctx context.Context, startedAfter, startedBefore time.Time, defaultRiskLevel string) ([]models.OverdueVisit, error) {
+	query := `
+		SELECT s.id AS schedule_id, s.user_id, u.name AS caregiver_name,
+			s.client_name, s.service_name, s.location, s.start_time,
+			COALESCE(rl.risk_level, ?) AS risk_level,
+			(SELECT COUNT(*) FROM visit_alerts a WHERE a.schedule_id = s.id) AS alerts_sent
+		FROM schedules s
+		LEFT JOIN users u ON u.id = s.user_id
+		LEFT JOIN client_risk_levels rl ON rl.client_name = s.client_name
+		WHERE s.status = ?
+			AND s.clock_in_time IS NULL
+			AND s.start_time > ? AND s.start_time <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM visit_alerts a
+				WHERE a.schedule_id = s.id AND a.acknowledged_at IS NOT NULL
+			)
+		ORDER BY s.start_time, s.id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get overdue visits statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var visits []models.OverdueVisit
+	err = stmt.SelectContext(ctx, &visits, defaultRiskLevel, models.StatusScheduled, startedAfter, startedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue visits: %w", err)
+	}
+
+	return visits, nil
+}
+
+// CreateAlert records an escalation step before it is sent. It reports false when the step was
+// already raised, e.g. by another replica, in which case the caller must not send it again.
+func (r *repository) CreateAlert(ctx context.Context, alert *models.VisitAlert) (bool, error) {
+	query := `
+		INSERT INTO visit_alerts (schedule_id, step, contact, recipient_id, risk_level, minutes_late, sent_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (schedule_id, step) DO NOTHING
+		RETURNING id`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		alert.ScheduleID, alert.Step, alert.Contact, alert.RecipientID, alert.RiskLevel, alert.MinutesLate, alert.SentAt,
+	).Scan(&alert.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *repository) RecordDelivery(ctx context.Context, alert *models.VisitAlert) error {
+	query := `UPDATE visit_alerts SET delivered_channels = ?, delivery_error = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), alert.DeliveredChannels, alert.DeliveryError, alert.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record alert delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetAlert(ctx context.Context, alertID int64) (*models.VisitAlert, error) {
+	query := `SELECT` + alertColumns + ` FROM visit_alerts a JOIN users u ON u.id = a.recipient_id WHERE a.id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get alert statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var alert models.VisitAlert
+	err = stmt.GetContext(ctx, &alert, alertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+
+	return &alert, nil
+}
+
+func (r *repository) GetAlertsBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitAlert, error) {
+	query := `SELECT` + alertColumns + `
+		FROM visit_alerts a JOIN users u ON u.id = a.recipient_id
+		WHERE a.schedule_id = ?
+		ORDER BY a.step`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get alerts statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var alerts []models.VisitAlert
+	if err = stmt.SelectContext(ctx, &alerts, scheduleID); err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Acknowledge marks the alert as handled by userID; an alert can only be acknowledged once
+func (r *repository) Acknowledge(ctx context.Context, alertID, userID int64, note string, now time.Time) error {
+	query := `
+		UPDATE visit_alerts
+		SET acknowledged_by = ?, acknowledged_at = ?, acknowledgement_note = NULLIF(?, '')
+		WHERE id = ? AND acknowledged_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), userID, now, note, alertID)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+	if affected == 0 {
+		return models.ErrAlertAlreadyAcknowledged
+	}
+
+	return nil
+}
+
+func (r *repository) GetRiskLevels(ctx context.Context) ([]models.ClientRiskLevel, error) {
+	query := `SELECT client_name, risk_level, updated_at FROM client_risk_levels ORDER BY client_name`
+
+	var levels []models.ClientRiskLevel
+	if err := r.db.SelectContext(ctx, &levels, query); err != nil {
+		return nil, fmt.Errorf("failed to get client risk levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (r *repository) SetRiskLevel(ctx context.Context, clientName, riskLevel string) (*models.ClientRiskLevel, error) {
+	query := `
+		INSERT INTO client_risk_levels (client_name, risk_level)
+		VALUES (?, ?)
+		ON CONFLICT (client_name) DO UPDATE SET
+			risk_level = EXCLUDED.risk_level,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING client_name, risk_level, updated_at`
+
+	var level models.ClientRiskLevel
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), clientName, riskLevel).StructScan(&level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set client risk level: %w", err)
+	}
+
+	return &level, nil
+}
@@ -0,0 +1,76 @@
+package geocode
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository is the Postgres-backed cache of reverse geocoding results
+type Repository interface {
+	Get(ctx context.Context, lat, lng float64, provider string) (string, bool, error)
+	Set(ctx context.Context, lat, lng float64, address, provider string) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// Get returns the address cached by provider; addresses from another provider, e.g. the fake one
+// used before switching, are treated as missing and replaced by the next Set
+func (r *repository) Get(ctx context.Context, lat, lng float64, provider string) (string, bool, error) {
+	query := `
+		SELECT address
+		FROM geocode_cache
+		WHERE latitude_key = ? AND longitude_key = ? AND provider = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to prepare get geocode statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var address string
+	err = stmt.GetContext(ctx, &address, lat, lng, provider)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get geocode: %w", err)
+	}
+
+	return address, true, nil
+}
+
+func (r *repository) Set(ctx context.Context, lat, lng float64, address, provider string) error {
+	query := `
+		INSERT INTO geocode_cache (latitude_key, longitude_key, address, provider)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (latitude_key, longitude_key)
+		DO UPDATE SET address = EXCLUDED.address, provider = EXCLUDED.provider, updated_at = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare set geocode statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	_, err = stmt.ExecContext(ctx, lat, lng, address, provider, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to set geocode: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package note
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitNote, error)
+	GetByID(ctx context.Context, noteID int64) (*models.VisitNote, error)
+	Create(ctx context.Context, data *models.VisitNote) (int64, error)
+	UpdateBody(ctx context.Context, noteID int64, body string) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const selectNotes = `
+		SELECT n.id, n.schedule_id, n.user_id, n.body, n.created_at, n.updated_at, u.name AS author_name
+		FROM visit_notes n
+		JOIN users u ON u.id = n.user_id`
+
+func (r *repository) GetBySchedule(ctx context.Context, scheduleID int64) ([]models.VisitNote, error) {
+	query := selectNotes + `
+		WHERE n.schedule_id = ?
+		ORDER BY n.created_at, n.id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get notes statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var notes []models.VisitNote
+	err = stmt.SelectContext(ctx, &notes, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, noteID int64) (*models.VisitNote, error) {
+	query := selectNotes + `
+		WHERE n.id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get note by id statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var note models.VisitNote
+	err = stmt.GetContext(ctx, &note, noteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note by id: %w", err)
+	}
+
+	return &note, nil
+}
+
+func (r *repository) Create(ctx context.Context, data *models.VisitNote) (int64, error) {
+	query := `
+		INSERT INTO visit_notes (schedule_id, user_id, body, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare create note statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var id int64
+	err = stmt.QueryRowxContext(ctx, data.ScheduleID, data.UserID, data.Body, data.CreatedAt, data.UpdatedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) UpdateBody(ctx context.Context, noteID int64, body string) error {
+	query := `
+		UPDATE visit_notes
+		SET body = ?, updated_at = ?
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare update note statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	_, err = stmt.ExecContext(ctx, body, time.Now().UTC(), noteID)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error
+	QueueStartReminders(ctx context.Context, now, startsBefore time.Time) (int64, error)
+	QueueClockOutReminders(ctx context.Context, now, endedAfter, endedBefore time.Time) (int64, error)
+	QueueAssignment(ctx context.Context, scheduleID, userID int64, now time.Time) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error)
+	RecordAttempt(ctx context.Context, n *models.Notification) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// staleReason is recorded on notifications skipped because the visit changed after they were queued
+const staleReason = "visit changed before the notification was sent"
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// enabledChannels expands each schedule into one row per channel its caregiver has enabled;
+// the defaults for caregivers without preferences match models.DefaultNotificationPreferences
+const enabledChannels = `
+	CROSS JOIN unnest(ARRAY['push', 'sms', 'email']) AS c(channel)
+	LEFT JOIN notification_preferences p ON p.user_id = s.user_id
+	WHERE CASE c.channel
+			WHEN 'push' THEN COALESCE(p.push_enabled, TRUE)
+			WHEN 'sms' THEN COALESCE(p.sms_enabled, FALSE)
+			ELSE COALESCE(p.email_enabled, TRUE)
+		END`
+
+// preferenceColumns reads quiet hours as HH:MM text rather than as a time of day on year zero
+const preferenceColumns = `
+	user_id, push_enabled, sms_enabled, email_enabled, push_token,
+	to_char(quiet_hours_start, 'HH24:MI') AS quiet_hours_start,
+	to_char(quiet_hours_end, 'HH24:MI') AS quiet_hours_end, timezone`
+
+func (r *repository) GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	query := `SELECT` + preferenceColumns + ` FROM notification_preferences WHERE user_id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get notification preferences statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var prefs models.NotificationPreferences
+	err = stmt.GetContext(ctx, &prefs, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		prefs = models.DefaultNotificationPreferences(userID)
+		return &prefs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return &prefs, nil
+}
+
+func (r *repository) SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (
+			user_id, push_enabled, sms_enabled, email_enabled, push_token,
+			quiet_hours_start, quiet_hours_end, timezone
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			push_enabled = EXCLUDED.push_enabled,
+			sms_enabled = EXCLUDED.sms_enabled,
+			email_enabled = EXCLUDED.email_enabled,
+			push_token = EXCLUDED.push_token,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			timezone = EXCLUDED.timezone,
+			updated_at = CURRENT_TIMESTAMP`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		prefs.UserID, prefs.PushEnabled, prefs.SMSEnabled, prefs.EmailEnabled, prefs.PushToken,
+		prefs.QuietHoursStart, prefs.QuietHoursEnd, prefs.Timezone,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// QueueStartReminders queues a reminder on every enabled channel for assigned visits starting between now
+// and startsBefore that have not been clocked into. Each reminder is queued once per visit and caregiver,
+// and expires when the visit starts.
+func (r *repository) QueueStartReminders(ctx context.Context, now, startsBefore time.Time) (int64, error) {
+	query := `
+		INSERT INTO notifications (user_id, schedule_id, kind, channel, send_after, expires_at)
+		SELECT s.user_id, s.id, ?, c.channel, ?, s.start_time
+		FROM schedules s` + enabledChannels + `
+			AND s.user_id IS NOT NULL
+			AND s.status = ?
+			AND s.clock_in_time IS NULL
+			AND s.start_time > ? AND s.start_time <= ?
+		ON CONFLICT (schedule_id, user_id, kind, channel) DO NOTHING`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		models.NotificationVisitStarting, now, models.StatusScheduled, now, startsBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue visit start reminders: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+// QueueClockOutReminders queues a reminder for visits that ended between endedAfter and endedBefore
+// and are still clocked into. The lower bound keeps long-forgotten visits from being reminded about.
+func (r *repository) QueueClockOutReminders(ctx context.Context, now, endedAfter, endedBefore time.Time) (int64, error) {
+	query := `
+		INSERT INTO notifications (user_id, schedule_id, kind, channel, send_after)
+		SELECT s.user_id, s.id, ?, c.channel, ?
+		FROM schedules s` + enabledChannels + `
+			AND s.user_id IS NOT NULL
+			AND s.status <> ?
+			AND s.clock_in_time IS NOT NULL
+			AND s.clock_out_time IS NULL
+			AND s.end_time > ? AND s.end_time <= ?
+		ON CONFLICT (schedule_id, user_id, kind, channel) DO NOTHING`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		models.NotificationClockOutMissing, now, models.StatusCancelled, endedAfter, endedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue clock-out reminders: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+// QueueAssignment tells a caregiver a visit was assigned to them, again if it comes back to them later
+func (r *repository) QueueAssignment(ctx context.Context, scheduleID, userID int64, now time.Time) error {
+	query := `
+		INSERT INTO notifications (user_id, schedule_id, kind, channel, send_after, expires_at)
+		SELECT s.user_id, s.id, ?, c.channel, ?, s.end_time
+		FROM schedules s` + enabledChannels + `
+			AND s.id = ? AND s.user_id = ?
+		ON CONFLICT (schedule_id, user_id, kind, channel) DO UPDATE SET
+			status = 'pending',
+			send_after = EXCLUDED.send_after,
+			expires_at = EXCLUDED.expires_at,
+			attempts = 0,
+			last_error = NULL,
+			sent_at = NULL,
+			updated_at = EXCLUDED.send_after`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), models.NotificationVisitReassigned, now, scheduleID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to queue assignment notification: %w", err)
+	}
+
+	return nil
+}
+
+// staleNotification matches a notification n that its schedule s no longer calls for: the visit was
+// reassigned to someone else, clocked into before its start reminder went out, clocked out of before
+// the clock-out reminder did, or is over before the caregiver heard it was theirs
+const staleNotification = `(
+		s.user_id IS DISTINCT FROM n.user_id
+		OR (n.kind = 'visit_starting' AND (s.clock_in_time IS NOT NULL OR s.status <> 'scheduled'))
+		OR (n.kind = 'clock_out_missing' AND (s.clock_out_time IS NOT NULL OR s.status = 'cancelled'))
+		OR (n.kind = 'visit_reassigned' AND s.status IN ('completed', 'cancelled'))
+	)`
+
+// ClaimDue picks up to limit pending notifications that are due and pushes them back by lease,
+// so that other replicas skip them while they are being sent. Due notifications the visit no longer
+// calls for are skipped in the same statement instead of being sent.
+func (r *repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+	query := `
+		WITH stale AS (
+			UPDATE notifications n
+			SET status = 'skipped', last_error = ?, updated_at = ?
+			FROM schedules s
+			WHERE n.status = 'pending' AND n.send_after <= ?
+				AND s.id = n.schedule_id
+				AND ` + staleNotification + `
+			RETURNING n.id
+		)
+		UPDATE notifications n
+		SET send_after = ?, updated_at = ?
+		FROM users u
+		LEFT JOIN notification_preferences p ON p.user_id = u.id,
+		schedules s
+		WHERE n.id IN (
+				SELECT n.id FROM notifications n
+				JOIN schedules s ON s.id = n.schedule_id
+				WHERE n.status = 'pending' AND n.send_after <= ?
+					AND NOT ` + staleNotification + `
+				ORDER BY n.send_after, n.id
+				LIMIT ?
+				FOR UPDATE OF n SKIP LOCKED
+			)
+			AND u.id = n.user_id
+			AND s.id = n.schedule_id
+		RETURNING n.id, n.user_id, n.schedule_id, n.kind, n.channel, n.status, n.send_after, n.expires_at,
+			n.attempts, n.last_error, n.sent_at,
+			u.name AS user_name, u.email, u.phone, u.locale,
+			s.client_name, s.service_name, s.location, s.start_time, s.end_time,
+			n.user_id AS "preferences.user_id",
+			COALESCE(p.push_enabled, TRUE) AS "preferences.push_enabled",
+			COALESCE(p.sms_enabled, FALSE) AS "preferences.sms_enabled",
+			COALESCE(p.email_enabled, TRUE) AS "preferences.email_enabled",
+			p.push_token AS "preferences.push_token",
+			to_char(p.quiet_hours_start, 'HH24:MI') AS "preferences.quiet_hours_start",
+			to_char(p.quiet_hours_end, 'HH24:MI') AS "preferences.quiet_hours_end",
+			p.timezone AS "preferences.timezone"`
+
+	now := time.Now().UTC()
+	var notifications []models.Notification
+	err := r.db.SelectContext(ctx, &notifications, r.db.Rebind(query),
+		staleReason, now, now, now.Add(lease), now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *repository) RecordAttempt(ctx context.Context, n *models.Notification) error {
+	query := `
+		UPDATE notifications
+		SET status = ?, attempts = ?, send_after = ?, last_error = ?, sent_at = ?, updated_at = ?
+		WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		n.Status, n.Attempts, n.SendAfter, n.LastError, n.SentAt, time.Now().UTC(), n.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record notification attempt: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// Channel is the Postgres notification channel announcing committed outbox events; the payload is the event id
+const Channel = "outbox_events"
+
+type Repository interface {
+	GetEvent(ctx context.Context, id int64) (*models.OutboxEvent, error)
+	GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]models.OutboxEvent, error)
+	GetLatestEventID(ctx context.Context) (int64, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// Write stores events in the caller's transaction and queues a delivery for every active subscription
+// to each event's type, so an event is published if and only if the change that raised it is committed
+func Write(ctx context.Context, tx *sqlx.Tx, events []models.OutboxEvent) error {
+	for _, e := range events {
+		var eventID int64
+		err := tx.QueryRowxContext(ctx, tx.Rebind(`
+			INSERT INTO outbox_events (event_type, schedule_id, payload)
+			VALUES (?, ?, ?)
+			RETURNING id`),
+			e.EventType, e.ScheduleID, e.Payload,
+		).Scan(&eventID)
+		if err != nil {
+			return fmt.Errorf("failed to write %s event: %w", e.EventType, err)
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(`
+			INSERT INTO webhook_deliveries (event_id, subscription_id)
+			SELECT ?, id FROM webhook_subscriptions
+			WHERE active AND ? = ANY(event_types)`),
+			eventID, e.EventType,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to queue %s event deliveries: %w", e.EventType, err)
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(`SELECT pg_notify(?, ?)`), Channel, strconv.FormatInt(eventID, 10))
+		if err != nil {
+			return fmt.Errorf("failed to notify %s event: %w", e.EventType, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) GetEvent(ctx context.Context, id int64) (*models.OutboxEvent, error) {
+	query := `
+		SELECT id, event_type, schedule_id, payload, created_at
+		FROM outbox_events
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get outbox event statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var event models.OutboxEvent
+	err = stmt.GetContext(ctx, &event, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// GetEventsAfter returns up to limit events with an id greater than afterID, oldest first
+func (r *repository) GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]models.OutboxEvent, error) {
+	query := `
+		SELECT id, event_type, schedule_id, payload, created_at
+		FROM outbox_events
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get outbox events statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var events []models.OutboxEvent
+	if err = stmt.SelectContext(ctx, &events, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *repository) GetLatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.GetContext(ctx, &id, `SELECT COALESCE(MAX(id), 0) FROM outbox_events`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest outbox event id: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetComplianceStats(ctx context.Context, from, to time.Time, timezone string, groupBy []string) ([]models.ComplianceStats, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// groupColumns are the SELECT expressions of each dimension; period dimensions truncate start_time in the
+// agency timezone, passed as the first query argument
+var groupColumns = map[string][]string{
+	models.ReportGroupCaregiver: {"f.user_id", "f.caregiver_name"},
+	models.ReportGroupClient:    {"f.client_name"},
+	models.ReportGroupService:   {"f.service_name"},
+	models.ReportGroupDay:       {"date_trunc('day', f.start_time AT TIME ZONE ?) AS period"},
+	models.ReportGroupWeek:      {"date_trunc('week', f.start_time AT TIME ZONE ?) AS period"},
+	models.ReportGroupMonth:     {"date_trunc('month', f.start_time AT TIME ZONE ?) AS period"},
+}
+
+// GetComplianceStats sums visit compliance facts for visits starting in [from, to), one row per group
+func (r *repository) GetComplianceStats(ctx context.Context, from, to time.Time, timezone string, groupBy []string) ([]models.ComplianceStats, error) {
+	var (
+		columns []string
+		args    []any
+	)
+	for _, g := range groupBy {
+		for _, col := range groupColumns[g] {
+			if strings.Contains(col, "?") {
+				args = append(args, timezone)
+			}
+			columns = append(columns, col)
+		}
+	}
+
+	// grouped by position since the period expressions hold a bind parameter
+	positions := make([]string, len(columns))
+	for i := range columns {
+		positions[i] = strconv.Itoa(i + 1)
+	}
+
+	query := "SELECT "
+	if len(columns) > 0 {
+		query += strings.Join(columns, ", ") + ", "
+	}
+	query += `
+			COUNT(*) AS total_visits,
+			COUNT(*) FILTER (WHERE f.clocked_in) AS clocked_in_visits,
+			COUNT(*) FILTER (WHERE f.clocked_in AND NOT f.late_clock_in) AS on_time_clock_ins,
+			COUNT(*) FILTER (WHERE f.location_exception) AS location_exceptions,
+			COUNT(*) FILTER (WHERE f.missed) AS missed_visits,
+			COALESCE(SUM(f.tasks_total), 0) AS tasks_total,
+			COALESCE(SUM(f.tasks_completed), 0) AS tasks_completed,
+			COUNT(f.actual_minutes) AS timed_visits,
+			COALESCE(SUM(f.actual_minutes), 0) AS actual_minutes,
+			COALESCE(SUM(f.scheduled_minutes) FILTER (WHERE f.actual_minutes IS NOT NULL), 0) AS scheduled_minutes
+		FROM visit_compliance_facts f
+		WHERE f.start_time >= ? AND f.start_time < ?`
+	args = append(args, from, to)
+	if len(positions) > 0 {
+		query += " GROUP BY " + strings.Join(positions, ", ") + " ORDER BY " + strings.Join(positions, ", ")
+	}
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get compliance stats statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var stats []models.ComplianceStats
+	if err = stmt.SelectContext(ctx, &stats, args...); err != nil {
+		return nil, fmt.Errorf("failed to get compliance stats: %w", err)
+	}
+
+	return stats, nil
+}
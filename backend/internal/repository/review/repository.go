@@ -0,0 +1,154 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetExceptions(ctx context.Context, filter models.ExceptionFilter) ([]models.VisitException, error)
+	GetException(ctx context.Context, scheduleID int64, flag string) (*models.VisitException, error)
+	SaveReview(ctx context.Context, review *models.ExceptionReview) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// exceptionsQuery expands each visit's compliance flags into one row per flag, with its review if there is one
+const exceptionsQuery = `
+	SELECT s.id AS schedule_id, COALESCE(s.user_id, 0) AS user_id, COALESCE(u.name, '') AS caregiver_name,
+		s.client_name, s.service_name, s.start_time, f.flag, s.validation_notes,
+		COALESCE(r.status, 'open') AS status, r.reviewed_by, r.reviewed_at, r.note AS review_note
+	FROM schedules s
+	CROSS JOIN LATERAL unnest(s.compliance_flags) AS f(flag)
+	LEFT JOIN users u ON u.id = s.user_id
+	LEFT JOIN visit_exception_reviews r ON r.schedule_id = s.id AND r.flag = f.flag`
+
+func (r *repository) GetExceptions(ctx context.Context, filter models.ExceptionFilter) ([]models.VisitException, error) {
+	query := exceptionsQuery + " WHERE 1 = 1"
+	var args []any
+
+	switch filter.Status {
+	case "", models.ExceptionStatusAll:
+	case models.ExceptionStatusUnresolved:
+		query += " AND (r.status IS NULL OR r.status = ?)"
+		args = append(args, models.ExceptionStatusAcknowledged)
+	case models.ExceptionStatusOpen:
+		query += " AND r.status IS NULL"
+	default:
+		query += " AND r.status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.Flag != "" {
+		query += " AND f.flag = ?"
+		args = append(args, filter.Flag)
+	}
+	if filter.UserID != 0 {
+		query += " AND s.user_id = ?"
+		args = append(args, filter.UserID)
+	}
+	if filter.ClientName != "" {
+		query += " AND s.client_name ILIKE ?"
+		args = append(args, "%"+filter.ClientName+"%")
+	}
+	if !filter.From.IsZero() {
+		query += " AND s.start_time >= ?"
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		query += " AND s.start_time < ?"
+		args = append(args, filter.To)
+	}
+	query += " ORDER BY s.start_time ASC, s.id ASC, f.flag ASC"
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get exceptions statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var exceptions []models.VisitException
+	if err = stmt.SelectContext(ctx, &exceptions, args...); err != nil {
+		return nil, fmt.Errorf("failed to get exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func (r *repository) GetException(ctx context.Context, scheduleID int64, flag string) (*models.VisitException, error) {
+	query := exceptionsQuery + " WHERE s.id = ? AND f.flag = ? LIMIT 1"
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get exception statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var exception models.VisitException
+	err = stmt.GetContext(ctx, &exception, scheduleID, flag)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrExceptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exception: %w", err)
+	}
+
+	return &exception, nil
+}
+
+// SaveReview records the latest decision on a visit's flag, replacing an earlier acknowledgement.
+// A flag that was already approved or rejected is never overwritten; ErrExceptionAlreadyResolved is returned instead.
+func (r *repository) SaveReview(ctx context.Context, review *models.ExceptionReview) error {
+	query := `
+		INSERT INTO visit_exception_reviews (schedule_id, flag, status, reviewed_by, note, reviewed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (schedule_id, flag) DO UPDATE SET
+			status = EXCLUDED.status,
+			reviewed_by = EXCLUDED.reviewed_by,
+			note = EXCLUDED.note,
+			reviewed_at = EXCLUDED.reviewed_at,
+			updated_at = ?
+		WHERE visit_exception_reviews.status = 'acknowledged'`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare save exception review statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	res, err := stmt.ExecContext(ctx,
+		review.ScheduleID, review.Flag, review.Status, review.ReviewedBy, review.Note, review.ReviewedAt,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save exception review: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get saved exception review count: %w", err)
+	}
+	if affected == 0 {
+		// approved or rejected by someone else since it was read
+		return models.ErrExceptionAlreadyResolved
+	}
+
+	return nil
+}
@@ -2,18 +2,28 @@ package schedule
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/attestation"
+	"github.com/erizkiatama/bluehorntech/internal/repository/outbox"
+	"github.com/erizkiatama/bluehorntech/internal/repository/task"
 	"github.com/jmoiron/sqlx"
 )
 
 type Repository interface {
 	GetAll(ctx context.Context, userID int64, isToday bool, start, end string) ([]models.Schedule, error)
 	GetByID(ctx context.Context, scheduleID, userID int64) (*models.Schedule, error)
-	UpdateClockIn(ctx context.Context, req models.Schedule) error
-	UpdateClockOut(ctx context.Context, req models.Schedule) error
+	UpdateClockIn(ctx context.Context, req models.Schedule, events []models.OutboxEvent) error
+	UpdateClockOut(ctx context.Context, req models.Schedule, att *models.Attestation, events []models.OutboxEvent) error
+	Get(ctx context.Context, scheduleID int64) (*models.Schedule, error)
+	GetByUserBetween(ctx context.Context, userID int64, from, to time.Time) ([]models.Schedule, error)
+	Create(ctx context.Context, req *models.Schedule, tasks []models.Task) (int64, error)
+	UpdateAssignee(ctx context.Context, scheduleID, userID int64) error
+	CountCompletedByClient(ctx context.Context, clientName string) ([]models.VisitCount, error)
 }
 
 type repository struct {
@@ -59,7 +69,11 @@ func (r *repository) GetByID(ctx context.Context, scheduleID, userID int64) (*mo
 	query := `
 		SELECT id, user_id, client_name, service_name, service_notes, location, start_time, end_time,
 			latitude, longitude, status, clock_in_time, clock_out_time, clock_in_latitude, clock_out_latitude,
-			clock_in_longitude, clock_out_longitude FROM schedules WHERE user_id = ? AND id = ?`
+			clock_in_longitude, clock_out_longitude, clock_in_address, clock_out_address, compliance_flags,
+			validation_notes, COALESCE(v.review_status, 'clear') AS review_status
+		FROM schedules
+		LEFT JOIN visit_review_status v ON v.schedule_id = schedules.id
+		WHERE user_id = ? AND id = ?`
 
 	var schedule models.Schedule
 	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
@@ -71,6 +85,9 @@ func (r *repository) GetByID(ctx context.Context, scheduleID, userID int64) (*mo
 	}()
 
 	err = stmt.GetContext(ctx, &schedule, userID, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrScheduleNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schedule by id: %w", err)
 	}
@@ -78,63 +95,253 @@ func (r *repository) GetByID(ctx context.Context, scheduleID, userID int64) (*mo
 	return &schedule, nil
 }
 
-func (r *repository) UpdateClockIn(ctx context.Context, req models.Schedule) error {
+// UpdateClockIn saves the clock-in and writes its events to the outbox in the same transaction
+func (r *repository) UpdateClockIn(ctx context.Context, req models.Schedule, events []models.OutboxEvent) error {
 	query := `
 		UPDATE schedules 
 		SET 
 			clock_in_time = ?,
 			clock_in_latitude = ?,
 			clock_in_longitude = ?,
+			clock_in_address = ?,
 			status = ?,
 			compliance_flags = ?,
 			validation_notes = ?,
 			updated_at = ?
 		WHERE id = ?`
 
-	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to prepare update clock in statement: %w", err)
+		return fmt.Errorf("failed to begin update clock in transaction: %w", err)
 	}
 	defer func() {
-		_ = stmt.Close()
+		_ = tx.Rollback()
 	}()
 
-	_, err = stmt.ExecContext(ctx,
-		req.ClockInTime, req.ClockInLatitude, req.ClockInLongitude, req.Status,
+	_, err = tx.ExecContext(ctx, tx.Rebind(query),
+		req.ClockInTime, req.ClockInLatitude, req.ClockInLongitude, req.ClockInAddress, req.Status,
 		req.ComplianceFlags, req.ValidationNotes, time.Now().UTC(), req.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update clock in: %w", err)
 	}
 
+	if err = outbox.Write(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit update clock in: %w", err)
+	}
+
 	return nil
 }
 
-func (r *repository) UpdateClockOut(ctx context.Context, req models.Schedule) error {
+// UpdateClockOut saves the clock-out, the client's attestation when there is one,
+// and writes its events to the outbox in the same transaction
+func (r *repository) UpdateClockOut(ctx context.Context, req models.Schedule, att *models.Attestation, events []models.OutboxEvent) error {
 	query := `
 		UPDATE schedules 
 		SET 
 			clock_out_time = ?,
 			clock_out_latitude = ?,
 			clock_out_longitude = ?,
+			clock_out_address = ?,
 			status = ?,
+			compliance_flags = ?,
+			validation_notes = ?,
+			actual_minutes = ?,
+			scheduled_minutes = ?,
+			variance_minutes = ?,
+			early_departure_reason = ?,
+			early_departure_note = ?,
 			updated_at = ?
 		WHERE id = ? AND clock_in_time IS NOT NULL`
 
-	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to prepare update clock out statement: %w", err)
+		return fmt.Errorf("failed to begin update clock out transaction: %w", err)
 	}
 	defer func() {
-		_ = stmt.Close()
+		_ = tx.Rollback()
 	}()
 
-	_, err = stmt.ExecContext(ctx,
-		req.ClockOutTime, req.ClockOutLatitude, req.ClockOutLongitude, req.Status, time.Now().UTC(), req.ID,
+	_, err = tx.ExecContext(ctx, tx.Rebind(query),
+		req.ClockOutTime, req.ClockOutLatitude, req.ClockOutLongitude, req.ClockOutAddress, req.Status,
+		req.ComplianceFlags, req.ValidationNotes, req.ActualMinutes, req.ScheduledMinutes, req.VarianceMinutes,
+		req.EarlyDepartureReason, req.EarlyDepartureNote, time.Now().UTC(), req.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update clock out: %w", err)
 	}
 
+	if att != nil {
+		if err = attestation.Upsert(ctx, tx, att); err != nil {
+			return err
+		}
+	}
+
+	if err = outbox.Write(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit update clock out: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a schedule regardless of which caregiver it is assigned to; unassigned schedules have a zero UserID
+func (r *repository) Get(ctx context.Context, scheduleID int64) (*models.Schedule, error) {
+	query := `
+		SELECT id, COALESCE(user_id, 0) AS user_id, client_name, service_name, service_notes, location, start_time,
+			end_time, latitude, longitude, status, clock_in_time, clock_out_time, clock_in_latitude, clock_out_latitude,
+			clock_in_longitude, clock_out_longitude, clock_in_address, clock_out_address, compliance_flags,
+			validation_notes
+		FROM schedules WHERE id = ?`
+
+	var schedule models.Schedule
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get schedule statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	err = stmt.GetContext(ctx, &schedule, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// GetByUserBetween returns the user's non-cancelled schedules overlapping [from, to)
+func (r *repository) GetByUserBetween(ctx context.Context, userID int64, from, to time.Time) ([]models.Schedule, error) {
+	query := `
+		SELECT id, user_id, client_name, service_name, service_notes, location, start_time, end_time,
+			latitude, longitude, status, clock_in_time, clock_out_time, clock_in_latitude, clock_out_latitude,
+			clock_in_longitude, clock_out_longitude FROM schedules
+		WHERE user_id = ? AND start_time < ? AND end_time > ? AND status <> 'cancelled'
+		ORDER BY start_time ASC`
+
+	var schedules []models.Schedule
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get schedules between statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	err = stmt.SelectContext(ctx, &schedules, userID, to, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules between: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// Create inserts a visit together with its planned tasks in one transaction
+func (r *repository) Create(ctx context.Context, req *models.Schedule, tasks []models.Task) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin create schedule transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `
+		INSERT INTO schedules (
+			user_id, client_name, service_name, service_notes, location,
+			latitude, longitude, start_time, end_time, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	var id int64
+	userID := sql.NullInt64{Int64: req.UserID, Valid: req.UserID != 0}
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		userID, req.ClientName, req.ServiceName, req.ServiceNotes, req.Location,
+		req.Latitude, req.Longitude, req.StartTime, req.EndTime, req.Status,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	if len(tasks) > 0 {
+		if err = task.CreateForSchedule(ctx, tx, id, tasks); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit create schedule: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateAssignee assigns a visit that has not started yet, returning ErrScheduleNotEditable when it no longer can be
+func (r *repository) UpdateAssignee(ctx context.Context, scheduleID, userID int64) error {
+	query := `
+		UPDATE schedules
+		SET
+			user_id = ?,
+			updated_at = ?
+		WHERE id = ? AND status = 'scheduled'`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return fmt.Errorf("failed to prepare update assignee statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	res, err := stmt.ExecContext(ctx, userID, time.Now().UTC(), scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to update assignee: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated assignee count: %w", err)
+	}
+	if affected == 0 {
+		// the visit is gone or was started or cancelled since it was read
+		return models.ErrScheduleNotEditable
+	}
+
 	return nil
 }
+
+// CountCompletedByClient returns how many completed visits each caregiver has had with the client
+func (r *repository) CountCompletedByClient(ctx context.Context, clientName string) ([]models.VisitCount, error) {
+	query := `
+		SELECT user_id, COUNT(*) AS count
+		FROM schedules
+		WHERE client_name = ? AND status = 'completed' AND user_id IS NOT NULL
+		GROUP BY user_id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare count completed by client statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var counts []models.VisitCount
+	err = stmt.SelectContext(ctx, &counts, clientName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completed by client: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,147 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]models.UserSkill, error)
+	GetByUser(ctx context.Context, userID int64) ([]string, error)
+	ReplaceForUser(ctx context.Context, userID int64, skills []string) error
+	GetRequired(ctx context.Context, serviceName string) ([]string, error)
+	ReplaceRequired(ctx context.Context, serviceName string, skills []string) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]models.UserSkill, error) {
+	query := `
+		SELECT id, user_id, skill
+		FROM user_skills
+		ORDER BY user_id, skill`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get all skills statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var skills []models.UserSkill
+	err = stmt.SelectContext(ctx, &skills)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+func (r *repository) GetByUser(ctx context.Context, userID int64) ([]string, error) {
+	query := `
+		SELECT skill
+		FROM user_skills
+		WHERE user_id = ?
+		ORDER BY skill`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get user skills statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	skills := []string{}
+	err = stmt.SelectContext(ctx, &skills, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+func (r *repository) ReplaceForUser(ctx context.Context, userID int64, skills []string) error {
+	return r.replace(ctx,
+		`DELETE FROM user_skills WHERE user_id = ?`,
+		`INSERT INTO user_skills (user_id, skill) VALUES (?, ?)`,
+		userID, skills,
+	)
+}
+
+func (r *repository) GetRequired(ctx context.Context, serviceName string) ([]string, error) {
+	query := `
+		SELECT skill
+		FROM service_skill_requirements
+		WHERE service_name = ?
+		ORDER BY skill`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get required skills statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	skills := []string{}
+	err = stmt.SelectContext(ctx, &skills, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+func (r *repository) ReplaceRequired(ctx context.Context, serviceName string, skills []string) error {
+	return r.replace(ctx,
+		`DELETE FROM service_skill_requirements WHERE service_name = ?`,
+		`INSERT INTO service_skill_requirements (service_name, skill) VALUES (?, ?)`,
+		serviceName, skills,
+	)
+}
+
+// replace deletes every row owned by key and inserts the given skills in a single transaction
+func (r *repository) replace(ctx context.Context, deleteQuery, insertQuery string, key any, skills []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin replace skills transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(deleteQuery), key); err != nil {
+		return fmt.Errorf("failed to delete skills: %w", err)
+	}
+
+	stmt, err := tx.PreparexContext(ctx, tx.Rebind(insertQuery))
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert skill statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, skill := range skills {
+		if _, err = stmt.ExecContext(ctx, key, skill); err != nil {
+			return fmt.Errorf("failed to insert skill: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit replace skills: %w", err)
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package task
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/outbox"
 	"github.com/jmoiron/sqlx"
 	"time"
 )
@@ -11,7 +14,14 @@ import (
 type Repository interface {
 	GetAll(ctx context.Context, scheduleID int64) ([]models.Task, error)
 	GetByID(ctx context.Context, taskID int64) (*models.Task, error)
-	UpdateTask(ctx context.Context, data *models.Task) error
+	UpdateTask(ctx context.Context, update models.TaskUpdate) (bool, error)
+	UpdateTasks(ctx context.Context, updates []models.TaskUpdate) (bool, error)
+	GetHistory(ctx context.Context, taskID int64) ([]models.TaskStatusChange, error)
+	CreateCorrection(ctx context.Context, correction *models.TaskCorrection) (bool, error)
+	GetCorrection(ctx context.Context, correctionID int64) (*models.TaskCorrection, error)
+	GetCorrections(ctx context.Context, status string) ([]models.TaskCorrection, error)
+	ApproveCorrection(ctx context.Context, correction *models.TaskCorrection, update models.TaskUpdate) (bool, error)
+	RejectCorrection(ctx context.Context, correction *models.TaskCorrection) (bool, error)
 }
 
 type repository struct {
@@ -24,10 +34,11 @@ func New(db *sqlx.DB) Repository {
 
 func (r *repository) GetAll(ctx context.Context, scheduleID int64) ([]models.Task, error) {
 	query := `
-		SELECT id, schedule_id, name, description, status, reason, completed_at
+		SELECT id, schedule_id, name, description, status, reason, completed_at,
+			is_required, sort_order, task_type, options, value
 		FROM tasks 
 		WHERE schedule_id = ?
-		ORDER BY id`
+		ORDER BY sort_order, id`
 
 	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
 	if err != nil {
@@ -48,7 +59,8 @@ func (r *repository) GetAll(ctx context.Context, scheduleID int64) ([]models.Tas
 
 func (r *repository) GetByID(ctx context.Context, taskID int64) (*models.Task, error) {
 	query := `
-		SELECT id, schedule_id, name, description, status, reason, completed_at
+		SELECT id, schedule_id, name, description, status, reason, completed_at,
+			is_required, sort_order, task_type, options, value
 		FROM tasks 
 		WHERE id = ?`
 
@@ -62,6 +74,9 @@ func (r *repository) GetByID(ctx context.Context, taskID int64) (*models.Task, e
 
 	var task models.Task
 	err = stmt.GetContext(ctx, &task, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrTaskNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task by id: %w", err)
 	}
@@ -69,28 +84,297 @@ func (r *repository) GetByID(ctx context.Context, taskID int64) (*models.Task, e
 	return &task, nil
 }
 
-func (r *repository) UpdateTask(ctx context.Context, data *models.Task) error {
+// UpdateTask changes a task's outcome and records the change and its outbox events in the same transaction.
+// It reports false when the task no longer has change.FromStatus, i.e. someone else changed it first,
+// or the visit is no longer in update.ScheduleStatus, e.g. it was clocked out in the meantime.
+func (r *repository) UpdateTask(ctx context.Context, update models.TaskUpdate) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin update task transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	updated, err := updateTask(ctx, tx, update)
+	if err != nil || !updated {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit update task: %w", err)
+	}
+
+	return true, nil
+}
+
+// UpdateTasks saves several task outcomes in one transaction, rolling all of them back
+// and reporting false if any task was changed by someone else in the meantime
+func (r *repository) UpdateTasks(ctx context.Context, updates []models.TaskUpdate) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin update tasks transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, u := range updates {
+		updated, err := updateTask(ctx, tx, u)
+		if err != nil || !updated {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit update tasks: %w", err)
+	}
+
+	return true, nil
+}
+
+func updateTask(ctx context.Context, tx *sqlx.Tx, update models.TaskUpdate) (bool, error) {
+	data, change := update.Task, update.Change
+
 	query := `
 		UPDATE tasks 
 		SET 
 			status = ?,
 			reason = ?,
 			completed_at = ?,
+			value = ?,
 			updated_at = ?
-		WHERE id = ? AND status = 'pending'`
+		WHERE id = ? AND status = ?
+			AND EXISTS (SELECT 1 FROM schedules WHERE id = tasks.schedule_id AND status = ?)`
+
+	res, err := tx.ExecContext(ctx, tx.Rebind(query),
+		data.Status, data.Reason, data.CompletedAt, data.Value, time.Now().UTC(), data.ID, change.FromStatus,
+		update.ScheduleStatus)
+	if err != nil {
+		return false, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get updated task count: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		INSERT INTO task_status_changes (
+			task_id, schedule_id, changed_by, approved_by, from_status, to_status,
+			from_reason, to_reason, from_value, to_value, change_reason
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		change.TaskID, change.ScheduleID, change.ChangedBy, change.ApprovedBy, change.FromStatus, change.ToStatus,
+		change.FromReason, change.ToReason, change.FromValue, change.ToValue, change.ChangeReason,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record task status change: %w", err)
+	}
+
+	if err = outbox.Write(ctx, tx, update.Events); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *repository) GetHistory(ctx context.Context, taskID int64) ([]models.TaskStatusChange, error) {
+	query := `
+		SELECT id, task_id, schedule_id, changed_by, approved_by, from_status, to_status,
+			from_reason, to_reason, from_value, to_value, change_reason, created_at
+		FROM task_status_changes
+		WHERE task_id = ?
+		ORDER BY created_at, id`
 
 	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
 	if err != nil {
-		return fmt.Errorf("failed to prepare update task statement: %w", err)
+		return nil, fmt.Errorf("failed to prepare get task history statement: %w", err)
 	}
 	defer func() {
 		_ = stmt.Close()
 	}()
 
-	_, err = stmt.ExecContext(ctx, data.Status, data.Reason, data.CompletedAt, time.Now().UTC(), data.ID)
+	var changes []models.TaskStatusChange
+	err = stmt.SelectContext(ctx, &changes, taskID)
 	if err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+
+	return changes, nil
+}
+
+// CreateForSchedule inserts a visit's planned tasks in the caller's transaction,
+// so a visit is never saved without the tasks it was booked with
+func CreateForSchedule(ctx context.Context, tx *sqlx.Tx, scheduleID int64, tasks []models.Task) error {
+	stmt, err := tx.PreparexContext(ctx, tx.Rebind(`
+		INSERT INTO tasks (schedule_id, name, description, is_required, sort_order, task_type, options)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`))
+	if err != nil {
+		return fmt.Errorf("failed to prepare create task statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, t := range tasks {
+		_, err = stmt.ExecContext(ctx, scheduleID, t.Name, t.Description, t.IsRequired, t.SortOrder, t.TaskType, t.Options)
+		if err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
 	}
 
 	return nil
 }
+
+const correctionColumns = `
+	id, task_id, schedule_id, requested_by, from_status, to_status, to_reason, to_value,
+	correction_reason, status, reviewed_by, reviewed_at, review_note, created_at`
+
+// CreateCorrection records a correction waiting for a supervisor. It reports false when
+// the task already has a pending correction.
+func (r *repository) CreateCorrection(ctx context.Context, correction *models.TaskCorrection) (bool, error) {
+	query := `
+		INSERT INTO task_corrections (
+			task_id, schedule_id, requested_by, from_status, to_status, to_reason, to_value, correction_reason, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (task_id) WHERE status = 'pending' DO NOTHING
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		correction.TaskID, correction.ScheduleID, correction.RequestedBy, correction.FromStatus, correction.ToStatus,
+		correction.ToReason, correction.ToValue, correction.CorrectionReason, correction.Status,
+	).Scan(&correction.ID, &correction.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create task correction: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *repository) GetCorrection(ctx context.Context, correctionID int64) (*models.TaskCorrection, error) {
+	query := `SELECT` + correctionColumns + `
+		FROM task_corrections
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get task correction statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var correction models.TaskCorrection
+	err = stmt.GetContext(ctx, &correction, correctionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrCorrectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task correction: %w", err)
+	}
+
+	return &correction, nil
+}
+
+// GetCorrections returns the corrections with the given status, oldest first
+func (r *repository) GetCorrections(ctx context.Context, status string) ([]models.TaskCorrection, error) {
+	query := `SELECT` + correctionColumns + `
+		FROM task_corrections
+		WHERE status = ?
+		ORDER BY created_at, id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get task corrections statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var corrections []models.TaskCorrection
+	err = stmt.SelectContext(ctx, &corrections, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task corrections: %w", err)
+	}
+
+	return corrections, nil
+}
+
+// ApproveCorrection applies the corrected outcome and marks the correction approved in the same transaction.
+// It reports false when the correction was reviewed by someone else first, or the task changed since it was requested.
+func (r *repository) ApproveCorrection(ctx context.Context, correction *models.TaskCorrection, update models.TaskUpdate) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin approve task correction transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	reviewed, err := reviewCorrection(ctx, tx, correction)
+	if err != nil || !reviewed {
+		return false, err
+	}
+
+	updated, err := updateTask(ctx, tx, update)
+	if err != nil || !updated {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit approve task correction: %w", err)
+	}
+
+	return true, nil
+}
+
+// RejectCorrection closes the correction without changing the task.
+// It reports false when the correction was reviewed by someone else first.
+func (r *repository) RejectCorrection(ctx context.Context, correction *models.TaskCorrection) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin reject task correction transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	reviewed, err := reviewCorrection(ctx, tx, correction)
+	if err != nil || !reviewed {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit reject task correction: %w", err)
+	}
+
+	return true, nil
+}
+
+func reviewCorrection(ctx context.Context, tx *sqlx.Tx, correction *models.TaskCorrection) (bool, error) {
+	query := `
+		UPDATE task_corrections
+		SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?
+		WHERE id = ? AND status = ?`
+
+	res, err := tx.ExecContext(ctx, tx.Rebind(query),
+		correction.Status, correction.ReviewedBy, correction.ReviewedAt, correction.ReviewNote,
+		correction.ID, models.CorrectionStatusPending,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to review task correction: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get reviewed task correction count: %w", err)
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]models.User, error)
+	GetByID(ctx context.Context, userID int64) (*models.User, error)
+	UpdateLocale(ctx context.Context, userID int64, locale string) (bool, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]models.User, error) {
+	query := `
+		SELECT id, name, phone, email, locale, role, created_at, updated_at
+		FROM users
+		ORDER BY id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get all users statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var users []models.User
+	err = stmt.SelectContext(ctx, &users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, userID int64) (*models.User, error) {
+	query := `
+		SELECT id, name, phone, email, locale, role, created_at, updated_at
+		FROM users
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get user by id statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var user models.User
+	err = stmt.GetContext(ctx, &user, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	return &user, nil
+}
+
+// UpdateLocale saves the user's language preference, reporting false when the user does not exist
+func (r *repository) UpdateLocale(ctx context.Context, userID int64, locale string) (bool, error) {
+	query := `UPDATE users SET locale = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare update user locale statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	res, err := stmt.ExecContext(ctx, locale, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update user locale: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
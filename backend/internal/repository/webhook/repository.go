@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository interface {
+	GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	EnqueueTo(ctx context.Context, subscriptionID int64, event models.OutboxEvent) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeadLetters(ctx context.Context) ([]models.WebhookDelivery, error)
+	RetryDeadLetter(ctx context.Context, deliveryID int64) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		ORDER BY id`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get webhook subscriptions statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var subs []models.WebhookSubscription
+	if err = stmt.SelectContext(ctx, &subs); err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *repository) GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		WHERE id = ?`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get webhook subscription statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var sub models.WebhookSubscription
+	err = stmt.GetContext(ctx, &sub, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return &sub, nil
+}
+
+func (r *repository) CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, active)
+		VALUES (?, ?, ?, ?)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), sub.URL, sub.Secret, sub.EventTypes, sub.Active).
+		Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = ?, secret = ?, event_types = ?, active = ?, updated_at = ?
+		WHERE id = ?
+		RETURNING updated_at`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		sub.URL, sub.Secret, sub.EventTypes, sub.Active, time.Now().UTC(), sub.ID,
+	).Scan(&sub.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrWebhookNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSubscription removes a subscription along with its pending and dead deliveries
+func (r *repository) DeleteSubscription(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM webhook_subscriptions WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get deleted webhook subscription count: %w", err)
+	}
+	if affected == 0 {
+		return models.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// EnqueueTo stores an event and queues its delivery to a single subscription, regardless of its event types
+func (r *repository) EnqueueTo(ctx context.Context, subscriptionID int64, event models.OutboxEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin enqueue webhook event transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var eventID int64
+	err = tx.QueryRowxContext(ctx, tx.Rebind(`
+		INSERT INTO outbox_events (event_type, schedule_id, payload)
+		VALUES (?, ?, ?)
+		RETURNING id`),
+		event.EventType, event.ScheduleID, event.Payload,
+	).Scan(&eventID)
+	if err != nil {
+		return fmt.Errorf("failed to write %s event: %w", event.EventType, err)
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		INSERT INTO webhook_deliveries (event_id, subscription_id) VALUES (?, ?)`),
+		eventID, subscriptionID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to queue %s event delivery: %w", event.EventType, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enqueue webhook event: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries picks up to limit pending deliveries that are due and pushes their next attempt
+// back by lease, so that other dispatchers skip them while they are in flight. A dispatcher that dies
+// mid-delivery leaves them to be retried once the lease runs out.
+func (r *repository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = ?, updated_at = ?
+		FROM outbox_events e, webhook_subscriptions s
+		WHERE d.id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= ?
+				ORDER BY next_attempt_at, id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			AND e.id = d.event_id
+			AND s.id = d.subscription_id
+		RETURNING d.id, d.event_id, d.subscription_id, d.status, d.attempts, d.next_attempt_at,
+			d.last_status_code, d.last_error, d.delivered_at, d.created_at,
+			e.event_type, e.payload, e.created_at AS event_created_at, s.url, s.secret`
+
+	now := time.Now().UTC()
+	var deliveries []models.WebhookDelivery
+	err := r.db.SelectContext(ctx, &deliveries, r.db.Rebind(query), now.Add(lease), now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt saves the outcome of a delivery attempt
+func (r *repository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?,
+			delivered_at = ?, updated_at = ?
+		WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode, delivery.LastError,
+		delivery.DeliveredAt, time.Now().UTC(), delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetDeadLetters(ctx context.Context) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, event_id, subscription_id, status, attempts, next_attempt_at, last_status_code, last_error,
+			delivered_at, created_at, event_type, payload, event_created_at, url
+		FROM webhook_dead_letters
+		ORDER BY created_at DESC, id DESC`
+
+	stmt, err := r.db.PreparexContext(ctx, r.db.Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare get dead letters statement: %w", err)
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	var deliveries []models.WebhookDelivery
+	if err = stmt.SelectContext(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to get dead letters: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RetryDeadLetter puts a dead delivery back in the queue with a fresh set of attempts
+func (r *repository) RetryDeadLetter(ctx context.Context, deliveryID int64) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = ?, updated_at = ?
+		WHERE id = ? AND status = 'dead'`
+
+	now := time.Now().UTC()
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), now, now, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to retry dead letter: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get retried dead letter count: %w", err)
+	}
+	if affected == 0 {
+		return models.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/attachment"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+	"github.com/erizkiatama/bluehorntech/internal/repository/task"
+	"github.com/erizkiatama/bluehorntech/pkg/storage"
+)
+
+// sniffLength is how much of a file http.DetectContentType looks at
+const sniffLength = 512
+
+type Service interface {
+	GetAttachments(ctx context.Context, userID, scheduleID int64) ([]models.AttachmentResponse, error)
+	GetAttachment(ctx context.Context, userID, attachmentID int64) (*models.AttachmentResponse, error)
+	Upload(ctx context.Context, userID, scheduleID, taskID int64, file *multipart.FileHeader) (*models.AttachmentResponse, error)
+	DeleteAttachment(ctx context.Context, userID, attachmentID int64) error
+	OpenFile(ctx context.Context, key string, expires int64, signature string) (*models.VisitAttachment, io.ReadCloser, error)
+}
+
+type service struct {
+	cfg            config.StorageConfig
+	store          storage.Store
+	attachmentRepo attachment.Repository
+	scheduleRepo   schedule.Repository
+	taskRepo       task.Repository
+}
+
+func New(
+	cfg config.StorageConfig,
+	store storage.Store,
+	attachmentRepo attachment.Repository,
+	scheduleRepo schedule.Repository,
+	taskRepo task.Repository,
+) Service {
+	return &service{
+		cfg:            cfg,
+		store:          store,
+		attachmentRepo: attachmentRepo,
+		scheduleRepo:   scheduleRepo,
+		taskRepo:       taskRepo,
+	}
+}
+
+func (s *service) GetAttachments(ctx context.Context, userID, scheduleID int64) ([]models.AttachmentResponse, error) {
+	if _, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.AttachmentResponse, len(attachments))
+	for i := range attachments {
+		r, err := s.toResponse(ctx, &attachments[i])
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = *r
+	}
+
+	return resp, nil
+}
+
+func (s *service) GetAttachment(ctx context.Context, userID, attachmentID int64) (*models.AttachmentResponse, error) {
+	att, err := s.getOwned(ctx, userID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, att)
+}
+
+// Upload validates the file's size and sniffed content type, stores it and records it against the visit or task
+func (s *service) Upload(ctx context.Context, userID, scheduleID, taskID int64, file *multipart.FileHeader) (*models.AttachmentResponse, error) {
+	if file == nil || file.Size == 0 {
+		return nil, models.ErrFileRequired
+	}
+	if s.cfg.MaxUploadBytes > 0 && file.Size > s.cfg.MaxUploadBytes {
+		return nil, models.ErrFileTooLarge
+	}
+
+	if _, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID); err != nil {
+		return nil, err
+	}
+
+	var taskRef sql.NullInt64
+	if taskID > 0 {
+		tsk, err := s.taskRepo.GetByID(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if tsk.ScheduleID != scheduleID {
+			return nil, models.ErrTaskNotInSchedule
+		}
+		taskRef = sql.NullInt64{Int64: taskID, Valid: true}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// the client's Content-Type header is not trusted, the type is detected from the content
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	head = head[:n]
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !s.isAllowed(contentType) {
+		return nil, models.ErrUnsupportedFileType
+	}
+
+	key, err := newKey(scheduleID, contentType, file.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	body := io.MultiReader(bytes.NewReader(head), f)
+	if err = s.store.Put(ctx, key, body, file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	att := &models.VisitAttachment{
+		ScheduleID:  scheduleID,
+		TaskID:      taskRef,
+		UserID:      userID,
+		StorageKey:  key,
+		FileName:    filepath.Base(file.Filename),
+		ContentType: contentType,
+		SizeBytes:   file.Size,
+	}
+
+	id, err := s.attachmentRepo.Create(ctx, att)
+	if err != nil {
+		if delErr := s.store.Delete(ctx, key); delErr != nil {
+			slog.WarnContext(ctx, "Failed to clean up orphaned attachment", "storage_key", key, "error", delErr)
+		}
+		return nil, err
+	}
+	att.ID = id
+
+	return s.toResponse(ctx, att)
+}
+
+func (s *service) DeleteAttachment(ctx context.Context, userID, attachmentID int64) error {
+	att, err := s.getOwned(ctx, userID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if att.UserID != userID {
+		return models.ErrAttachmentNotDeletable
+	}
+
+	if err = s.attachmentRepo.Delete(ctx, attachmentID); err != nil {
+		return err
+	}
+
+	// the row is gone so the file is unreachable; a failed delete only leaves an orphan behind
+	if err = s.store.Delete(ctx, att.StorageKey); err != nil {
+		slog.WarnContext(ctx, "Failed to delete attachment file", "storage_key", att.StorageKey, "error", err)
+	}
+
+	return nil
+}
+
+// OpenFile serves a file through a signed download URL handed out by the local store
+func (s *service) OpenFile(ctx context.Context, key string, expires int64, signature string) (*models.VisitAttachment, io.ReadCloser, error) {
+	if !storage.Verify(s.cfg.SigningKey, key, expires, signature, time.Now()) {
+		return nil, nil, models.ErrInvalidDownloadURL
+	}
+
+	att, err := s.attachmentRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.store.Get(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, nil, models.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return att, body, nil
+}
+
+// getOwned loads an attachment on one of the user's visits
+func (s *service) getOwned(ctx context.Context, userID, attachmentID int64) (*models.VisitAttachment, error) {
+	att, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// another caregiver's visit, don't reveal that the attachment exists
+	_, err = s.scheduleRepo.GetByID(ctx, att.ScheduleID, userID)
+	if errors.Is(err, models.ErrScheduleNotFound) {
+		return nil, models.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return att, nil
+}
+
+func (s *service) toResponse(ctx context.Context, att *models.VisitAttachment) (*models.AttachmentResponse, error) {
+	expiry := storage.URLExpiry(s.cfg)
+	url, err := s.store.SignedURL(ctx, att.StorageKey, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attachment url: %w", err)
+	}
+
+	resp := att.ToAttachmentResponse(url, time.Now().UTC().Add(expiry))
+	return &resp, nil
+}
+
+func (s *service) isAllowed(contentType string) bool {
+	for _, allowed := range s.cfg.AllowedMimeTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// newKey builds a random, unguessable storage key grouped by visit
+func newKey(scheduleID int64, contentType, fileName string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate attachment key: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 && !contains(exts, ext) {
+		ext = exts[0]
+	}
+
+	return fmt.Sprintf("schedules/%d/%s%s", scheduleID, hex.EncodeToString(random), ext), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package availability
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/availability"
+)
+
+type Service interface {
+	GetAvailability(ctx context.Context, userID int64) ([]models.AvailabilityResponse, error)
+	SetAvailability(ctx context.Context, userID int64, req *models.SetAvailabilityRequest) ([]models.AvailabilityResponse, error)
+	GetTimeOffs(ctx context.Context, userID int64) ([]models.TimeOffResponse, error)
+	RequestTimeOff(ctx context.Context, userID int64, req *models.CreateTimeOffRequest) (*models.TimeOffResponse, error)
+	ReviewTimeOff(ctx context.Context, reviewerID, timeOffID int64, approve bool, req *models.ReviewTimeOffRequest) (*models.TimeOffResponse, error)
+}
+
+type service struct {
+	availabilityRepo availability.Repository
+}
+
+func New(availabilityRepo availability.Repository) Service {
+	return &service{availabilityRepo: availabilityRepo}
+}
+
+func (s *service) GetAvailability(ctx context.Context, userID int64) ([]models.AvailabilityResponse, error) {
+	windows, err := s.availabilityRepo.GetAvailability(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.AvailabilityResponse, len(windows))
+	for i, w := range windows {
+		resp[i] = w.ToAvailabilityResponse()
+	}
+
+	return resp, nil
+}
+
+// SetAvailability replaces the user's weekly availability; an empty list means always available
+func (s *service) SetAvailability(ctx context.Context, userID int64, req *models.SetAvailabilityRequest) ([]models.AvailabilityResponse, error) {
+	windows := make([]models.Availability, len(req.Windows))
+	for i, w := range req.Windows {
+		if err := validateAvailabilityWindow(&w); err != nil {
+			return nil, err
+		}
+
+		windows[i] = models.Availability{
+			UserID:    userID,
+			DayOfWeek: w.DayOfWeek,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			Timezone:  w.Timezone,
+		}
+	}
+
+	if err := s.availabilityRepo.ReplaceAvailability(ctx, userID, windows); err != nil {
+		return nil, err
+	}
+
+	return s.GetAvailability(ctx, userID)
+}
+
+func (s *service) GetTimeOffs(ctx context.Context, userID int64) ([]models.TimeOffResponse, error) {
+	timeOffs, err := s.availabilityRepo.GetTimeOffs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.TimeOffResponse, len(timeOffs))
+	for i, t := range timeOffs {
+		resp[i] = t.ToTimeOffResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *service) RequestTimeOff(ctx context.Context, userID int64, req *models.CreateTimeOffRequest) (*models.TimeOffResponse, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, models.ErrInvalidTimeOffRange
+	}
+
+	timeOff := &models.TimeOff{
+		UserID:    userID,
+		StartTime: req.StartTime.UTC(),
+		EndTime:   req.EndTime.UTC(),
+		Reason: sql.NullString{
+			String: req.Reason,
+			Valid:  req.Reason != "",
+		},
+		Status: models.TimeOffStatusPending,
+	}
+
+	id, err := s.availabilityRepo.CreateTimeOff(ctx, timeOff)
+	if err != nil {
+		return nil, err
+	}
+	timeOff.ID = id
+
+	resp := timeOff.ToTimeOffResponse()
+	return &resp, nil
+}
+
+// ReviewTimeOff approves or rejects a pending time off request
+func (s *service) ReviewTimeOff(ctx context.Context, reviewerID, timeOffID int64, approve bool, req *models.ReviewTimeOffRequest) (*models.TimeOffResponse, error) {
+	timeOff, err := s.availabilityRepo.GetTimeOffByID(ctx, timeOffID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !timeOff.CanReview() {
+		return nil, models.ErrTimeOffAlreadyReviewed
+	}
+
+	timeOff.Status = models.TimeOffStatusRejected
+	if approve {
+		timeOff.Status = models.TimeOffStatusApproved
+	}
+	timeOff.ReviewedBy = sql.NullInt64{Int64: reviewerID, Valid: true}
+	timeOff.ReviewedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	timeOff.ReviewNote = sql.NullString{String: req.Note, Valid: req.Note != ""}
+
+	if err = s.availabilityRepo.UpdateTimeOffStatus(ctx, timeOff); err != nil {
+		return nil, err
+	}
+
+	resp := timeOff.ToTimeOffResponse()
+	return &resp, nil
+}
+
+func validateAvailabilityWindow(w *models.AvailabilityWindowRequest) error {
+	if w.Timezone == "" {
+		w.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return models.ErrInvalidAvailability
+	}
+
+	start, err := time.Parse(models.AvailabilityTimeLayout, w.StartTime)
+	if err != nil {
+		return models.ErrInvalidAvailability
+	}
+	end, err := time.Parse(models.AvailabilityTimeLayout, w.EndTime)
+	if err != nil {
+		return models.ErrInvalidAvailability
+	}
+	if !end.After(start) {
+		return models.ErrInvalidAvailability
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package conflict
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/availability"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+	"github.com/erizkiatama/bluehorntech/pkg/routing"
+)
+
+// Checker finds everything that prevents a caregiver from taking a visit
+type Checker interface {
+	Check(ctx context.Context, sch *models.Schedule) ([]models.Conflict, error)
+}
+
+type checker struct {
+	cfg              config.ServiceConfig
+	scheduleRepo     schedule.Repository
+	availabilityRepo availability.Repository
+	router           routing.Provider
+}
+
+func New(cfg config.ServiceConfig, scheduleRepo schedule.Repository, availabilityRepo availability.Repository, router routing.Provider) Checker {
+	return &checker{cfg: cfg, scheduleRepo: scheduleRepo, availabilityRepo: availabilityRepo, router: router}
+}
+
+// Check validates sch against sch.UserID's other visits, approved time off and availability windows.
+// sch.ID may be zero for a schedule that has not been created yet.
+func (c *checker) Check(ctx context.Context, sch *models.Schedule) ([]models.Conflict, error) {
+	dayStart, dayEnd := helpers.DayBounds(sch.StartTime, c.cfg.AgencyTimezone)
+
+	sameDay, err := c.scheduleRepo.GetByUserBetween(ctx, sch.UserID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	others := make([]models.Schedule, 0, len(sameDay))
+	for _, other := range sameDay {
+		if other.ID != sch.ID {
+			others = append(others, other)
+		}
+	}
+
+	timeOffs, err := c.availabilityRepo.GetTimeOffsBetween(ctx, sch.UserID, sch.StartTime, sch.EndTime, models.TimeOffStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := c.availabilityRepo.GetAvailability(ctx, sch.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.FromContext(ctx)
+
+	var conflicts []models.Conflict
+	conflicts = append(conflicts, checkOverlap(locale, sch, others)...)
+	conflicts = append(conflicts, checkTimeOff(locale, sch, timeOffs)...)
+	conflicts = append(conflicts, checkAvailability(locale, sch, windows)...)
+	conflicts = append(conflicts, c.checkDailyHours(locale, sch, others)...)
+
+	travelConflicts, err := c.checkTravelTime(ctx, sch, others)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, travelConflicts...)
+
+	return conflicts, nil
+}
+
+func checkOverlap(locale i18n.Locale, sch *models.Schedule, others []models.Schedule) []models.Conflict {
+	var conflicts []models.Conflict
+	for _, other := range others {
+		if other.Overlaps(sch.StartTime, sch.EndTime) {
+			conflicts = append(conflicts, models.Conflict{
+				Type: models.ConflictOverlap,
+				Message: locale.T(i18n.MsgConflictOverlap, i18n.Args{
+					"client": other.ClientName,
+					"start":  other.StartTime.Format(time.Kitchen),
+					"end":    other.EndTime.Format(time.Kitchen),
+				}),
+				ScheduleID: other.ID,
+			})
+		}
+	}
+	return conflicts
+}
+
+func checkTimeOff(locale i18n.Locale, sch *models.Schedule, timeOffs []models.TimeOff) []models.Conflict {
+	var conflicts []models.Conflict
+	for _, timeOff := range timeOffs {
+		if timeOff.Overlaps(sch.StartTime, sch.EndTime) {
+			conflicts = append(conflicts, models.Conflict{
+				Type: models.ConflictTimeOff,
+				Message: locale.T(i18n.MsgConflictTimeOff, i18n.Args{
+					"start": timeOff.StartTime.Format(time.RFC3339),
+					"end":   timeOff.EndTime.Format(time.RFC3339),
+				}),
+				TimeOffID: timeOff.ID,
+			})
+		}
+	}
+	return conflicts
+}
+
+// checkAvailability only applies when the caregiver has declared availability windows
+func checkAvailability(locale i18n.Locale, sch *models.Schedule, windows []models.Availability) []models.Conflict {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	for _, w := range windows {
+		if w.Covers(sch.StartTime, sch.EndTime) {
+			return nil
+		}
+	}
+
+	return []models.Conflict{{
+		Type:    models.ConflictUnavailable,
+		Message: locale.T(i18n.MsgConflictUnavailable, nil),
+	}}
+}
+
+func (c *checker) checkDailyHours(locale i18n.Locale, sch *models.Schedule, others []models.Schedule) []models.Conflict {
+	if c.cfg.MaxDailyVisitSeconds <= 0 {
+		return nil
+	}
+
+	total := sch.Duration()
+	for _, other := range others {
+		total += other.Duration()
+	}
+
+	limit := c.cfg.MaxDailyVisitSeconds * time.Second
+	if total <= limit {
+		return nil
+	}
+
+	return []models.Conflict{{
+		Type: models.ConflictMaxDailyHours,
+		Message: locale.T(i18n.MsgConflictMaxDailyHours, i18n.Args{
+			"total": locale.Duration(total),
+			"limit": locale.Duration(limit),
+		}),
+	}}
+}
+
+// checkTravelTime verifies the caregiver can reach this visit from the previous one and the next one from this
+func (c *checker) checkTravelTime(ctx context.Context, sch *models.Schedule, others []models.Schedule) ([]models.Conflict, error) {
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].StartTime.Before(others[j].StartTime)
+	})
+
+	var prev, next *models.Schedule
+	for i := range others {
+		other := &others[i]
+		if !other.EndTime.After(sch.StartTime) {
+			prev = other
+		}
+		if next == nil && !other.StartTime.Before(sch.EndTime) {
+			next = other
+		}
+	}
+
+	var conflicts []models.Conflict
+	if prev != nil {
+		conflict, ok, err := c.travelConflict(ctx, prev, sch, prev.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	if next != nil {
+		conflict, ok, err := c.travelConflict(ctx, sch, next, next.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts, nil
+}
+
+// travelConflict reports when the trip from one visit to the next does not fit in the gap between them.
+// otherID is the visit the candidate conflicts with.
+func (c *checker) travelConflict(ctx context.Context, from, to *models.Schedule, otherID int64) (models.Conflict, bool, error) {
+	estimate, err := c.router.Estimate(ctx, from.Point(), to.Point())
+	if err != nil {
+		return models.Conflict{}, false, fmt.Errorf("failed to estimate travel time: %w", err)
+	}
+
+	gap := to.StartTime.Sub(from.EndTime)
+	if estimate.Duration <= gap {
+		return models.Conflict{}, false, nil
+	}
+
+	locale := i18n.FromContext(ctx)
+	return models.Conflict{
+		Type: models.ConflictTravelTime,
+		Message: locale.T(i18n.MsgConflictTravelTime, i18n.Args{
+			"distance": locale.Kilometers(estimate.DistanceMeters),
+			"travel":   locale.Duration(estimate.Duration),
+			"gap":      locale.Duration(gap),
+		}),
+		ScheduleID: otherID,
+	}, true, nil
+}
@@ -0,0 +1,191 @@
+package credential
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/credential"
+)
+
+type Service interface {
+	GetTypes(ctx context.Context) ([]models.CredentialTypeResponse, error)
+	CreateType(ctx context.Context, req *models.CreateCredentialTypeRequest) (*models.CredentialTypeResponse, error)
+	GetUserCredentials(ctx context.Context, userID int64) ([]models.UserCredentialResponse, error)
+	AddUserCredential(ctx context.Context, userID int64, req *models.CreateUserCredentialRequest) (*models.UserCredentialResponse, error)
+	RemoveUserCredential(ctx context.Context, userID, credentialID int64) error
+	GetRequirements(ctx context.Context, serviceName string) (*models.CredentialRequirementsResponse, error)
+	SetRequirements(ctx context.Context, serviceName string, req *models.SetCredentialRequirementsRequest) (*models.CredentialRequirementsResponse, error)
+	GetExpiring(ctx context.Context, days int) ([]models.UserCredentialResponse, error)
+}
+
+type service struct {
+	credentialRepo credential.Repository
+}
+
+func New(credentialRepo credential.Repository) Service {
+	return &service{credentialRepo: credentialRepo}
+}
+
+func (s *service) GetTypes(ctx context.Context) ([]models.CredentialTypeResponse, error) {
+	types, err := s.credentialRepo.GetTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.CredentialTypeResponse, len(types))
+	for i, t := range types {
+		resp[i] = t.ToCredentialTypeResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *service) CreateType(ctx context.Context, req *models.CreateCredentialTypeRequest) (*models.CredentialTypeResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	_, err := s.credentialRepo.GetTypeByCode(ctx, code)
+	if err == nil {
+		return nil, models.ErrCredentialTypeExists
+	}
+	if !errors.Is(err, models.ErrCredentialTypeNotFound) {
+		return nil, err
+	}
+
+	credentialType := &models.CredentialType{
+		Code: code,
+		Name: req.Name,
+		Description: sql.NullString{
+			String: req.Description,
+			Valid:  req.Description != "",
+		},
+	}
+
+	id, err := s.credentialRepo.CreateType(ctx, credentialType)
+	if err != nil {
+		return nil, err
+	}
+	credentialType.ID = id
+
+	resp := credentialType.ToCredentialTypeResponse()
+	return &resp, nil
+}
+
+func (s *service) GetUserCredentials(ctx context.Context, userID int64) ([]models.UserCredentialResponse, error) {
+	credentials, err := s.credentialRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserCredentialResponses(credentials), nil
+}
+
+func (s *service) AddUserCredential(ctx context.Context, userID int64, req *models.CreateUserCredentialRequest) (*models.UserCredentialResponse, error) {
+	credentialType, err := s.credentialRepo.GetTypeByID(ctx, req.CredentialTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt, err := time.Parse(models.CredentialDateLayout, req.IssuedAt)
+	if err != nil {
+		return nil, models.ErrInvalidCredentialDates
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != "" {
+		t, err := time.Parse(models.CredentialDateLayout, req.ExpiresAt)
+		if err != nil || t.Before(issuedAt) {
+			return nil, models.ErrInvalidCredentialDates
+		}
+		expiresAt = sql.NullTime{Time: t, Valid: true}
+	}
+
+	cred := &models.UserCredential{
+		UserID:           userID,
+		CredentialTypeID: credentialType.ID,
+		CredentialNumber: sql.NullString{String: req.CredentialNumber, Valid: req.CredentialNumber != ""},
+		IssuedAt:         issuedAt,
+		ExpiresAt:        expiresAt,
+		DocumentRef:      sql.NullString{String: req.DocumentRef, Valid: req.DocumentRef != ""},
+		TypeCode:         credentialType.Code,
+		TypeName:         credentialType.Name,
+	}
+
+	id, err := s.credentialRepo.Create(ctx, cred)
+	if err != nil {
+		return nil, err
+	}
+	cred.ID = id
+
+	resp := cred.ToUserCredentialResponse(time.Now().UTC())
+	return &resp, nil
+}
+
+func (s *service) RemoveUserCredential(ctx context.Context, userID, credentialID int64) error {
+	deleted, err := s.credentialRepo.Delete(ctx, userID, credentialID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return models.ErrCredentialNotFound
+	}
+
+	return nil
+}
+
+func (s *service) GetRequirements(ctx context.Context, serviceName string) (*models.CredentialRequirementsResponse, error) {
+	types, err := s.credentialRepo.GetRequiredTypes(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.CredentialRequirementsResponse{
+		ServiceName:     serviceName,
+		CredentialTypes: make([]models.CredentialTypeResponse, len(types)),
+	}
+	for i, t := range types {
+		resp.CredentialTypes[i] = t.ToCredentialTypeResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *service) SetRequirements(ctx context.Context, serviceName string, req *models.SetCredentialRequirementsRequest) (*models.CredentialRequirementsResponse, error) {
+	for _, typeID := range req.CredentialTypeIDs {
+		if _, err := s.credentialRepo.GetTypeByID(ctx, typeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.credentialRepo.ReplaceRequiredTypes(ctx, serviceName, req.CredentialTypeIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetRequirements(ctx, serviceName)
+}
+
+// GetExpiring lists credentials expiring within the next days days, including today
+func (s *service) GetExpiring(ctx context.Context, days int) ([]models.UserCredentialResponse, error) {
+	if days < 1 || days > 365 {
+		return nil, models.ErrInvalidCredentialWindow
+	}
+
+	now := time.Now().UTC()
+	credentials, err := s.credentialRepo.GetExpiring(ctx, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserCredentialResponses(credentials), nil
+}
+
+func toUserCredentialResponses(credentials []models.UserCredential) []models.UserCredentialResponse {
+	now := time.Now().UTC()
+	resp := make([]models.UserCredentialResponse, len(credentials))
+	for i, c := range credentials {
+		resp[i] = c.ToUserCredentialResponse(now)
+	}
+	return resp
+}
@@ -0,0 +1,315 @@
+package escalation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/logging"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/escalation"
+	"github.com/erizkiatama/bluehorntech/internal/repository/notification"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+	"github.com/erizkiatama/bluehorntech/pkg/notify"
+)
+
+const (
+	defaultPollInterval = time.Minute
+	defaultRiskLevel    = "standard"
+
+	// visits are only escalated while they started within this long ago
+	escalationLookback = 24 * time.Hour
+	maxErrorLength     = 500
+
+	timeLayout = "15:04"
+)
+
+// alertChannels are tried in this order; an alert goes out on every one the recipient can receive
+var alertChannels = []string{models.ChannelPush, models.ChannelSMS, models.ChannelEmail}
+
+type step struct {
+	contact     string
+	recipientID int64
+	after       time.Duration
+}
+
+// Escalator alerts the configured contacts, one policy step at a time, about visits nobody has clocked into.
+// Escalation stops once the visit is clocked into or any of its alerts is acknowledged.
+type Escalator struct {
+	escalationRepo   escalation.Repository
+	userRepo         user.Repository
+	notificationRepo notification.Repository
+	channels         map[string]notify.Channel
+	policies         map[string][]step
+	defaultRiskLevel string
+	agencyLocation   *time.Location
+	defaultLocale    i18n.Locale
+	pollInterval     time.Duration
+}
+
+func NewEscalator(cfg config.EscalationConfig, agencyTimezone, defaultLocale string, escalationRepo escalation.Repository,
+	userRepo user.Repository, notificationRepo notification.Repository, channels map[string]notify.Channel) *Escalator {
+	loc, err := time.LoadLocation(agencyTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	locale, ok := i18n.Parse(defaultLocale)
+	if !ok {
+		locale = i18n.Default
+	}
+
+	e := &Escalator{
+		escalationRepo:   escalationRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		channels:         channels,
+		policies:         policies(cfg, knownRecipients(userRepo, cfg)),
+		defaultRiskLevel: normalizeRiskLevel(cfg.DefaultRiskLevel),
+		agencyLocation:   loc,
+		defaultLocale:    locale,
+		pollInterval:     secondsOr(cfg.PollIntervalSeconds, defaultPollInterval),
+	}
+	if e.defaultRiskLevel == "" {
+		e.defaultRiskLevel = defaultRiskLevel
+	}
+
+	return e
+}
+
+// knownRecipients maps each contact to its configured user, leaving out users that do not exist
+// so their alerts are not refused by the database on every run. When the users cannot be checked
+// the contacts are kept as configured.
+func knownRecipients(userRepo user.Repository, cfg config.EscalationConfig) map[string]int64 {
+	configured := map[string]int64{
+		models.EscalationContactOnCall: cfg.OnCallUserID,
+		models.EscalationContactBackup: cfg.BackupUserID,
+		models.EscalationContactAdmin:  cfg.AdminUserID,
+	}
+
+	recipients := make(map[string]int64, len(configured))
+	for contact, userID := range configured {
+		if userID <= 0 {
+			continue
+		}
+		_, err := userRepo.GetByID(context.Background(), userID)
+		if errors.Is(err, models.ErrUserNotFound) {
+			slog.Warn("Escalation contact is not an existing user", "contact", contact, "user_id", userID)
+			continue
+		}
+		if err != nil {
+			slog.Warn("Failed to check escalation contact", "contact", contact, "user_id", userID, "error", err)
+		}
+		recipients[contact] = userID
+	}
+
+	return recipients
+}
+
+// policies orders each policy's steps by when they are due.
+// Steps whose contact has no user are left out.
+func policies(cfg config.EscalationConfig, recipients map[string]int64) map[string][]step {
+	resolved := make(map[string][]step, len(cfg.Policies))
+	for name, policy := range cfg.Policies {
+		name = normalizeRiskLevel(name)
+		steps := make([]step, 0, len(policy.Steps))
+		for _, s := range policy.Steps {
+			contact := strings.ToLower(strings.TrimSpace(s.Contact))
+			if !models.IsValidEscalationContact(contact) || recipients[contact] <= 0 {
+				slog.Warn("Skipping escalation step, no user is configured for its contact", "policy", name, "contact", s.Contact)
+				continue
+			}
+			steps = append(steps, step{contact: contact, recipientID: recipients[contact], after: s.AfterSeconds * time.Second})
+		}
+		sort.SliceStable(steps, func(i, j int) bool { return steps[i].after < steps[j].after })
+		resolved[name] = steps
+	}
+
+	return resolved
+}
+
+func secondsOr(seconds, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return seconds * time.Second
+}
+
+// Run escalates overdue visits every poll interval until ctx is cancelled
+func (e *Escalator) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		e.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends every escalation step that has come due since the last run
+func (e *Escalator) RunOnce(ctx context.Context) {
+	now := time.Now().UTC()
+
+	visits, err := e.escalationRepo.GetOverdueVisits(ctx, now.Add(-escalationLookback), now, e.defaultRiskLevel)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get overdue visits", "error", err)
+		return
+	}
+
+	for i := range visits {
+		e.escalate(ctx, &visits[i], now)
+	}
+}
+
+// escalate raises the visit's next steps that are due; several can be due at once after downtime
+func (e *Escalator) escalate(ctx context.Context, v *models.OverdueVisit, now time.Time) {
+	ctx = logging.WithScheduleID(ctx, v.ScheduleID)
+	steps, ok := e.policies[v.RiskLevel]
+	if !ok {
+		steps = e.policies[e.defaultRiskLevel]
+	}
+
+	late := now.Sub(v.StartTime)
+	for i := v.AlertsSent; i < len(steps) && late >= steps[i].after; i++ {
+		alert := &models.VisitAlert{
+			ScheduleID:  v.ScheduleID,
+			Step:        i + 1,
+			Contact:     steps[i].contact,
+			RecipientID: steps[i].recipientID,
+			RiskLevel:   v.RiskLevel,
+			MinutesLate: int64(late.Minutes()),
+			SentAt:      now,
+		}
+
+		created, err := e.escalationRepo.CreateAlert(ctx, alert)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create alert", "error", err)
+			return
+		}
+		if !created {
+			continue
+		}
+
+		e.send(ctx, v, alert, now)
+		// recorded even when ctx is cancelled mid-send, so the delivery is not lost
+		if err = e.escalationRepo.RecordDelivery(context.WithoutCancel(ctx), alert); err != nil {
+			slog.ErrorContext(ctx, "Failed to record alert delivery", "alert_id", alert.ID, "error", err)
+		}
+		slog.WarnContext(ctx, "Visit is late, alerted escalation contact", "alert_id", alert.ID, "minutes_late", alert.MinutesLate,
+			"contact", alert.Contact, "recipient_id", alert.RecipientID, "channels", []string(alert.DeliveredChannels))
+	}
+}
+
+// send delivers the alert on every channel the recipient has enabled. Alerts are urgent,
+// so unlike reminders they ignore quiet hours and are not retried; the next step escalates instead.
+func (e *Escalator) send(ctx context.Context, v *models.OverdueVisit, alert *models.VisitAlert, now time.Time) {
+	recipient, err := e.userRepo.GetByID(ctx, alert.RecipientID)
+	if err != nil {
+		alert.DeliveryError = errorText(err.Error())
+		return
+	}
+	prefs, err := e.notificationRepo.GetPreferences(ctx, alert.RecipientID)
+	if err != nil {
+		alert.DeliveryError = errorText(err.Error())
+		return
+	}
+
+	title, body := e.render(v, alert, recipient, prefs)
+	var failures []string
+	for _, name := range alertChannels {
+		channel, ok := e.channels[name]
+		address := address(name, recipient, prefs)
+		if !ok || address == "" {
+			continue
+		}
+
+		err = channel.Send(ctx, notify.Message{
+			To:    address,
+			Title: title,
+			Body:  body,
+			Data: map[string]string{
+				"kind":        "visit_late",
+				"alert_id":    strconv.FormatInt(alert.ID, 10),
+				"schedule_id": strconv.FormatInt(v.ScheduleID, 10),
+			},
+		})
+		if err != nil {
+			failures = append(failures, name+": "+err.Error())
+			continue
+		}
+		alert.DeliveredChannels = append(alert.DeliveredChannels, name)
+	}
+
+	switch {
+	case len(failures) > 0:
+		alert.DeliveryError = errorText(strings.Join(failures, "; "))
+	case len(alert.DeliveredChannels) == 0:
+		alert.DeliveryError = errorText("recipient has no enabled channel with an address")
+	}
+}
+
+// render writes the alert in the recipient's language, with times in their timezone
+func (e *Escalator) render(v *models.OverdueVisit, alert *models.VisitAlert, recipient *models.User, prefs *models.NotificationPreferences) (string, string) {
+	locale, ok := i18n.Parse(recipient.Locale.String)
+	if !ok {
+		locale = e.defaultLocale
+	}
+	loc := e.agencyLocation
+	if prefs.Timezone.Valid {
+		if l, err := time.LoadLocation(prefs.Timezone.String); err == nil {
+			loc = l
+		}
+	}
+
+	args := i18n.Args{
+		"id":        strconv.FormatInt(alert.ID, 10),
+		"caregiver": v.CaregiverName.String,
+		"client":    v.ClientName,
+		"service":   v.ServiceName,
+		"location":  v.Location,
+		"risk":      v.RiskLevel,
+		"time":      v.StartTime.In(loc).Format(timeLayout),
+		"minutes":   strconv.FormatInt(alert.MinutesLate, 10),
+	}
+
+	if !v.UserID.Valid {
+		return locale.T(i18n.MsgAlertVisitLateTitle, args), locale.T(i18n.MsgAlertVisitUnassignedBody, args)
+	}
+	return locale.T(i18n.MsgAlertVisitLateTitle, args), locale.T(i18n.MsgAlertVisitLateBody, args)
+}
+
+func address(channel string, recipient *models.User, prefs *models.NotificationPreferences) string {
+	switch channel {
+	case models.ChannelPush:
+		if prefs.PushEnabled {
+			return prefs.PushToken.String
+		}
+	case models.ChannelSMS:
+		if prefs.SMSEnabled {
+			return recipient.Phone.String
+		}
+	case models.ChannelEmail:
+		if prefs.EmailEnabled {
+			return recipient.Email
+		}
+	}
+	return ""
+}
+
+func errorText(text string) sql.NullString {
+	if len(text) > maxErrorLength {
+		text = text[:maxErrorLength]
+	}
+	return sql.NullString{String: text, Valid: true}
+}
@@ -0,0 +1,100 @@
+package escalation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/escalation"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+)
+
+type Service interface {
+	GetAlerts(ctx context.Context, scheduleID int64) ([]models.VisitAlertResponse, error)
+	AcknowledgeAlert(ctx context.Context, userID, alertID int64, req *models.AcknowledgeAlertRequest) (*models.VisitAlertResponse, error)
+	GetRiskLevels(ctx context.Context) ([]models.ClientRiskLevel, error)
+	SetRiskLevel(ctx context.Context, req *models.SetClientRiskLevelRequest) (*models.ClientRiskLevel, error)
+}
+
+type service struct {
+	cfg            config.EscalationConfig
+	escalationRepo escalation.Repository
+	scheduleRepo   schedule.Repository
+}
+
+func New(cfg config.EscalationConfig, escalationRepo escalation.Repository, scheduleRepo schedule.Repository) Service {
+	return &service{cfg: cfg, escalationRepo: escalationRepo, scheduleRepo: scheduleRepo}
+}
+
+// GetAlerts returns every alert raised for the visit, in escalation order
+func (s *service) GetAlerts(ctx context.Context, scheduleID int64) ([]models.VisitAlertResponse, error) {
+	if _, err := s.scheduleRepo.Get(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.escalationRepo.GetAlertsBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.VisitAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		resp = append(resp, alert.ToVisitAlertResponse())
+	}
+	return resp, nil
+}
+
+// AcknowledgeAlert records who took charge of the late visit, which stops any further escalation for it
+func (s *service) AcknowledgeAlert(ctx context.Context, userID, alertID int64, req *models.AcknowledgeAlertRequest) (*models.VisitAlertResponse, error) {
+	alert, err := s.escalationRepo.GetAlert(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert.IsAcknowledged() {
+		return nil, models.ErrAlertAlreadyAcknowledged
+	}
+
+	if err = s.escalationRepo.Acknowledge(ctx, alertID, userID, strings.TrimSpace(req.Note), time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	alert, err = s.escalationRepo.GetAlert(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := alert.ToVisitAlertResponse()
+	return &resp, nil
+}
+
+func (s *service) GetRiskLevels(ctx context.Context) ([]models.ClientRiskLevel, error) {
+	levels, err := s.escalationRepo.GetRiskLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if levels == nil {
+		levels = []models.ClientRiskLevel{}
+	}
+	return levels, nil
+}
+
+// SetRiskLevel assigns the client one of the configured escalation policies
+func (s *service) SetRiskLevel(ctx context.Context, req *models.SetClientRiskLevelRequest) (*models.ClientRiskLevel, error) {
+	clientName := strings.TrimSpace(req.ClientName)
+	riskLevel := normalizeRiskLevel(req.RiskLevel)
+	if clientName == "" {
+		return nil, models.ErrInvalidClientName
+	}
+	if _, ok := s.cfg.Policies[riskLevel]; !ok {
+		return nil, models.ErrInvalidRiskLevel
+	}
+
+	return s.escalationRepo.SetRiskLevel(ctx, clientName, riskLevel)
+}
+
+// normalizeRiskLevel matches the config loader, which lowercases the policy names
+func normalizeRiskLevel(level string) string {
+	return strings.ToLower(strings.TrimSpace(level))
+}
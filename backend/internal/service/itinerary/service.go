@@ -0,0 +1,221 @@
+package itinerary
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+	"github.com/erizkiatama/bluehorntech/pkg/routing"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	milesPerKm  = 0.621371
+	hoursPerDay = 24
+)
+
+type Service interface {
+	GetItinerary(ctx context.Context, userID int64, date string) (*models.ItineraryResponse, error)
+	GetMileageReport(ctx context.Context, userID int64, date string) (*models.MileageReportResponse, error)
+}
+
+type service struct {
+	cfg          config.ServiceConfig
+	scheduleRepo schedule.Repository
+	router       routing.Provider
+}
+
+func New(cfg config.ServiceConfig, scheduleRepo schedule.Repository, router routing.Provider) Service {
+	return &service{cfg: cfg, scheduleRepo: scheduleRepo, router: router}
+}
+
+// GetItinerary orders the caregiver's visits for a day and estimates the travel between each pair
+func (s *service) GetItinerary(ctx context.Context, userID int64, date string) (*models.ItineraryResponse, error) {
+	day, err := s.parseDate(date)
+	if err != nil {
+		return nil, err
+	}
+	dayStart, dayEnd := helpers.DayBounds(day, s.cfg.AgencyTimezone)
+
+	schedules, err := s.scheduleRepo.GetByUserBetween(ctx, userID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	legs, err := s.buildLegs(ctx, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.ItineraryResponse{
+		UserID:   userID,
+		Date:     dayStart.Format(dateLayout),
+		Provider: s.router.Name(),
+		Visits:   make([]models.ScheduleResponse, len(schedules)),
+		Legs:     legs,
+	}
+	for i, sch := range schedules {
+		resp.Visits[i] = sch.ToScheduleResponse()
+	}
+	for _, leg := range legs {
+		resp.TotalDistanceKm += leg.DistanceKm
+		resp.TotalTravelMinutes += leg.TravelMinutes
+		if !leg.Feasible {
+			resp.HasInfeasibleLegs = true
+		}
+	}
+	resp.TotalDistanceKm = round(resp.TotalDistanceKm)
+	resp.TotalTravelMinutes = round(resp.TotalTravelMinutes)
+
+	return resp, nil
+}
+
+// GetMileageReport totals the distance driven between completed visits over the pay period containing date
+func (s *service) GetMileageReport(ctx context.Context, userID int64, date string) (*models.MileageReportResponse, error) {
+	day, err := s.parseDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	periodStart, periodEnd, err := s.payPeriod(day)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.scheduleRepo.GetByUserBetween(ctx, userID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.MileageReportResponse{
+		UserID:      userID,
+		PeriodStart: periodStart.Format(dateLayout),
+		PeriodEnd:   periodEnd.AddDate(0, 0, -1).Format(dateLayout),
+		Days:        []models.MileageDayResponse{},
+		RatePerMile: s.cfg.MileageRatePerMile,
+	}
+
+	for dayStart := periodStart; dayStart.Before(periodEnd); dayStart = dayStart.AddDate(0, 0, 1) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		var completed []models.Schedule
+		for _, sch := range schedules {
+			if sch.Status == models.StatusCompleted && !sch.StartTime.Before(dayStart) && sch.StartTime.Before(dayEnd) {
+				completed = append(completed, sch)
+			}
+		}
+		if len(completed) < 2 {
+			continue
+		}
+
+		legs, err := s.buildLegs(ctx, completed)
+		if err != nil {
+			return nil, err
+		}
+
+		var km float64
+		for _, leg := range legs {
+			km += leg.DistanceKm
+		}
+
+		resp.Days = append(resp.Days, models.MileageDayResponse{
+			Date:       dayStart.Format(dateLayout),
+			Legs:       len(legs),
+			DistanceKm: round(km),
+			Miles:      round(km * milesPerKm),
+		})
+		resp.TotalDistanceKm += km
+	}
+
+	resp.TotalMiles = round(resp.TotalDistanceKm * milesPerKm)
+	resp.TotalDistanceKm = round(resp.TotalDistanceKm)
+	resp.Reimbursement = math.Round(resp.TotalMiles*s.cfg.MileageRatePerMile*100) / 100
+
+	return resp, nil
+}
+
+// buildLegs estimates travel between consecutive visits, which must already be sorted by start time.
+// A leg is infeasible when the travel time is longer than the gap between one visit's end and the next's start.
+func (s *service) buildLegs(ctx context.Context, schedules []models.Schedule) ([]models.ItineraryLegResponse, error) {
+	legs := []models.ItineraryLegResponse{}
+	for i := 1; i < len(schedules); i++ {
+		from, to := &schedules[i-1], &schedules[i]
+
+		estimate, err := s.router.Estimate(ctx, from.Point(), to.Point())
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate travel from schedule %d to %d: %w", from.ID, to.ID, err)
+		}
+
+		gap := to.StartTime.Sub(from.EndTime)
+		legs = append(legs, models.ItineraryLegResponse{
+			FromScheduleID: from.ID,
+			ToScheduleID:   to.ID,
+			DistanceKm:     round(estimate.DistanceMeters / 1000),
+			TravelMinutes:  round(estimate.Duration.Minutes()),
+			GapMinutes:     round(gap.Minutes()),
+			Feasible:       estimate.Duration <= gap,
+		})
+	}
+
+	return legs, nil
+}
+
+// parseDate reads a YYYY-MM-DD date in the agency timezone, defaulting to today
+func (s *service) parseDate(date string) (time.Time, error) {
+	loc := s.location()
+	if date == "" {
+		return time.Now().In(loc), nil
+	}
+
+	day, err := time.ParseInLocation(dateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, models.ErrInvalidDate
+	}
+
+	return day, nil
+}
+
+// payPeriod returns [start, end) of the pay period containing day, counted in whole periods from the anchor date
+func (s *service) payPeriod(day time.Time) (time.Time, time.Time, error) {
+	if s.cfg.PayPeriodDays <= 0 {
+		return time.Time{}, time.Time{}, models.ErrInvalidPayPeriod
+	}
+
+	loc := s.location()
+	anchor, err := time.ParseInLocation(dateLayout, s.cfg.PayPeriodAnchor, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, models.ErrInvalidPayPeriod
+	}
+
+	dayStart, _ := helpers.DayBounds(day, s.cfg.AgencyTimezone)
+	elapsedDays := calendarDays(anchor, dayStart)
+	periods := int(math.Floor(float64(elapsedDays) / float64(s.cfg.PayPeriodDays)))
+
+	start := anchor.AddDate(0, 0, periods*s.cfg.PayPeriodDays)
+	return start, start.AddDate(0, 0, s.cfg.PayPeriodDays), nil
+}
+
+// calendarDays counts the dates from start to end; both are moved to UTC midnight first so a DST
+// change in between does not make a day 23 or 25 hours long
+func calendarDays(start, end time.Time) int {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / hoursPerDay)
+}
+
+func (s *service) location() *time.Location {
+	loc, err := time.LoadLocation(s.cfg.AgencyTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,348 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+	"github.com/erizkiatama/bluehorntech/internal/repository/skill"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+	"github.com/erizkiatama/bluehorntech/internal/service/conflict"
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+)
+
+// continuityVisitTarget is the number of past visits with a client that earns the full continuity score
+const continuityVisitTarget = 5
+
+type Service interface {
+	GetMatches(ctx context.Context, scheduleID int64) (*models.ScheduleMatchResponse, error)
+	GetUserSkills(ctx context.Context, userID int64) (*models.UserSkillsResponse, error)
+	SetUserSkills(ctx context.Context, userID int64, req *models.SetSkillsRequest) (*models.UserSkillsResponse, error)
+	GetServiceRequirements(ctx context.Context, serviceName string) (*models.ServiceRequirementsResponse, error)
+	SetServiceRequirements(ctx context.Context, serviceName string, req *models.SetSkillsRequest) (*models.ServiceRequirementsResponse, error)
+}
+
+type service struct {
+	cfg             config.ServiceConfig
+	scheduleRepo    schedule.Repository
+	userRepo        user.Repository
+	skillRepo       skill.Repository
+	conflictChecker conflict.Checker
+}
+
+func New(
+	cfg config.ServiceConfig,
+	scheduleRepo schedule.Repository,
+	userRepo user.Repository,
+	skillRepo skill.Repository,
+	conflictChecker conflict.Checker,
+) Service {
+	return &service{
+		cfg:             cfg,
+		scheduleRepo:    scheduleRepo,
+		userRepo:        userRepo,
+		skillRepo:       skillRepo,
+		conflictChecker: conflictChecker,
+	}
+}
+
+// GetMatches ranks every caregiver other than the current assignee for a visit that has not started yet.
+// Caregivers with missing skills or scheduling conflicts are returned as ineligible after the eligible ones.
+func (s *service) GetMatches(ctx context.Context, scheduleID int64) (*models.ScheduleMatchResponse, error) {
+	sch, err := s.scheduleRepo.Get(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sch.CanStart() {
+		return nil, models.ErrScheduleNotEditable
+	}
+
+	required, err := s.skillRepo.GetRequired(ctx, sch.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allSkills, err := s.skillRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skillsByUser := models.SkillsByUser(allSkills)
+
+	visitCounts, err := s.scheduleRepo.CountCompletedByClient(ctx, sch.ClientName)
+	if err != nil {
+		return nil, err
+	}
+	visitsByUser := make(map[int64]int64, len(visitCounts))
+	for _, vc := range visitCounts {
+		visitsByUser[vc.UserID] = vc.Count
+	}
+
+	candidates := make([]models.CaregiverMatchResponse, 0, len(users))
+	for _, u := range users {
+		if u.ID == sch.UserID {
+			continue
+		}
+
+		match, err := s.scoreCaregiver(ctx, sch, u, required, skillsByUser[u.ID], visitsByUser[u.ID])
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, *match)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Eligible != candidates[j].Eligible {
+			return candidates[i].Eligible
+		}
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return &models.ScheduleMatchResponse{
+		ScheduleID:     sch.ID,
+		RequiredSkills: required,
+		Candidates:     candidates,
+	}, nil
+}
+
+func (s *service) scoreCaregiver(
+	ctx context.Context,
+	sch *models.Schedule,
+	u models.User,
+	required []string,
+	skills map[string]bool,
+	pastVisits int64,
+) (*models.CaregiverMatchResponse, error) {
+	candidate := *sch
+	candidate.UserID = u.ID
+
+	conflicts, err := s.conflictChecker.Check(ctx, &candidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check conflicts for user %d: %w", u.ID, err)
+	}
+
+	dayStart, dayEnd := helpers.DayBounds(sch.StartTime, s.cfg.AgencyTimezone)
+	sameDay, err := s.scheduleRepo.GetByUserBetween(ctx, u.ID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.FromContext(ctx)
+	skillFactor, hasSkills := scoreSkills(locale, sch, required, skills)
+	factors := []models.MatchFactorResponse{
+		skillFactor,
+		scoreAvailability(locale, conflicts),
+		s.scoreLoad(locale, sch, sameDay),
+		s.scoreDistance(locale, sch, sameDay),
+		scoreContinuity(locale, sch, pastVisits),
+	}
+
+	var total float64
+	for _, f := range factors {
+		total += f.Score
+	}
+
+	return &models.CaregiverMatchResponse{
+		UserID:    u.ID,
+		Name:      u.Name,
+		Eligible:  hasSkills && len(conflicts) == 0,
+		Score:     round(total),
+		Factors:   factors,
+		Conflicts: conflicts,
+	}, nil
+}
+
+func scoreSkills(locale i18n.Locale, sch *models.Schedule, required []string, skills map[string]bool) (models.MatchFactorResponse, bool) {
+	if len(required) == 0 {
+		return models.MatchFactorResponse{
+			Factor: models.MatchFactorSkills,
+			Score:  models.MatchWeightSkills,
+			Reason: locale.T(i18n.MsgMatchNoSkillsRequired, i18n.Args{"service": sch.ServiceName}),
+		}, true
+	}
+
+	var missing []string
+	for _, r := range required {
+		if !skills[r] {
+			missing = append(missing, r)
+		}
+	}
+
+	if len(missing) > 0 {
+		return models.MatchFactorResponse{
+			Factor: models.MatchFactorSkills,
+			Score:  0,
+			Reason: locale.T(i18n.MsgMatchMissingSkills, i18n.Args{"skills": strings.Join(missing, ", ")}),
+		}, false
+	}
+
+	return models.MatchFactorResponse{
+		Factor: models.MatchFactorSkills,
+		Score:  models.MatchWeightSkills,
+		Reason: locale.T(i18n.MsgMatchHasSkills, i18n.Args{"skills": strings.Join(required, ", ")}),
+	}, true
+}
+
+// scoreAvailability does not add points; conflicts make the caregiver ineligible instead
+func scoreAvailability(locale i18n.Locale, conflicts []models.Conflict) models.MatchFactorResponse {
+	reason := locale.T(i18n.MsgMatchAvailable, nil)
+	if len(conflicts) > 0 {
+		reason = locale.T(i18n.MsgMatchConflicts, i18n.Args{"count": locale.Integer(int64(len(conflicts)))})
+	}
+
+	return models.MatchFactorResponse{
+		Factor: models.MatchFactorAvailability,
+		Score:  0,
+		Reason: reason,
+	}
+}
+
+// scoreLoad favours caregivers with fewer hours already booked that day
+func (s *service) scoreLoad(locale i18n.Locale, sch *models.Schedule, sameDay []models.Schedule) models.MatchFactorResponse {
+	var booked time.Duration
+	for _, other := range sameDay {
+		if other.ID != sch.ID {
+			booked += other.Duration()
+		}
+	}
+
+	limit := s.cfg.MaxDailyVisitSeconds * time.Second
+	if limit <= 0 {
+		limit = 8 * time.Hour
+	}
+	ratio := math.Min(1, float64(booked)/float64(limit))
+
+	return models.MatchFactorResponse{
+		Factor: models.MatchFactorLoad,
+		Score:  round(models.MatchWeightLoad * (1 - ratio)),
+		Reason: locale.T(i18n.MsgMatchLoad, i18n.Args{"booked": locale.Duration(booked)}),
+	}
+}
+
+// scoreDistance favours caregivers whose previous visit that day is close to this visit's location
+func (s *service) scoreDistance(locale i18n.Locale, sch *models.Schedule, sameDay []models.Schedule) models.MatchFactorResponse {
+	var prev *models.Schedule
+	for i := range sameDay {
+		other := &sameDay[i]
+		if other.ID == sch.ID || other.EndTime.After(sch.StartTime) {
+			continue
+		}
+		if prev == nil || other.EndTime.After(prev.EndTime) {
+			prev = other
+		}
+	}
+
+	if prev == nil {
+		return models.MatchFactorResponse{
+			Factor: models.MatchFactorDistance,
+			Score:  round(models.MatchWeightDistance / 2),
+			Reason: locale.T(i18n.MsgMatchNoEarlierVisit, nil),
+		}
+	}
+
+	distanceKm := helpers.CalculateDistance(prev.Latitude, prev.Longitude, sch.Latitude, sch.Longitude) / 1000
+	score := 0.0
+	if s.cfg.MatchDistanceRadiusKm > 0 {
+		score = models.MatchWeightDistance * math.Max(0, 1-distanceKm/s.cfg.MatchDistanceRadiusKm)
+	}
+
+	return models.MatchFactorResponse{
+		Factor: models.MatchFactorDistance,
+		Score:  round(score),
+		Reason: locale.T(i18n.MsgMatchDistance, i18n.Args{
+			"distance": locale.Kilometers(distanceKm * 1000),
+			"client":   prev.ClientName,
+		}),
+	}
+}
+
+// scoreContinuity favours caregivers the client already knows
+func scoreContinuity(locale i18n.Locale, sch *models.Schedule, pastVisits int64) models.MatchFactorResponse {
+	ratio := math.Min(1, float64(pastVisits)/continuityVisitTarget)
+
+	return models.MatchFactorResponse{
+		Factor: models.MatchFactorContinuity,
+		Score:  round(models.MatchWeightContinuity * ratio),
+		Reason: locale.T(i18n.MsgMatchContinuity, i18n.Args{
+			"count":  locale.Integer(pastVisits),
+			"client": sch.ClientName,
+		}),
+	}
+}
+
+func (s *service) GetUserSkills(ctx context.Context, userID int64) (*models.UserSkillsResponse, error) {
+	skills, err := s.skillRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserSkillsResponse{UserID: userID, Skills: skills}, nil
+}
+
+func (s *service) SetUserSkills(ctx context.Context, userID int64, req *models.SetSkillsRequest) (*models.UserSkillsResponse, error) {
+	skills, err := normalizeSkills(req.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.skillRepo.ReplaceForUser(ctx, userID, skills); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserSkills(ctx, userID)
+}
+
+func (s *service) GetServiceRequirements(ctx context.Context, serviceName string) (*models.ServiceRequirementsResponse, error) {
+	skills, err := s.skillRepo.GetRequired(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ServiceRequirementsResponse{ServiceName: serviceName, Skills: skills}, nil
+}
+
+func (s *service) SetServiceRequirements(ctx context.Context, serviceName string, req *models.SetSkillsRequest) (*models.ServiceRequirementsResponse, error) {
+	skills, err := normalizeSkills(req.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.skillRepo.ReplaceRequired(ctx, serviceName, skills); err != nil {
+		return nil, err
+	}
+
+	return s.GetServiceRequirements(ctx, serviceName)
+}
+
+// normalizeSkills trims, lowercases and de-duplicates skill names
+func normalizeSkills(skills []string) ([]string, error) {
+	seen := make(map[string]bool, len(skills))
+	result := make([]string, 0, len(skills))
+	for _, sk := range skills {
+		sk = strings.ToLower(strings.TrimSpace(sk))
+		if sk == "" {
+			return nil, models.ErrInvalidSkill
+		}
+		if !seen[sk] {
+			seen[sk] = true
+			result = append(result, sk)
+		}
+	}
+	return result, nil
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
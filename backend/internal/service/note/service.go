@@ -0,0 +1,105 @@
+package note
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/note"
+	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
+)
+
+type Service interface {
+	GetNotes(ctx context.Context, userID, scheduleID int64) ([]models.NoteResponse, error)
+	AddNote(ctx context.Context, userID, scheduleID int64, req *models.NoteRequest) (*models.NoteResponse, error)
+	UpdateNote(ctx context.Context, userID, noteID int64, req *models.NoteRequest) (*models.NoteResponse, error)
+}
+
+type service struct {
+	editWindow   time.Duration
+	noteRepo     note.Repository
+	scheduleRepo schedule.Repository
+}
+
+func New(cfg config.ServiceConfig, noteRepo note.Repository, scheduleRepo schedule.Repository) Service {
+	return &service{
+		editWindow:   cfg.NoteEditWindowSeconds * time.Second,
+		noteRepo:     noteRepo,
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+func (s *service) GetNotes(ctx context.Context, userID, scheduleID int64) ([]models.NoteResponse, error) {
+	if _, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.noteRepo.GetBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	resp := make([]models.NoteResponse, len(notes))
+	for i, n := range notes {
+		resp[i] = n.ToNoteResponse(userID, now, s.editWindow)
+	}
+
+	return resp, nil
+}
+
+func (s *service) AddNote(ctx context.Context, userID, scheduleID int64, req *models.NoteRequest) (*models.NoteResponse, error) {
+	if _, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	newNote := &models.VisitNote{
+		ScheduleID: scheduleID,
+		UserID:     userID,
+		Body:       strings.TrimSpace(req.Body),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	id, err := s.noteRepo.Create(ctx, newNote)
+	if err != nil {
+		return nil, err
+	}
+
+	// re-read to pick up the author's name
+	created, err := s.noteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := created.ToNoteResponse(userID, now, s.editWindow)
+	return &resp, nil
+}
+
+// UpdateNote lets the author correct a note until the edit window after its creation closes
+func (s *service) UpdateNote(ctx context.Context, userID, noteID int64, req *models.NoteRequest) (*models.NoteResponse, error) {
+	existing, err := s.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if !existing.IsEditableBy(userID, now, s.editWindow) {
+		return nil, models.ErrNoteNotEditable
+	}
+
+	if err = s.noteRepo.UpdateBody(ctx, noteID, strings.TrimSpace(req.Body)); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.noteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := updated.ToNoteResponse(userID, now, s.editWindow)
+	return &resp, nil
+}
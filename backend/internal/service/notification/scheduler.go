@@ -0,0 +1,288 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/logging"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/notification"
+	"github.com/erizkiatama/bluehorntech/pkg/notify"
+)
+
+const (
+	defaultPollInterval     = 30 * time.Second
+	defaultBatchSize        = 50
+	defaultMaxAttempts      = 5
+	defaultRetryBase        = time.Minute
+	defaultRetryMax         = time.Hour
+	defaultVisitReminder    = 15 * time.Minute
+	defaultClockOutReminder = 15 * time.Minute
+
+	// clock-out reminders are only queued for visits that ended within this long before they became due
+	clockOutLookback = 24 * time.Hour
+	claimLease       = 5 * time.Minute
+	maxErrorLength   = 500
+
+	timeLayout = "15:04"
+	dateLayout = "2006-01-02"
+)
+
+// Scheduler queues reminders from the schedules' start and end times and sends due notifications,
+// holding them back during the caregiver's quiet hours and retrying failed sends with backoff
+type Scheduler struct {
+	notificationRepo notification.Repository
+	channels         map[string]notify.Channel
+	agencyLocation   *time.Location
+	defaultLocale    i18n.Locale
+	pollInterval     time.Duration
+	batchSize        int
+	maxAttempts      int
+	retryBase        time.Duration
+	retryMax         time.Duration
+	visitReminder    time.Duration
+	clockOutReminder time.Duration
+}
+
+func NewScheduler(cfg config.NotificationConfig, agencyTimezone, defaultLocale string, notificationRepo notification.Repository, channels map[string]notify.Channel) *Scheduler {
+	loc, err := time.LoadLocation(agencyTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	locale, ok := i18n.Parse(defaultLocale)
+	if !ok {
+		locale = i18n.Default
+	}
+
+	s := &Scheduler{
+		notificationRepo: notificationRepo,
+		channels:         channels,
+		agencyLocation:   loc,
+		defaultLocale:    locale,
+		pollInterval:     secondsOr(cfg.PollIntervalSeconds, defaultPollInterval),
+		batchSize:        cfg.BatchSize,
+		maxAttempts:      cfg.MaxAttempts,
+		retryBase:        secondsOr(cfg.RetryBaseSeconds, defaultRetryBase),
+		retryMax:         secondsOr(cfg.RetryMaxSeconds, defaultRetryMax),
+		visitReminder:    secondsOr(cfg.VisitReminderSeconds, defaultVisitReminder),
+		clockOutReminder: secondsOr(cfg.ClockOutReminderSeconds, defaultClockOutReminder),
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultBatchSize
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = defaultMaxAttempts
+	}
+
+	return s
+}
+
+func secondsOr(seconds, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return seconds * time.Second
+}
+
+// Run queues and sends notifications every poll interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce queues the reminders that became due and sends every due notification
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	now := time.Now().UTC()
+
+	if _, err := s.notificationRepo.QueueStartReminders(ctx, now, now.Add(s.visitReminder)); err != nil {
+		slog.ErrorContext(ctx, "Failed to queue visit reminders", "error", err)
+	}
+	endedBefore := now.Add(-s.clockOutReminder)
+	if _, err := s.notificationRepo.QueueClockOutReminders(ctx, now, endedBefore.Add(-clockOutLookback), endedBefore); err != nil {
+		slog.ErrorContext(ctx, "Failed to queue clock-out reminders", "error", err)
+	}
+
+	for {
+		due, err := s.notificationRepo.ClaimDue(ctx, s.batchSize, claimLease)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim due notifications", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range due {
+			wg.Add(1)
+			go func(n *models.Notification) {
+				defer wg.Done()
+				s.process(ctx, n)
+			}(&due[i])
+		}
+		wg.Wait()
+
+		if len(due) < s.batchSize {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) process(ctx context.Context, n *models.Notification) {
+	ctx = logging.WithScheduleID(ctx, n.ScheduleID)
+	now := time.Now().UTC()
+	s.deliver(ctx, n, now)
+
+	// recorded even when ctx is cancelled mid-send, so the attempt is not lost
+	if err := s.notificationRepo.RecordAttempt(context.WithoutCancel(ctx), n); err != nil {
+		slog.ErrorContext(ctx, "Failed to record notification", "notification_id", n.ID, "error", err)
+	}
+}
+
+// deliver sends n unless it no longer applies, or postpones it past quiet hours, and sets its new state
+func (s *Scheduler) deliver(ctx context.Context, n *models.Notification, now time.Time) {
+	if n.ExpiresAt.Valid && !now.Before(n.ExpiresAt.Time) {
+		skip(n, "expired before it could be sent")
+		return
+	}
+	if !channelEnabled(n) {
+		skip(n, n.Channel+" notifications are turned off")
+		return
+	}
+	channel, ok := s.channels[n.Channel]
+	if !ok {
+		skip(n, "no "+n.Channel+" channel is configured")
+		return
+	}
+	address := n.Address()
+	if address == "" {
+		skip(n, "no "+n.Channel+" address for the caregiver")
+		return
+	}
+
+	loc := s.location(n)
+	if until, quiet := n.Preferences.QuietUntil(now, loc); quiet {
+		if n.ExpiresAt.Valid && !until.Before(n.ExpiresAt.Time) {
+			skip(n, "expires during quiet hours")
+			return
+		}
+		n.Status = models.NotificationStatusPending
+		n.SendAfter = until
+		return
+	}
+
+	title, body := s.render(n, now, loc)
+	err := channel.Send(ctx, notify.Message{
+		To:    address,
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"kind":        n.Kind,
+			"schedule_id": strconv.FormatInt(n.ScheduleID, 10),
+		},
+	})
+
+	n.Attempts++
+	switch {
+	case err == nil:
+		n.Status = models.NotificationStatusSent
+		n.SentAt = sql.NullTime{Time: now, Valid: true}
+		n.LastError = sql.NullString{}
+	case n.Attempts >= s.maxAttempts:
+		n.Status = models.NotificationStatusFailed
+		n.LastError = errorText(err.Error())
+		slog.WarnContext(ctx, "Notification failed", "notification_id", n.ID, "kind", n.Kind, "channel", n.Channel,
+			"attempts", n.Attempts, "error", err)
+	default:
+		n.Status = models.NotificationStatusPending
+		n.SendAfter = now.Add(s.backoff(n.Attempts))
+		n.LastError = errorText(err.Error())
+	}
+}
+
+// backoff doubles the wait after every failed send, up to the configured maximum
+func (s *Scheduler) backoff(attempts int) time.Duration {
+	wait := s.retryBase
+	for i := 1; i < attempts && wait < s.retryMax; i++ {
+		wait *= 2
+	}
+	if wait > s.retryMax {
+		wait = s.retryMax
+	}
+	return wait
+}
+
+// render writes the message in the caregiver's language, with times in their timezone
+func (s *Scheduler) render(n *models.Notification, now time.Time, loc *time.Location) (string, string) {
+	locale, ok := i18n.Parse(n.Locale.String)
+	if !ok {
+		locale = s.defaultLocale
+	}
+
+	args := i18n.Args{
+		"client":   n.ClientName,
+		"service":  n.ServiceName,
+		"location": n.Location,
+		"date":     n.StartTime.In(loc).Format(dateLayout),
+		"time":     n.StartTime.In(loc).Format(timeLayout),
+	}
+
+	switch n.Kind {
+	case models.NotificationVisitStarting:
+		args["minutes"] = strconv.Itoa(int(math.Ceil(n.StartTime.Sub(now).Minutes())))
+		return locale.T(i18n.MsgNotifyVisitStartingTitle, args), locale.T(i18n.MsgNotifyVisitStartingBody, args)
+	case models.NotificationClockOutMissing:
+		args["time"] = n.EndTime.In(loc).Format(timeLayout)
+		return locale.T(i18n.MsgNotifyClockOutMissingTitle, args), locale.T(i18n.MsgNotifyClockOutMissingBody, args)
+	default:
+		return locale.T(i18n.MsgNotifyVisitReassignedTitle, args), locale.T(i18n.MsgNotifyVisitReassignedBody, args)
+	}
+}
+
+func (s *Scheduler) location(n *models.Notification) *time.Location {
+	if n.Preferences.Timezone.Valid {
+		if loc, err := time.LoadLocation(n.Preferences.Timezone.String); err == nil {
+			return loc
+		}
+	}
+	return s.agencyLocation
+}
+
+func channelEnabled(n *models.Notification) bool {
+	switch n.Channel {
+	case models.ChannelPush:
+		return n.Preferences.PushEnabled
+	case models.ChannelSMS:
+		return n.Preferences.SMSEnabled
+	case models.ChannelEmail:
+		return n.Preferences.EmailEnabled
+	default:
+		return false
+	}
+}
+
+func skip(n *models.Notification, reason string) {
+	n.Status = models.NotificationStatusSkipped
+	n.LastError = sql.NullString{String: reason, Valid: true}
+}
+
+func errorText(text string) sql.NullString {
+	if len(text) > maxErrorLength {
+		text = text[:maxErrorLength]
+	}
+	return sql.NullString{String: text, Valid: true}
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+func TestBackoff(t *testing.T) {
+	s := NewScheduler(config.NotificationConfig{RetryBaseSeconds: 60, RetryMaxSeconds: 3600}, "UTC", "en", nil, nil)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 3, want: 4 * time.Minute},
+		{attempts: 6, want: 32 * time.Minute},
+		{attempts: 7, want: time.Hour},
+		{attempts: 70, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := s.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffDefaults(t *testing.T) {
+	s := NewScheduler(config.NotificationConfig{}, "UTC", "en", nil, nil)
+
+	if got := s.backoff(1); got != defaultRetryBase {
+		t.Errorf("backoff(1) = %v, want %v", got, defaultRetryBase)
+	}
+	if got := s.backoff(100); got != defaultRetryMax {
+		t.Errorf("backoff(100) = %v, want %v", got, defaultRetryMax)
+	}
+}
@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/notification"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+)
+
+type Service interface {
+	GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID int64, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferencesResponse, error)
+	NotifyAssignment(ctx context.Context, scheduleID, userID int64) error
+}
+
+type service struct {
+	notificationRepo notification.Repository
+	userRepo         user.Repository
+}
+
+func New(notificationRepo notification.Repository, userRepo user.Repository) Service {
+	return &service{notificationRepo: notificationRepo, userRepo: userRepo}
+}
+
+func (s *service) GetPreferences(ctx context.Context, userID int64) (*models.NotificationPreferencesResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := prefs.ToNotificationPreferencesResponse()
+	return &resp, nil
+}
+
+// UpdatePreferences replaces the caregiver's channels and quiet hours. The push token is write-only:
+// it is kept when omitted and cleared when empty.
+func (s *service) UpdatePreferences(ctx context.Context, userID int64, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferencesResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	quietStart, quietEnd := strings.TrimSpace(req.QuietHoursStart), strings.TrimSpace(req.QuietHoursEnd)
+	if (quietStart == "") != (quietEnd == "") {
+		return nil, models.ErrInvalidQuietHours
+	}
+	for _, v := range []string{quietStart, quietEnd} {
+		if _, err := time.Parse(models.QuietHoursLayout, v); v != "" && err != nil {
+			return nil, models.ErrInvalidQuietHours
+		}
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, models.ErrInvalidTimezone
+		}
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs.PushEnabled = req.PushEnabled
+	prefs.SMSEnabled = req.SMSEnabled
+	prefs.EmailEnabled = req.EmailEnabled
+	if req.PushToken != nil {
+		token := strings.TrimSpace(*req.PushToken)
+		prefs.PushToken = sql.NullString{String: token, Valid: token != ""}
+	}
+	prefs.QuietHoursStart = sql.NullString{String: quietStart, Valid: quietStart != ""}
+	prefs.QuietHoursEnd = sql.NullString{String: quietEnd, Valid: quietEnd != ""}
+	prefs.Timezone = sql.NullString{String: timezone, Valid: timezone != ""}
+
+	if err = s.notificationRepo.SavePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	resp := prefs.ToNotificationPreferencesResponse()
+	return &resp, nil
+}
+
+// NotifyAssignment queues the "visit assigned to you" message to the visit's new caregiver
+func (s *service) NotifyAssignment(ctx context.Context, scheduleID, userID int64) error {
+	return s.notificationRepo.QueueAssignment(ctx, scheduleID, userID, time.Now().UTC())
+}
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/report"
+)
+
+// defaultReportDays is the range reported when no from date is given, four whole weeks ending on the to date
+const defaultReportDays = 28
+
+type Service interface {
+	GetComplianceReport(ctx context.Context, req *models.ComplianceReportRequest) (*models.ComplianceReportResponse, error)
+}
+
+type service struct {
+	cfg        config.ServiceConfig
+	reportRepo report.Repository
+}
+
+func New(cfg config.ServiceConfig, reportRepo report.Repository) Service {
+	return &service{cfg: cfg, reportRepo: reportRepo}
+}
+
+// GetComplianceReport aggregates EVV compliance over visits starting between the from and to dates,
+// grouped by the requested dimensions, along with the totals over the whole range
+func (s *service) GetComplianceReport(ctx context.Context, req *models.ComplianceReportRequest) (*models.ComplianceReportResponse, error) {
+	if err := validateGroupBy(req.GroupBy); err != nil {
+		return nil, err
+	}
+
+	loc := s.location()
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if req.To != "" {
+		parsed, err := time.ParseInLocation(models.ReportDateLayout, req.To, loc)
+		if err != nil {
+			return nil, models.ErrInvalidDate
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, 1-defaultReportDays)
+	if req.From != "" {
+		parsed, err := time.ParseInLocation(models.ReportDateLayout, req.From, loc)
+		if err != nil {
+			return nil, models.ErrInvalidDate
+		}
+		from = parsed
+	}
+	if to.Before(from) {
+		return nil, models.ErrInvalidDateRange
+	}
+
+	stats, err := s.reportRepo.GetComplianceStats(ctx, from, to.AddDate(0, 0, 1), loc.String(), req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.ComplianceReportResponse{
+		From:    from.Format(models.ReportDateLayout),
+		To:      to.Format(models.ReportDateLayout),
+		GroupBy: req.GroupBy,
+		Rows:    make([]models.ComplianceReportRow, len(stats)),
+	}
+	if resp.GroupBy == nil {
+		resp.GroupBy = []string{}
+	}
+
+	var totals models.ComplianceStats
+	for i, st := range stats {
+		resp.Rows[i] = st.ToComplianceReportRow()
+		totals.Add(st)
+	}
+	resp.Totals = totals.ToComplianceReportRow()
+
+	return resp, nil
+}
+
+func validateGroupBy(groupBy []string) error {
+	seen := make(map[string]bool, len(groupBy))
+	periods := 0
+	for _, g := range groupBy {
+		if !models.IsValidReportGroup(g) || seen[g] {
+			return models.ErrInvalidGroupBy
+		}
+		seen[g] = true
+		if models.IsReportPeriod(g) {
+			periods++
+		}
+	}
+	if periods > 1 {
+		return models.ErrInvalidGroupBy
+	}
+
+	return nil
+}
+
+func (s *service) location() *time.Location {
+	loc, err := time.LoadLocation(s.cfg.AgencyTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
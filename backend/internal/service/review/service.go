@@ -0,0 +1,133 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/review"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+)
+
+const dateLayout = "2006-01-02"
+
+type Service interface {
+	GetExceptions(ctx context.Context, req *models.ExceptionQueryRequest) ([]models.VisitExceptionResponse, error)
+	ReviewException(ctx context.Context, reviewerID, scheduleID int64, flag, status string, req *models.ReviewExceptionRequest) (*models.VisitExceptionResponse, error)
+}
+
+type service struct {
+	cfg        config.ServiceConfig
+	reviewRepo review.Repository
+	userRepo   user.Repository
+}
+
+func New(cfg config.ServiceConfig, reviewRepo review.Repository, userRepo user.Repository) Service {
+	return &service{cfg: cfg, reviewRepo: reviewRepo, userRepo: userRepo}
+}
+
+// GetExceptions lists visit compliance flags for supervisors, by default only the unresolved ones
+func (s *service) GetExceptions(ctx context.Context, req *models.ExceptionQueryRequest) ([]models.VisitExceptionResponse, error) {
+	filter := models.ExceptionFilter{
+		Flag:       strings.ToUpper(strings.TrimSpace(req.Flag)),
+		UserID:     req.CaregiverID,
+		ClientName: strings.TrimSpace(req.Client),
+		Status:     req.Status,
+	}
+	if filter.Status == "" {
+		filter.Status = models.ExceptionStatusUnresolved
+	}
+	if !models.IsValidExceptionStatusFilter(filter.Status) {
+		return nil, models.ErrInvalidExceptionStatus
+	}
+
+	loc := s.location()
+	if req.From != "" {
+		from, err := time.ParseInLocation(dateLayout, req.From, loc)
+		if err != nil {
+			return nil, models.ErrInvalidDate
+		}
+		filter.From = from
+	}
+	if req.To != "" {
+		to, err := time.ParseInLocation(dateLayout, req.To, loc)
+		if err != nil {
+			return nil, models.ErrInvalidDate
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	exceptions, err := s.reviewRepo.GetExceptions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.VisitExceptionResponse, len(exceptions))
+	for i, e := range exceptions {
+		resp[i] = e.ToVisitExceptionResponse()
+	}
+
+	return resp, nil
+}
+
+// ReviewException acknowledges, approves or rejects one compliance flag of a visit.
+// Approving or rejecting resolves it and needs a note; acknowledging keeps it in the queue.
+// Only supervisors and admins can review.
+func (s *service) ReviewException(ctx context.Context, reviewerID, scheduleID int64, flag, status string, req *models.ReviewExceptionRequest) (*models.VisitExceptionResponse, error) {
+	reviewer, err := s.userRepo.GetByID(ctx, reviewerID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, models.ErrSupervisorRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer.CanReview() {
+		return nil, models.ErrSupervisorRequired
+	}
+
+	exception, err := s.reviewRepo.GetException(ctx, scheduleID, strings.ToUpper(flag))
+	if err != nil {
+		return nil, err
+	}
+
+	if exception.IsResolved() {
+		return nil, models.ErrExceptionAlreadyResolved
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if status != models.ExceptionStatusAcknowledged && note == "" {
+		return nil, models.ErrReviewNoteRequired
+	}
+
+	review := &models.ExceptionReview{
+		ScheduleID: scheduleID,
+		Flag:       exception.Flag,
+		Status:     status,
+		ReviewedBy: reviewerID,
+		Note:       sql.NullString{String: note, Valid: note != ""},
+		ReviewedAt: time.Now().UTC(),
+	}
+	if err = s.reviewRepo.SaveReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	exception.Status = review.Status
+	exception.ReviewedBy = sql.NullInt64{Int64: review.ReviewedBy, Valid: true}
+	exception.ReviewedAt = sql.NullTime{Time: review.ReviewedAt, Valid: true}
+	exception.ReviewNote = review.Note
+
+	resp := exception.ToVisitExceptionResponse()
+	return &resp, nil
+}
+
+func (s *service) location() *time.Location {
+	loc, err := time.LoadLocation(s.cfg.AgencyTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
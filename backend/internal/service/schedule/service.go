@@ -1,16 +1,30 @@
 package schedule
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
 	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/monitoring"
+	"github.com/erizkiatama/bluehorntech/internal/repository/attestation"
+	"github.com/erizkiatama/bluehorntech/internal/repository/credential"
 	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
 	"github.com/erizkiatama/bluehorntech/internal/repository/task"
+	"github.com/erizkiatama/bluehorntech/internal/service/conflict"
+	"github.com/erizkiatama/bluehorntech/internal/service/notification"
+	"github.com/erizkiatama/bluehorntech/pkg/geocoding"
 	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+	"github.com/jmoiron/sqlx/types"
 	"github.com/lib/pq"
 )
 
@@ -19,23 +33,51 @@ type Service interface {
 	GetAllSchedules(ctx context.Context, userID int64) (*models.ListScheduleResponse, error)
 	GetScheduleDetails(ctx context.Context, userID, scheduleID int64) (*models.ScheduleResponse, error)
 	ClockIn(ctx context.Context, userID, scheduleID int64, req *models.ClockInOutRequest) (*models.ClockInResponse, error)
-	ClockOut(ctx context.Context, userID, scheduleID int64, req *models.ClockInOutRequest) (*models.ClockOutResponse, error)
+	ClockOut(ctx context.Context, userID, scheduleID int64, req *models.ClockOutRequest) (*models.ClockOutResponse, error)
+	CreateSchedule(ctx context.Context, req *models.CreateScheduleRequest) (*models.ScheduleResponse, error)
+	AssignSchedule(ctx context.Context, scheduleID int64, req *models.AssignScheduleRequest) (*models.ScheduleResponse, error)
 }
 
 type service struct {
-	cfg          config.ServiceConfig
-	scheduleRepo schedule.Repository
-	taskRepo     task.Repository
+	cfg             config.ServiceConfig
+	scheduleRepo    schedule.Repository
+	taskRepo        task.Repository
+	conflictChecker conflict.Checker
+	credentialRepo  credential.Repository
+	geocoder        geocoding.Resolver
+	attestationRepo attestation.Repository
+	notifier        notification.Service
+	metrics         *monitoring.Metrics
 }
 
-func New(cfg config.ServiceConfig, scheduleRepo schedule.Repository, taskRepo task.Repository) Service {
-	return &service{cfg: cfg, scheduleRepo: scheduleRepo, taskRepo: taskRepo}
+func New(
+	cfg config.ServiceConfig,
+	scheduleRepo schedule.Repository,
+	taskRepo task.Repository,
+	conflictChecker conflict.Checker,
+	credentialRepo credential.Repository,
+	geocoder geocoding.Resolver,
+	attestationRepo attestation.Repository,
+	notifier notification.Service,
+	metrics *monitoring.Metrics,
+) Service {
+	return &service{
+		cfg:             cfg,
+		scheduleRepo:    scheduleRepo,
+		taskRepo:        taskRepo,
+		conflictChecker: conflictChecker,
+		credentialRepo:  credentialRepo,
+		geocoder:        geocoder,
+		attestationRepo: attestationRepo,
+		notifier:        notifier,
+		metrics:         metrics,
+	}
 }
 
 func (s *service) GetTodaySchedules(ctx context.Context, userID int64, tz string) (*models.ListScheduleResponse, error) {
 	loc, err := time.LoadLocation(tz)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timezone: %w", err)
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidTimezone, tz)
 	}
 	now := time.Now().In(loc)
 	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
@@ -97,38 +139,73 @@ func (s *service) GetScheduleDetails(ctx context.Context, userID, scheduleID int
 		return nil, err
 	}
 
-	return sch.ToScheduleDetailResponse(tasks), nil
+	attestation, err := s.attestationRepo.GetBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := sch.ToScheduleDetailResponse(tasks)
+	if attestation != nil {
+		resp.Attestation = attestation.ToAttestationResponse()
+	}
+
+	return resp, nil
 }
 
+// ClockIn starts the visit, counting it as rejected when it is refused
 func (s *service) ClockIn(ctx context.Context, userID, scheduleID int64, req *models.ClockInOutRequest) (*models.ClockInResponse, error) {
+	resp, err := s.clockIn(ctx, userID, scheduleID, req)
+	if err != nil {
+		s.metrics.ClockInRejected(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *service) clockIn(ctx context.Context, userID, scheduleID int64, req *models.ClockInOutRequest) (*models.ClockInResponse, error) {
 	var (
 		complianceFlags []string
 		complianceNotes sql.NullString
 	)
 	sch, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID)
 	if err != nil {
-		return nil, models.ErrScheduleNotFound
+		return nil, err
 	}
 
 	if sch.ClockInTime.Valid {
 		return nil, models.ErrVisitAlreadyStarted
 	}
 
-	if err = s.validateClockInTime(s.cfg, sch, *req.Timestamp); err != nil {
+	timeCompliance, err := s.validateClockInTime(ctx, s.cfg, sch, *req.Timestamp)
+	if err != nil {
 		return nil, err
 	}
 
-	compliance := s.validateLocationCompliance(s.cfg, sch, req.Latitude, req.Longitude)
+	compliance := s.validateLocationCompliance(ctx, s.cfg, sch, req.Latitude, req.Longitude)
 	if compliance.Flags == models.ComplianceLocationError {
 		return nil, models.ErrLocationTooFar
 	}
 
-	if compliance.Flags != "" {
-		complianceFlags = []string{compliance.Flags}
+	credentialCompliance, err := s.validateCredentials(ctx, s.cfg, sch, *req.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes, warnings []string
+	for _, c := range []models.ComplianceResult{timeCompliance, compliance, credentialCompliance} {
+		if c.Flags != "" {
+			complianceFlags = append(complianceFlags, c.Flags)
+		}
+		if c.Notes != "" {
+			notes = append(notes, c.Notes)
+		}
+		if c.WarningMessage != "" {
+			warnings = append(warnings, c.WarningMessage)
+		}
 	}
-	if compliance.Notes != "" {
+	if len(notes) > 0 {
 		complianceNotes = sql.NullString{
-			String: compliance.Notes,
+			String: strings.Join(notes, "; "),
 			Valid:  true,
 		}
 	}
@@ -148,29 +225,39 @@ func (s *service) ClockIn(ctx context.Context, userID, scheduleID int64, req *mo
 			Float64: req.Longitude,
 			Valid:   true,
 		},
+		ClockInAddress: sql.NullString{
+			String: s.geocoder.Resolve(ctx, req.Latitude, req.Longitude),
+			Valid:  true,
+		},
 		Status:          models.StatusInProgress,
 		ComplianceFlags: pq.StringArray(complianceFlags),
 		ValidationNotes: complianceNotes,
 	}
 
-	err = s.scheduleRepo.UpdateClockIn(ctx, clockInData)
+	events, err := visitEvents(models.EventVisitStarted, sch, clockInData, complianceFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.scheduleRepo.UpdateClockIn(ctx, clockInData, events)
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.ClockedIn(complianceFlags)
 
 	response := &models.ClockInResponse{
 		ClockInTime:    *req.Timestamp,
 		CanProceed:     true,
-		WarningMessage: compliance.WarningMessage,
+		WarningMessage: strings.Join(warnings, "; "),
 	}
 
 	return response, nil
 }
 
-func (s *service) ClockOut(ctx context.Context, userID, scheduleID int64, req *models.ClockInOutRequest) (*models.ClockOutResponse, error) {
+func (s *service) ClockOut(ctx context.Context, userID, scheduleID int64, req *models.ClockOutRequest) (*models.ClockOutResponse, error) {
 	sch, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID)
 	if err != nil {
-		return nil, models.ErrScheduleNotFound
+		return nil, err
 	}
 
 	if !sch.ClockInTime.Valid {
@@ -182,10 +269,47 @@ func (s *service) ClockOut(ctx context.Context, userID, scheduleID int64, req *m
 	}
 
 	visitDuration := req.Timestamp.Sub(sch.ClockInTime.Time)
-	if visitDuration*time.Second < s.cfg.MinVisitDurationSeconds*time.Second {
-		return nil, models.ErrClockOutTooEarly
+	durationCompliance, err := s.validateVisitDuration(ctx, s.cfg, sch, visitDuration, req.DurationReason, req.EarlyDeparture)
+	if err != nil {
+		return nil, err
 	}
 
+	taskCompliance, err := s.validateRequiredTasks(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	attestationCompliance, err := s.validateAttestation(ctx, req.Attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	// saved together with the clock-out, so a refused clock-out leaves no attestation behind
+	var attestation *models.Attestation
+	if req.Attestation != nil {
+		attestation, err = buildAttestation(s.cfg, scheduleID, req.Attestation, *req.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	complianceNotes := sch.ValidationNotes
+	var newFlags, warnings []string
+	for _, c := range append(durationCompliance, taskCompliance, attestationCompliance) {
+		if c.Flags != "" {
+			newFlags = append(newFlags, c.Flags)
+		}
+		if c.Notes != "" {
+			complianceNotes = appendNote(complianceNotes, c.Notes)
+		}
+		if c.WarningMessage != "" {
+			warnings = append(warnings, c.WarningMessage)
+		}
+	}
+
+	actualMinutes := models.DurationMinutes(visitDuration)
+	scheduledMinutes := models.DurationMinutes(sch.Duration())
+
 	clockOutData := models.Schedule{
 		ID:     scheduleID,
 		UserID: userID,
@@ -201,58 +325,685 @@ func (s *service) ClockOut(ctx context.Context, userID, scheduleID int64, req *m
 			Float64: req.Longitude,
 			Valid:   true,
 		},
-		Status: models.StatusCompleted,
+		ClockOutAddress: sql.NullString{
+			String: s.geocoder.Resolve(ctx, req.Latitude, req.Longitude),
+			Valid:  true,
+		},
+		Status:           models.StatusCompleted,
+		ComplianceFlags:  append(append(pq.StringArray{}, sch.ComplianceFlags...), newFlags...),
+		ValidationNotes:  complianceNotes,
+		ActualMinutes:    sql.NullInt64{Int64: actualMinutes, Valid: true},
+		ScheduledMinutes: sql.NullInt64{Int64: scheduledMinutes, Valid: true},
+		VarianceMinutes:  sql.NullInt64{Int64: actualMinutes - scheduledMinutes, Valid: true},
+	}
+	if req.EarlyDeparture != nil {
+		clockOutData.EarlyDepartureReason = sql.NullString{String: req.EarlyDeparture.ReasonCode, Valid: true}
+		clockOutData.EarlyDepartureNote = sql.NullString{
+			String: strings.TrimSpace(req.EarlyDeparture.Note),
+			Valid:  strings.TrimSpace(req.EarlyDeparture.Note) != "",
+		}
+	}
+
+	events, err := visitEvents(models.EventVisitEnded, sch, clockOutData, newFlags)
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.scheduleRepo.UpdateClockOut(ctx, clockOutData)
+	err = s.scheduleRepo.UpdateClockOut(ctx, clockOutData, attestation, events)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update clock-out: %w", err)
 	}
+	s.metrics.ClockedOut(newFlags)
 
 	return &models.ClockOutResponse{
-		ClockInTime:   sch.ClockInTime.Time,
-		ClockOutTime:  *req.Timestamp,
-		TotalDuration: helpers.FormatDuration(visitDuration),
-		Date:          helpers.FormatShiftDate(sch.StartTime),
+		ClockInTime:      sch.ClockInTime.Time,
+		ClockOutTime:     *req.Timestamp,
+		TotalDuration:    helpers.FormatDuration(visitDuration),
+		ActualMinutes:    actualMinutes,
+		ScheduledMinutes: scheduledMinutes,
+		VarianceMinutes:  actualMinutes - scheduledMinutes,
+		Date:             helpers.FormatShiftDate(sch.StartTime),
+		WarningMessage:   strings.Join(warnings, "; "),
 	}, nil
 }
 
-func (s *service) validateClockInTime(cfg config.ServiceConfig, sch *models.Schedule, clockInTime time.Time) error {
-	shiftStart := sch.StartTime
-	shiftEnd := sch.EndTime
+// CreateSchedule books a new visit for a caregiver, refusing it when it conflicts with their other commitments
+func (s *service) CreateSchedule(ctx context.Context, req *models.CreateScheduleRequest) (*models.ScheduleResponse, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, models.ErrInvalidScheduleTime
+	}
 
-	if clockInTime.Before(shiftStart.Add(-cfg.MaxEarlyClockInSeconds * time.Second)) {
-		return models.ErrClockInTooEarly
+	sch := &models.Schedule{
+		UserID:      req.UserID,
+		ClientName:  req.ClientName,
+		ServiceName: req.ServiceName,
+		ServiceNotes: sql.NullString{
+			String: req.ServiceNotes,
+			Valid:  req.ServiceNotes != "",
+		},
+		Location:  req.Location,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		StartTime: req.StartTime.UTC(),
+		EndTime:   req.EndTime.UTC(),
+		Status:    models.StatusScheduled,
 	}
 
-	if clockInTime.After(shiftEnd.Add(cfg.MaxLateClockInSeconds * time.Second)) {
-		return models.ErrClockInTooLate
+	tasks, err := buildTasks(req.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// unassigned visits are matched to a caregiver later, so there is nothing to conflict with yet
+	if sch.IsAssigned() {
+		if err := s.checkConflicts(ctx, sch); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.scheduleRepo.Create(ctx, sch, tasks)
+	if err != nil {
+		return nil, err
+	}
+	sch.ID = id
+
+	resp := sch.ToScheduleResponse()
+	return &resp, nil
+}
+
+// AssignSchedule gives a visit that has not started yet to a caregiver, replacing any current assignee
+func (s *service) AssignSchedule(ctx context.Context, scheduleID int64, req *models.AssignScheduleRequest) (*models.ScheduleResponse, error) {
+	sch, err := s.scheduleRepo.Get(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sch.CanStart() {
+		return nil, models.ErrScheduleNotEditable
+	}
+
+	previousUserID := sch.UserID
+	sch.UserID = req.UserID
+	if err = s.checkConflicts(ctx, sch); err != nil {
+		return nil, err
+	}
+
+	if err = s.scheduleRepo.UpdateAssignee(ctx, scheduleID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	// the assignment stands even if the caregiver cannot be told about it
+	if req.UserID != previousUserID {
+		if err = s.notifier.NotifyAssignment(ctx, scheduleID, req.UserID); err != nil {
+			slog.WarnContext(ctx, "Failed to notify caregiver of their assignment", "caregiver_id", req.UserID, "error", err)
+		}
+	}
+
+	resp := sch.ToScheduleResponse()
+	return &resp, nil
+}
+
+func (s *service) checkConflicts(ctx context.Context, sch *models.Schedule) error {
+	conflicts, err := s.conflictChecker.Check(ctx, sch)
+	if err != nil {
+		return fmt.Errorf("failed to check schedule conflicts: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		return &models.ScheduleConflictError{Conflicts: conflicts}
 	}
 
 	return nil
 }
 
-func (s *service) validateLocationCompliance(cfg config.ServiceConfig, sch *models.Schedule, lat, lng float64) models.ComplianceResult {
+// validateClockInTime checks the clock-in falls inside the service's clock-in window. Clock-ins outside it
+// are refused or flagged as TIME_ERROR depending on the enforcement; late ones inside it are flagged as TIME_WARNING.
+func (s *service) validateClockInTime(ctx context.Context, cfg config.ServiceConfig, sch *models.Schedule, clockInTime time.Time) (models.ComplianceResult, error) {
+	window := clockInWindowFor(cfg, sch.ServiceName)
+
+	anchor := sch.StartTime
+	if window.Anchor == models.ClockInAnchorEnd {
+		anchor = sch.EndTime
+	}
+
+	windowErr := &models.ClockWindowError{
+		OpensAt:     sch.StartTime.Add(-*window.MaxEarlyClockInSeconds * time.Second),
+		ClosesAt:    anchor.Add(*window.MaxLateClockInSeconds * time.Second),
+		AttemptedAt: clockInTime,
+	}
+
+	notes, caller := s.notesLocale(), i18n.FromContext(ctx)
+
+	switch {
+	case clockInTime.Before(windowErr.OpensAt):
+		windowErr.Err = models.ErrClockInTooEarly
+	case clockInTime.After(windowErr.ClosesAt):
+		windowErr.Err = models.ErrClockInTooLate
+	case clockInTime.After(sch.StartTime):
+		late := roundUpToMinute(clockInTime.Sub(sch.StartTime))
+		return models.ComplianceResult{
+			Flags:          models.ComplianceTimeWarning,
+			Notes:          notes.T(i18n.MsgClockInLateNote, i18n.Args{"late": notes.Duration(late)}),
+			WarningMessage: caller.T(i18n.MsgClockInLateWarning, i18n.Args{"late": caller.Duration(late)}),
+		}, nil
+	default:
+		return models.ComplianceResult{}, nil
+	}
+
+	if window.Enforcement != models.ClockInEnforcementFlag {
+		return models.ComplianceResult{}, windowErr
+	}
+
+	outside := roundUpToMinute(windowErr.OutsideBy())
+	noteKey := i18n.MsgClockInBeforeWindowNote
+	if errors.Is(windowErr, models.ErrClockInTooLate) {
+		noteKey = i18n.MsgClockInAfterWindowNote
+	}
+	return models.ComplianceResult{
+		Flags:          models.ComplianceTimeError,
+		Notes:          notes.T(noteKey, i18n.Args{"outside": notes.Duration(outside)}),
+		WarningMessage: caller.T(i18n.MsgClockInOutsideWindowWarning, nil),
+	}, nil
+}
+
+// clockInWindowFor returns the agency clock-in window with any overrides configured for the service applied
+func clockInWindowFor(cfg config.ServiceConfig, serviceName string) config.ClockInWindowConfig {
+	window := config.ClockInWindowConfig{
+		Anchor:                 cfg.ClockInAnchor,
+		MaxEarlyClockInSeconds: &cfg.MaxEarlyClockInSeconds,
+		MaxLateClockInSeconds:  &cfg.MaxLateClockInSeconds,
+		Enforcement:            cfg.ClockInEnforcement,
+	}
+
+	// the config loader lower-cases map keys, so service names are compared the same way
+	override, ok := cfg.ClockInWindows[strings.ToLower(serviceName)]
+	if !ok {
+		return window
+	}
+	if override.Anchor != "" {
+		window.Anchor = override.Anchor
+	}
+	if override.MaxEarlyClockInSeconds != nil {
+		window.MaxEarlyClockInSeconds = override.MaxEarlyClockInSeconds
+	}
+	if override.MaxLateClockInSeconds != nil {
+		window.MaxLateClockInSeconds = override.MaxLateClockInSeconds
+	}
+	if override.Enforcement != "" {
+		window.Enforcement = override.Enforcement
+	}
+
+	return window
+}
+
+// validateVisitDuration applies the service's duration policy at clock-out. Each breached limit either
+// refuses the clock-out, is flagged, or is flagged only when the caregiver gives a reason. A visit cut short
+// with an early departure reason is accepted and flagged EARLY_DEPARTURE for supervisor review instead.
+func (s *service) validateVisitDuration(ctx context.Context, cfg config.ServiceConfig, sch *models.Schedule, actual time.Duration, reason string, departure *models.EarlyDepartureRequest) ([]models.ComplianceResult, error) {
+	if departure != nil {
+		if !models.IsValidEarlyDepartureReason(departure.ReasonCode) {
+			return nil, models.ErrInvalidEarlyDeparture
+		}
+		if departure.ReasonCode == models.EarlyDepartureOther && strings.TrimSpace(departure.Note) == "" {
+			return nil, models.ErrInvalidEarlyDeparture
+		}
+	}
+
+	policy := visitDurationFor(cfg, sch.ServiceName)
+	scheduled := sch.Duration()
+	variance := actual - scheduled
+	if variance < 0 {
+		variance = -variance
+	}
+
+	checks := []struct {
+		breached bool
+		short    bool
+		action   string
+		err      error
+		flag     string
+		limit    time.Duration
+		noteKey  string
+	}{
+		{
+			breached: actual < *policy.MinVisitDurationSeconds*time.Second,
+			short:    true,
+			action:   policy.MinVisitDurationAction,
+			err:      models.ErrClockOutTooEarly,
+			flag:     models.ComplianceDurationShort,
+			limit:    *policy.MinVisitDurationSeconds * time.Second,
+			noteKey:  i18n.MsgVisitTooShortNote,
+		},
+		{
+			breached: *policy.MaxVisitDurationSeconds > 0 && actual > *policy.MaxVisitDurationSeconds*time.Second,
+			action:   policy.MaxVisitDurationAction,
+			err:      models.ErrVisitTooLong,
+			flag:     models.ComplianceDurationLong,
+			limit:    *policy.MaxVisitDurationSeconds * time.Second,
+			noteKey:  i18n.MsgVisitTooLongNote,
+		},
+		{
+			breached: *policy.MaxDurationVarianceSeconds > 0 && variance > *policy.MaxDurationVarianceSeconds*time.Second,
+			short:    actual < scheduled,
+			action:   policy.DurationVarianceAction,
+			err:      models.ErrDurationVariance,
+			flag:     models.ComplianceDurationVariance,
+			limit:    *policy.MaxDurationVarianceSeconds * time.Second,
+			noteKey:  i18n.MsgDurationVarianceNote,
+		},
+	}
+
+	notes, caller := s.notesLocale(), i18n.FromContext(ctx)
+	reason = strings.TrimSpace(reason)
+
+	var results []models.ComplianceResult
+	if departure != nil {
+		note := notes.T(i18n.MsgEarlyDepartureNote, i18n.Args{
+			"actual":    notes.Duration(actual),
+			"scheduled": notes.Duration(scheduled),
+			"reason":    departure.ReasonCode,
+		})
+		if n := strings.TrimSpace(departure.Note); n != "" {
+			note += " - " + n
+		}
+		results = append(results, models.ComplianceResult{
+			Flags:          models.ComplianceEarlyDeparture,
+			Notes:          note,
+			WarningMessage: caller.T(i18n.MsgEarlyDepartureWarning, nil),
+		})
+	}
+
+	for _, c := range checks {
+		// a visit that ended early for a recorded reason is reviewed as an early departure, not refused as short
+		if !c.breached || (c.short && departure != nil) {
+			continue
+		}
+
+		durationErr := &models.VisitDurationError{
+			Err:       c.err,
+			Violation: c.flag,
+			Actual:    actual,
+			Scheduled: scheduled,
+			Limit:     c.limit,
+		}
+		switch c.action {
+		case models.DurationActionFlag:
+		case models.DurationActionReason:
+			if reason == "" {
+				durationErr.Err = models.ErrDurationReasonRequired
+				return nil, durationErr
+			}
+		default:
+			return nil, durationErr
+		}
+
+		note := notes.T(c.noteKey, i18n.Args{
+			"actual":    notes.Duration(actual),
+			"scheduled": notes.Duration(scheduled),
+			"limit":     notes.Duration(c.limit),
+		})
+		if reason != "" {
+			note += " - " + notes.T(i18n.MsgDurationReasonNote, i18n.Args{"reason": reason})
+		}
+		results = append(results, models.ComplianceResult{
+			Flags:          c.flag,
+			Notes:          note,
+			WarningMessage: caller.T(i18n.MsgVisitDurationWarning, i18n.Args{"actual": caller.Duration(actual)}),
+		})
+	}
+
+	return results, nil
+}
+
+// visitDurationFor returns the agency visit duration policy with any overrides configured for the service applied
+func visitDurationFor(cfg config.ServiceConfig, serviceName string) config.VisitDurationConfig {
+	policy := config.VisitDurationConfig{
+		MinVisitDurationSeconds:    &cfg.MinVisitDurationSeconds,
+		MinVisitDurationAction:     cfg.MinVisitDurationAction,
+		MaxVisitDurationSeconds:    &cfg.MaxVisitDurationSeconds,
+		MaxVisitDurationAction:     cfg.MaxVisitDurationAction,
+		MaxDurationVarianceSeconds: &cfg.MaxDurationVarianceSeconds,
+		DurationVarianceAction:     cfg.DurationVarianceAction,
+	}
+
+	override, ok := cfg.VisitDurations[strings.ToLower(serviceName)]
+	if !ok {
+		return policy
+	}
+	if override.MinVisitDurationSeconds != nil {
+		policy.MinVisitDurationSeconds = override.MinVisitDurationSeconds
+	}
+	if override.MinVisitDurationAction != "" {
+		policy.MinVisitDurationAction = override.MinVisitDurationAction
+	}
+	if override.MaxVisitDurationSeconds != nil {
+		policy.MaxVisitDurationSeconds = override.MaxVisitDurationSeconds
+	}
+	if override.MaxVisitDurationAction != "" {
+		policy.MaxVisitDurationAction = override.MaxVisitDurationAction
+	}
+	if override.MaxDurationVarianceSeconds != nil {
+		policy.MaxDurationVarianceSeconds = override.MaxDurationVarianceSeconds
+	}
+	if override.DurationVarianceAction != "" {
+		policy.DurationVarianceAction = override.DurationVarianceAction
+	}
+
+	return policy
+}
+
+func roundUpToMinute(d time.Duration) time.Duration {
+	return (d + time.Minute - 1).Truncate(time.Minute)
+}
+
+func (s *service) validateLocationCompliance(ctx context.Context, cfg config.ServiceConfig, sch *models.Schedule, lat, lng float64) models.ComplianceResult {
 	distanceMeters := helpers.CalculateDistance(
 		sch.Latitude, sch.Longitude,
 		lat, lng,
 	)
+	notes, caller := s.notesLocale(), i18n.FromContext(ctx)
 
 	if distanceMeters > cfg.MaxDistanceError {
 		return models.ComplianceResult{
-			Flags:          models.ComplianceLocationError,
-			Notes:          fmt.Sprintf("Distance from scheduled location: %.0fm (exceeds %.0fm limit)", distanceMeters, cfg.MaxDistanceError),
-			WarningMessage: fmt.Sprintf("You are %.0fm away from the scheduled location", distanceMeters),
+			Flags: models.ComplianceLocationError,
+			Notes: notes.T(i18n.MsgLocationExceededNote, i18n.Args{
+				"distance": notes.Meters(distanceMeters),
+				"limit":    notes.Meters(cfg.MaxDistanceError),
+			}),
+			WarningMessage: caller.T(i18n.MsgLocationWarning, i18n.Args{"distance": caller.Meters(distanceMeters)}),
 		}
 	}
 
 	if distanceMeters > cfg.MaxDistanceWarning {
 		return models.ComplianceResult{
 			Flags:          models.ComplianceLocationWarn,
-			Notes:          fmt.Sprintf("Distance from scheduled location: %.0fm (warning threshold)", distanceMeters),
-			WarningMessage: fmt.Sprintf("You are %.0fm away from the scheduled location", distanceMeters),
+			Notes:          notes.T(i18n.MsgLocationWarningNote, i18n.Args{"distance": notes.Meters(distanceMeters)}),
+			WarningMessage: caller.T(i18n.MsgLocationWarning, i18n.Args{"distance": caller.Meters(distanceMeters)}),
 		}
 	}
 
 	return models.ComplianceResult{}
 }
+
+// validateCredentials checks the caregiver holds every credential the service requires on the clock-in date.
+// Missing credentials block the clock-in or are only flagged, depending on the configured enforcement.
+func (s *service) validateCredentials(ctx context.Context, cfg config.ServiceConfig, sch *models.Schedule, clockInTime time.Time) (models.ComplianceResult, error) {
+	required, err := s.credentialRepo.GetRequiredTypes(ctx, sch.ServiceName)
+	if err != nil {
+		return models.ComplianceResult{}, fmt.Errorf("failed to get required credentials: %w", err)
+	}
+	if len(required) == 0 {
+		return models.ComplianceResult{}, nil
+	}
+
+	valid, err := s.credentialRepo.GetValidForUser(ctx, sch.UserID, clockInTime)
+	if err != nil {
+		return models.ComplianceResult{}, fmt.Errorf("failed to get valid credentials: %w", err)
+	}
+
+	held := make(map[int64]bool, len(valid))
+	for _, c := range valid {
+		held[c.CredentialTypeID] = true
+	}
+
+	var missing []string
+	for _, t := range required {
+		if !held[t.ID] {
+			missing = append(missing, t.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return models.ComplianceResult{}, nil
+	}
+
+	if cfg.CredentialEnforcement != models.CredentialEnforcementFlag {
+		return models.ComplianceResult{}, fmt.Errorf("%w: %s", models.ErrMissingCredential, strings.Join(missing, ", "))
+	}
+
+	credentials := strings.Join(missing, ", ")
+	return models.ComplianceResult{
+		Flags: models.ComplianceCredentialWarn,
+		Notes: s.notesLocale().T(i18n.MsgCredentialsMissingNote, i18n.Args{
+			"service":     sch.ServiceName,
+			"credentials": credentials,
+		}),
+		WarningMessage: i18n.FromContext(ctx).T(i18n.MsgCredentialsMissingWarning, i18n.Args{"credentials": credentials}),
+	}, nil
+}
+
+// validateAttestation applies the configured policy to a clock-out without client attestation
+func (s *service) validateAttestation(ctx context.Context, req *models.AttestationRequest) (models.ComplianceResult, error) {
+	if req != nil {
+		if req.Signature == nil && req.UnableToSignReason == "" {
+			return models.ComplianceResult{}, models.ErrInvalidAttestation
+		}
+		if req.Signature == nil {
+			return models.ComplianceResult{
+				Notes: s.notesLocale().T(i18n.MsgClientUnableToSignNote, i18n.Args{"reason": req.UnableToSignReason}),
+			}, nil
+		}
+		return models.ComplianceResult{}, nil
+	}
+
+	switch s.cfg.AttestationPolicy {
+	case models.AttestationPolicyBlock:
+		return models.ComplianceResult{}, models.ErrAttestationRequired
+	case models.AttestationPolicyFlag:
+		return models.ComplianceResult{
+			Flags:          models.ComplianceAttestationMissing,
+			Notes:          s.notesLocale().T(i18n.MsgAttestationMissingNote, nil),
+			WarningMessage: i18n.FromContext(ctx).T(i18n.MsgAttestationMissingWarning, nil),
+		}, nil
+	default:
+		return models.ComplianceResult{}, nil
+	}
+}
+
+func buildAttestation(cfg config.ServiceConfig, scheduleID int64, req *models.AttestationRequest, capturedAt time.Time) (*models.Attestation, error) {
+	attestation := &models.Attestation{
+		ScheduleID: scheduleID,
+		UnableToSignReason: sql.NullString{
+			String: req.UnableToSignReason,
+			Valid:  req.UnableToSignReason != "" && req.Signature == nil,
+		},
+		CapturedAt: capturedAt,
+	}
+
+	if req.Signature != nil {
+		if req.SignerName == "" {
+			return nil, models.ErrInvalidAttestation
+		}
+		if !models.IsValidSignerRelationship(req.SignerRelationship) {
+			return nil, models.ErrInvalidSignerRelationship
+		}
+
+		data, err := encodeSignature(cfg, req.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		attestation.SignerName = sql.NullString{String: req.SignerName, Valid: true}
+		attestation.SignerRelationship = sql.NullString{String: req.SignerRelationship, Valid: true}
+		attestation.SignatureFormat = sql.NullString{String: req.Signature.Format, Valid: true}
+		attestation.SignatureData = sql.NullString{String: data, Valid: true}
+	}
+
+	if req.CaregiverSignature != nil {
+		data, err := encodeSignature(cfg, req.CaregiverSignature)
+		if err != nil {
+			return nil, err
+		}
+
+		attestation.CaregiverSignatureFormat = sql.NullString{String: req.CaregiverSignature.Format, Valid: true}
+		attestation.CaregiverSignatureData = sql.NullString{String: data, Valid: true}
+	}
+
+	return attestation, nil
+}
+
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+// encodeSignature validates a signature and returns it in its stored form:
+// JSON for vector strokes and plain base64 for PNG images
+func encodeSignature(cfg config.ServiceConfig, sig *models.SignatureRequest) (string, error) {
+	switch sig.Format {
+	case models.SignatureFormatStrokes:
+		points := 0
+		for _, stroke := range sig.Strokes {
+			points += len(stroke)
+		}
+		if points == 0 {
+			return "", models.ErrInvalidSignature
+		}
+
+		data, err := json.Marshal(sig.Strokes)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode signature strokes: %w", err)
+		}
+		return string(data), nil
+
+	case models.SignatureFormatPNG:
+		encoded := strings.TrimPrefix(sig.PNG, "data:image/png;base64,")
+		img, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || !bytes.HasPrefix(img, pngMagic) {
+			return "", models.ErrInvalidSignature
+		}
+		if cfg.MaxSignatureBytes > 0 && len(img) > cfg.MaxSignatureBytes {
+			return "", models.ErrSignatureTooLarge
+		}
+		return encoded, nil
+
+	default:
+		return "", models.ErrInvalidSignature
+	}
+}
+
+// visitEvents builds the outbox events of a clock-in or clock-out, adding visit.flagged when it raised compliance flags
+func visitEvents(eventType string, sch *models.Schedule, update models.Schedule, newFlags []string) ([]models.OutboxEvent, error) {
+	data := models.VisitEventData{
+		ScheduleID:      sch.ID,
+		UserID:          sch.UserID,
+		ClientName:      sch.ClientName,
+		ServiceName:     sch.ServiceName,
+		Status:          update.Status,
+		StartTime:       sch.StartTime,
+		EndTime:         sch.EndTime,
+		ComplianceFlags: update.ComplianceFlags,
+		NewFlags:        newFlags,
+	}
+	if data.ComplianceFlags == nil {
+		data.ComplianceFlags = []string{}
+	}
+	clockIn := sch.ClockInTime
+	if update.ClockInTime.Valid {
+		clockIn = update.ClockInTime
+	}
+	if clockIn.Valid {
+		data.ClockInTime = &clockIn.Time
+	}
+	if update.ClockOutTime.Valid {
+		data.ClockOutTime = &update.ClockOutTime.Time
+	}
+	if update.ActualMinutes.Valid {
+		data.ActualMinutes = &update.ActualMinutes.Int64
+	}
+
+	eventTypes := []string{eventType}
+	if len(newFlags) > 0 {
+		eventTypes = append(eventTypes, models.EventVisitFlagged)
+	}
+
+	events := make([]models.OutboxEvent, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		event, err := models.NewOutboxEvent(t, sch.ID, data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// notesLocale is the language compliance notes are stored in, shared by everyone who reviews the visit
+func (s *service) notesLocale() i18n.Locale {
+	locale, ok := i18n.Parse(s.cfg.ComplianceNotesLocale)
+	if !ok {
+		return i18n.Default
+	}
+	return locale
+}
+
+func appendNote(notes sql.NullString, note string) sql.NullString {
+	if notes.Valid && notes.String != "" {
+		return sql.NullString{String: notes.String + "; " + note, Valid: true}
+	}
+	return sql.NullString{String: note, Valid: true}
+}
+
+// validateRequiredTasks applies the configured policy to required tasks left pending at clock-out
+func (s *service) validateRequiredTasks(ctx context.Context, scheduleID int64) (models.ComplianceResult, error) {
+	tasks, err := s.taskRepo.GetAll(ctx, scheduleID)
+	if err != nil {
+		return models.ComplianceResult{}, err
+	}
+
+	pending := models.PendingRequired(tasks)
+	if len(pending) == 0 {
+		return models.ComplianceResult{}, nil
+	}
+
+	if s.cfg.RequiredTaskPolicy != models.RequiredTaskPolicyFlag {
+		resp := make([]models.TaskResponse, len(pending))
+		for i, t := range pending {
+			resp[i] = t.ToTaskResponse()
+		}
+		return models.ComplianceResult{}, &models.RequiredTasksPendingError{Tasks: resp}
+	}
+
+	names := make([]string, len(pending))
+	for i, t := range pending {
+		names[i] = t.Name
+	}
+	caller := i18n.FromContext(ctx)
+	return models.ComplianceResult{
+		Flags:          models.ComplianceTasksPending,
+		Notes:          s.notesLocale().T(i18n.MsgTasksPendingNote, i18n.Args{"tasks": strings.Join(names, ", ")}),
+		WarningMessage: caller.T(i18n.MsgTasksPendingWarning, i18n.Args{"count": caller.Integer(int64(len(pending)))}),
+	}, nil
+}
+
+// buildTasks validates the planned tasks of a new visit and numbers them in the order given
+func buildTasks(reqs []models.CreateTaskRequest) ([]models.Task, error) {
+	tasks := make([]models.Task, len(reqs))
+	for i, req := range reqs {
+		taskType := req.TaskType
+		if taskType == "" {
+			taskType = models.TaskTypeGeneral
+		}
+		if err := models.ValidateTaskOptions(taskType, req.Options); err != nil {
+			return nil, err
+		}
+
+		tasks[i] = models.Task{
+			Name: req.Name,
+			Description: sql.NullString{
+				String: req.Description,
+				Valid:  req.Description != "",
+			},
+			IsRequired: req.Required,
+			SortOrder:  i + 1,
+			TaskType:   taskType,
+		}
+
+		if req.Options != nil {
+			options, err := json.Marshal(req.Options)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode task options: %w", err)
+			}
+			tasks[i].Options = types.NullJSONText{JSONText: options, Valid: true}
+		}
+	}
+
+	return tasks, nil
+}
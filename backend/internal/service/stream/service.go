@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/events"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/outbox"
+)
+
+const (
+	defaultHeartbeat   = 15 * time.Second
+	defaultReplayLimit = 500
+)
+
+type Service interface {
+	Open(ctx context.Context, req *models.StreamQueryRequest, lastEventID int64) (*Stream, error)
+	Heartbeat() time.Duration
+}
+
+type service struct {
+	cfg        config.StreamConfig
+	bus        *events.Bus
+	outboxRepo outbox.Repository
+}
+
+func New(cfg config.StreamConfig, bus *events.Bus, outboxRepo outbox.Repository) Service {
+	return &service{cfg: cfg, bus: bus, outboxRepo: outboxRepo}
+}
+
+// Stream is one coordinator's view of the visit events: what they missed since their last event, then live events
+type Stream struct {
+	Filter models.StreamFilter
+	Replay []models.StreamEvent
+	// LastID is the newest event already covered by the replay; live events up to it are duplicates
+	LastID int64
+	// Reset is set instead of Replay when the client missed more than the replay limit
+	Reset bool
+	sub   *events.Subscription
+}
+
+// Events delivers live events, not yet filtered; it is closed when the subscriber falls too far behind
+func (s *Stream) Events() <-chan models.StreamEvent {
+	return s.sub.Events()
+}
+
+func (s *Stream) Close() {
+	s.sub.Close()
+}
+
+// Open subscribes to live events before reading the replay, so nothing committed in between is lost.
+// When more than the configured replay limit of events were missed, none are replayed and the stream is marked Reset.
+func (s *service) Open(ctx context.Context, req *models.StreamQueryRequest, lastEventID int64) (*Stream, error) {
+	filter := models.StreamFilter{
+		UserID:     req.CaregiverID,
+		ScheduleID: req.ScheduleID,
+		ClientName: strings.TrimSpace(req.Client),
+	}
+	if req.Types != "" {
+		filter.Types = make(map[string]bool)
+		for _, t := range strings.Split(req.Types, ",") {
+			t = strings.TrimSpace(t)
+			if !models.IsValidEventType(t) {
+				return nil, models.ErrInvalidEventType
+			}
+			filter.Types[t] = true
+		}
+	}
+
+	stream := &Stream{Filter: filter, LastID: lastEventID, sub: s.bus.Subscribe()}
+	if lastEventID <= 0 {
+		return stream, nil
+	}
+
+	replayLimit := s.cfg.ReplayLimit
+	if replayLimit <= 0 {
+		replayLimit = defaultReplayLimit
+	}
+	// one more than the limit tells whether the client missed too much to catch up
+	missed, err := s.outboxRepo.GetEventsAfter(ctx, lastEventID, replayLimit+1)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+	if len(missed) > replayLimit {
+		stream.Reset = true
+		return stream, nil
+	}
+	for i := range missed {
+		stream.LastID = missed[i].ID
+		if event := missed[i].ToStreamEvent(); filter.Matches(event) {
+			stream.Replay = append(stream.Replay, event)
+		}
+	}
+
+	return stream, nil
+}
+
+// Heartbeat is how often an idle stream sends a comment to keep proxies from closing it
+func (s *service) Heartbeat() time.Duration {
+	if s.cfg.HeartbeatSeconds <= 0 {
+		return defaultHeartbeat
+	}
+	return s.cfg.HeartbeatSeconds * time.Second
+}
@@ -3,57 +3,208 @@ package task
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
 	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/monitoring"
 	"github.com/erizkiatama/bluehorntech/internal/repository/schedule"
 	"github.com/erizkiatama/bluehorntech/internal/repository/task"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+	"github.com/jmoiron/sqlx/types"
 )
 
 type Service interface {
 	UpdateTask(ctx context.Context, userID, taskID int64, req *models.UpdateTaskRequest) (*models.TaskResponse, error)
+	UpdateTasks(ctx context.Context, userID, scheduleID int64, req *models.BulkUpdateTasksRequest) ([]models.TaskResponse, error)
+	GetHistory(ctx context.Context, userID, taskID int64) ([]models.TaskStatusChangeResponse, error)
+	RequestCorrection(ctx context.Context, userID, taskID int64, req *models.UpdateTaskRequest) (*models.TaskCorrectionResponse, error)
+	GetCorrections(ctx context.Context, reviewerID int64, status string) ([]models.TaskCorrectionResponse, error)
+	ReviewCorrection(ctx context.Context, reviewerID, correctionID int64, approve bool, req *models.ReviewTaskCorrectionRequest) (*models.TaskCorrectionResponse, error)
 }
 
 type service struct {
+	cfg          config.ServiceConfig
 	taskRepo     task.Repository
 	scheduleRepo schedule.Repository
+	userRepo     user.Repository
+	metrics      *monitoring.Metrics
 }
 
-func New(taskRepo task.Repository, scheduleRepo schedule.Repository) Service {
-	return &service{taskRepo: taskRepo, scheduleRepo: scheduleRepo}
+func New(cfg config.ServiceConfig, taskRepo task.Repository, scheduleRepo schedule.Repository, userRepo user.Repository, metrics *monitoring.Metrics) Service {
+	return &service{cfg: cfg, taskRepo: taskRepo, scheduleRepo: scheduleRepo, userRepo: userRepo, metrics: metrics}
 }
 
-// UpdateTask handles the business logic for updating a task
+// UpdateTask handles the business logic for updating a task.
+// A task's outcome can be changed freely while the visit is in progress (with a reason once it has one);
+// after clock-out it can only be corrected through RequestCorrection and a supervisor's approval.
 func (s *service) UpdateTask(ctx context.Context, userID, taskID int64, req *models.UpdateTaskRequest) (*models.TaskResponse, error) {
 	tsk, err := s.taskRepo.GetByID(ctx, taskID)
 	if err != nil {
-		return nil, models.ErrTaskNotFound
+		return nil, err
 	}
 
 	sch, err := s.scheduleRepo.GetByID(ctx, tsk.ScheduleID, userID)
 	if err != nil {
-		return nil, models.ErrScheduleNotFound
+		return nil, err
 	}
 
-	if sch.Status != models.StatusInProgress {
-		return nil, models.ErrVisitNotInProgress
+	update, err := s.prepareUpdate(userID, sch, tsk, req, time.Now().UTC())
+	if err != nil {
+		return nil, err
 	}
 
-	if tsk.Status != models.TaskStatusPending {
+	updated, err := s.taskRepo.UpdateTask(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		// changed by someone else since it was read
 		return nil, models.ErrTaskAlreadyUpdated
 	}
+	s.metrics.TaskOutcome(update.Task.Status)
+
+	applyUpdate(tsk, update.Task)
+	response := tsk.ToTaskResponse()
+	return &response, nil
+}
+
+// UpdateTasks validates every item first and reports all invalid ones together;
+// only when all are valid are they saved, in a single transaction
+func (s *service) UpdateTasks(ctx context.Context, userID, scheduleID int64, req *models.BulkUpdateTasksRequest) ([]models.TaskResponse, error) {
+	sch, err := s.scheduleRepo.GetByID(ctx, scheduleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetAll(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	tasksByID := make(map[int64]*models.Task, len(tasks))
+	for i := range tasks {
+		tasksByID[tasks[i].ID] = &tasks[i]
+	}
+
+	var (
+		now     = time.Now().UTC()
+		locale  = i18n.FromContext(ctx)
+		updates = make([]models.TaskUpdate, 0, len(req.Tasks))
+		invalid []models.TaskUpdateItemError
+		seen    = make(map[int64]bool, len(req.Tasks))
+		// addError collects a rejected item; anything outside the error catalogue is not the item's fault
+		// and is returned to abort the whole request
+		addError = func(i int, taskID int64, err error) error {
+			def, ok := models.LookupError(err)
+			if !ok {
+				return err
+			}
+			invalid = append(invalid, models.TaskUpdateItemError{
+				Index:  i,
+				TaskID: taskID,
+				Code:   def.Code,
+				Error:  def.LocalizedMessage(locale, err),
+			})
+			return nil
+		}
+	)
+
+	for i := range req.Tasks {
+		item := &req.Tasks[i]
+
+		tsk, ok := tasksByID[item.TaskID]
+		if !ok {
+			if err = addError(i, item.TaskID, models.ErrTaskNotInSchedule); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if seen[item.TaskID] {
+			if err = addError(i, item.TaskID, models.ErrDuplicateTaskUpdate); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		seen[item.TaskID] = true
+
+		if err = models.ValidateTaskStatus(item.Status, item.Reason); err != nil {
+			if err = addError(i, item.TaskID, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		update, err := s.prepareUpdate(userID, sch, tsk, &item.UpdateTaskRequest, now)
+		if err != nil {
+			if err = addError(i, item.TaskID, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		updates = append(updates, update)
+	}
+
+	if len(invalid) > 0 {
+		return nil, &models.TaskUpdateItemsError{Items: invalid}
+	}
+
+	updated, err := s.taskRepo.UpdateTasks(ctx, updates)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, models.ErrTaskAlreadyUpdated
+	}
+
+	for _, update := range updates {
+		s.metrics.TaskOutcome(update.Task.Status)
+		applyUpdate(tasksByID[update.Task.ID], update.Task)
+	}
+
+	resp := make([]models.TaskResponse, len(tasks))
+	for i, t := range tasks {
+		resp[i] = t.ToTaskResponse()
+	}
+
+	return resp, nil
+}
+
+// prepareUpdate checks that the caregiver may change the outcome and builds the new task state with its audit record
+func (s *service) prepareUpdate(userID int64, sch *models.Schedule, tsk *models.Task, req *models.UpdateTaskRequest, now time.Time) (models.TaskUpdate, error) {
+	if err := s.checkChangeAllowed(sch, tsk, req, now); err != nil {
+		return models.TaskUpdate{}, err
+	}
+
+	return s.buildUpdate(userID, sql.NullInt64{}, sch, tsk, req, now)
+}
+
+// buildUpdate builds the new task state with its audit record and outbox events
+func (s *service) buildUpdate(userID int64, approvedBy sql.NullInt64, sch *models.Schedule, tsk *models.Task, req *models.UpdateTaskRequest, now time.Time) (models.TaskUpdate, error) {
+	if err := tsk.ValidateValue(req.Status, req.Value); err != nil {
+		return models.TaskUpdate{}, err
+	}
 
 	updateData := &models.Task{
-		ID:     taskID,
+		ID:     tsk.ID,
 		Status: req.Status,
 	}
+	if len(req.Value) > 0 && string(req.Value) != "null" {
+		updateData.Value = types.NullJSONText{JSONText: types.JSONText(req.Value), Valid: true}
+	}
 
-	// Set completion time only for completed tasks
+	// Set completion time only for completed tasks, keeping the original time when it already was,
 	// set reason only for non completed tasks
 	switch req.Status {
 	case models.TaskStatusCompleted:
+		completedAt := now
+		if tsk.IsCompleted() && tsk.CompletedAt.Valid {
+			completedAt = tsk.CompletedAt.Time
+		}
 		updateData.CompletedAt = sql.NullTime{
-			Time:  time.Now().UTC(),
+			Time:  completedAt,
 			Valid: true,
 		}
 	case models.TaskStatusNotCompleted:
@@ -63,17 +214,280 @@ func (s *service) UpdateTask(ctx context.Context, userID, taskID int64, req *mod
 		}
 	}
 
-	err = s.taskRepo.UpdateTask(ctx, updateData)
+	change := &models.TaskStatusChange{
+		TaskID:     tsk.ID,
+		ScheduleID: tsk.ScheduleID,
+		ChangedBy:  userID,
+		ApprovedBy: approvedBy,
+		FromStatus: tsk.Status,
+		ToStatus:   updateData.Status,
+		FromReason: tsk.Reason,
+		ToReason:   updateData.Reason,
+		FromValue:  tsk.Value,
+		ToValue:    updateData.Value,
+		ChangeReason: sql.NullString{
+			String: strings.TrimSpace(req.CorrectionReason),
+			Valid:  strings.TrimSpace(req.CorrectionReason) != "",
+		},
+	}
+
+	update := models.TaskUpdate{Task: updateData, Change: change, ScheduleStatus: sch.Status}
+
+	eventTypes := []string{models.EventTaskUpdated}
+	// task.not_completed is raised when a task becomes not completed, not on edits to its reason
+	if updateData.Status == models.TaskStatusNotCompleted && tsk.Status != models.TaskStatusNotCompleted {
+		eventTypes = append(eventTypes, models.EventTaskNotCompleted)
+	}
+	eventData := models.TaskEventData{
+		TaskID:         tsk.ID,
+		ScheduleID:     tsk.ScheduleID,
+		UserID:         sch.UserID,
+		ClientName:     sch.ClientName,
+		Name:           tsk.Name,
+		PreviousStatus: tsk.Status,
+		Status:         updateData.Status,
+		Reason:         updateData.Reason.String,
+		ChangedBy:      userID,
+	}
+	for _, t := range eventTypes {
+		event, err := models.NewOutboxEvent(t, tsk.ScheduleID, eventData)
+		if err != nil {
+			return models.TaskUpdate{}, err
+		}
+		update.Events = append(update.Events, event)
+	}
+
+	return update, nil
+}
+
+func applyUpdate(tsk, updateData *models.Task) {
+	tsk.Status = updateData.Status
+	tsk.Reason = updateData.Reason
+	tsk.CompletedAt = updateData.CompletedAt
+	tsk.Value = updateData.Value
+}
+
+func (s *service) GetHistory(ctx context.Context, userID, taskID int64) ([]models.TaskStatusChangeResponse, error) {
+	tsk, err := s.taskRepo.GetByID(ctx, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &models.TaskResponse{
-		ID:          taskID,
-		Status:      req.Status,
-		Reason:      req.Reason,
-		CompletedAt: updateData.CompletedAt.Time,
+	if _, err = s.scheduleRepo.GetByID(ctx, tsk.ScheduleID, userID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.taskRepo.GetHistory(ctx, taskID)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	resp := make([]models.TaskStatusChangeResponse, len(changes))
+	for i, c := range changes {
+		resp[i] = c.ToTaskStatusChangeResponse()
+	}
+
+	return resp, nil
+}
+
+// checkChangeAllowed decides whether the caregiver may change the task's outcome directly.
+// Once the visit is clocked out a change has to go through RequestCorrection instead.
+func (s *service) checkChangeAllowed(sch *models.Schedule, tsk *models.Task, req *models.UpdateTaskRequest, now time.Time) error {
+	switch sch.Status {
+	case models.StatusInProgress:
+		if !tsk.IsPending() && strings.TrimSpace(req.CorrectionReason) == "" {
+			return models.ErrCorrectionReasonRequired
+		}
+		return nil
+
+	case models.StatusCompleted:
+		if err := s.checkCorrectionWindow(sch, now); err != nil {
+			return err
+		}
+		return models.ErrApprovalRequired
+
+	default:
+		return models.ErrVisitNotInProgress
+	}
+}
+
+// checkCorrectionWindow reports whether the visit's tasks can still be corrected after clock-out
+func (s *service) checkCorrectionWindow(sch *models.Schedule, now time.Time) error {
+	window := s.cfg.TaskCorrectionSeconds * time.Second
+	if window <= 0 || !sch.ClockOutTime.Valid || now.After(sch.ClockOutTime.Time.Add(window)) {
+		return models.ErrCorrectionWindowClosed
+	}
+	return nil
+}
+
+// RequestCorrection records a change to a task's outcome after clock-out; it is applied once a supervisor approves it
+func (s *service) RequestCorrection(ctx context.Context, userID, taskID int64, req *models.UpdateTaskRequest) (*models.TaskCorrectionResponse, error) {
+	tsk, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := s.scheduleRepo.GetByID(ctx, tsk.ScheduleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sch.Status {
+	case models.StatusCompleted:
+	case models.StatusInProgress:
+		return nil, models.ErrCorrectionNotNeeded
+	default:
+		return nil, models.ErrVisitNotInProgress
+	}
+
+	if err = s.checkCorrectionWindow(sch, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	correctionReason := strings.TrimSpace(req.CorrectionReason)
+	if correctionReason == "" {
+		return nil, models.ErrCorrectionReasonRequired
+	}
+	if err = models.ValidateTaskStatus(req.Status, req.Reason); err != nil {
+		return nil, err
+	}
+	if err = tsk.ValidateValue(req.Status, req.Value); err != nil {
+		return nil, err
+	}
+
+	correction := &models.TaskCorrection{
+		TaskID:           tsk.ID,
+		ScheduleID:       tsk.ScheduleID,
+		RequestedBy:      userID,
+		FromStatus:       tsk.Status,
+		ToStatus:         req.Status,
+		CorrectionReason: correctionReason,
+		Status:           models.CorrectionStatusPending,
+	}
+	if req.Status == models.TaskStatusNotCompleted {
+		correction.ToReason = sql.NullString{String: req.Reason, Valid: true}
+	}
+	if len(req.Value) > 0 && string(req.Value) != "null" {
+		correction.ToValue = types.NullJSONText{JSONText: types.JSONText(req.Value), Valid: true}
+	}
+
+	created, err := s.taskRepo.CreateCorrection(ctx, correction)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, models.ErrCorrectionPending
+	}
+
+	resp := correction.ToTaskCorrectionResponse()
+	return &resp, nil
+}
+
+func (s *service) GetCorrections(ctx context.Context, reviewerID int64, status string) ([]models.TaskCorrectionResponse, error) {
+	if status == "" {
+		status = models.CorrectionStatusPending
+	}
+	if !models.IsValidCorrectionStatus(status) {
+		return nil, models.ErrInvalidCorrectionStatus
+	}
+
+	if err := s.checkReviewer(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
+	corrections, err := s.taskRepo.GetCorrections(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.TaskCorrectionResponse, len(corrections))
+	for i, c := range corrections {
+		resp[i] = c.ToTaskCorrectionResponse()
+	}
+
+	return resp, nil
+}
+
+// ReviewCorrection approves or rejects a pending correction. The reviewer must be a supervisor other than
+// the caregiver who asked for it; an approved correction is applied as a change by that caregiver, approved by the reviewer.
+func (s *service) ReviewCorrection(ctx context.Context, reviewerID, correctionID int64, approve bool, req *models.ReviewTaskCorrectionRequest) (*models.TaskCorrectionResponse, error) {
+	if err := s.checkReviewer(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
+	correction, err := s.taskRepo.GetCorrection(ctx, correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if correction.Status != models.CorrectionStatusPending {
+		return nil, models.ErrCorrectionAlreadyReviewed
+	}
+	if correction.RequestedBy == reviewerID {
+		return nil, models.ErrInvalidApprover
+	}
+
+	now := time.Now().UTC()
+	correction.ReviewedBy = sql.NullInt64{Int64: reviewerID, Valid: true}
+	correction.ReviewedAt = sql.NullTime{Time: now, Valid: true}
+	correction.ReviewNote = sql.NullString{String: strings.TrimSpace(req.Note), Valid: strings.TrimSpace(req.Note) != ""}
+
+	if !approve {
+		correction.Status = models.CorrectionStatusRejected
+		reviewed, err := s.taskRepo.RejectCorrection(ctx, correction)
+		if err != nil {
+			return nil, err
+		}
+		if !reviewed {
+			return nil, models.ErrCorrectionAlreadyReviewed
+		}
+
+		resp := correction.ToTaskCorrectionResponse()
+		return &resp, nil
+	}
+
+	tsk, err := s.taskRepo.GetByID(ctx, correction.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if tsk.Status != correction.FromStatus {
+		// changed since the correction was requested, so it no longer describes the task
+		return nil, models.ErrTaskAlreadyUpdated
+	}
+
+	sch, err := s.scheduleRepo.GetByID(ctx, correction.ScheduleID, correction.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := s.buildUpdate(correction.RequestedBy, correction.ReviewedBy, sch, tsk, correction.Request(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	correction.Status = models.CorrectionStatusApproved
+	reviewed, err := s.taskRepo.ApproveCorrection(ctx, correction, update)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		return nil, models.ErrTaskAlreadyUpdated
+	}
+	s.metrics.TaskOutcome(update.Task.Status)
+
+	resp := correction.ToTaskCorrectionResponse()
+	return &resp, nil
+}
+
+// checkReviewer makes sure the user may review task corrections
+func (s *service) checkReviewer(ctx context.Context, reviewerID int64) error {
+	reviewer, err := s.userRepo.GetByID(ctx, reviewerID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return models.ErrSupervisorRequired
+	}
+	if err != nil {
+		return err
+	}
+	if !reviewer.CanReview() {
+		return models.ErrSupervisorRequired
+	}
+	return nil
 }
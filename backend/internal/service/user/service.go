@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/user"
+)
+
+type Service interface {
+	UpdateLocale(ctx context.Context, userID int64, req *models.UpdateLocaleRequest) (*models.UserLocaleResponse, error)
+}
+
+type service struct {
+	userRepo user.Repository
+}
+
+func New(userRepo user.Repository) Service {
+	return &service{userRepo: userRepo}
+}
+
+// UpdateLocale saves the language the user wants messages in, accepting tags such as "es-MX"
+func (s *service) UpdateLocale(ctx context.Context, userID int64, req *models.UpdateLocaleRequest) (*models.UserLocaleResponse, error) {
+	locale, ok := i18n.Parse(req.Locale)
+	if !ok {
+		return nil, models.ErrUnsupportedLocale
+	}
+
+	updated, err := s.userRepo.UpdateLocale(ctx, userID, string(locale))
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, models.ErrUserNotFound
+	}
+
+	return &models.UserLocaleResponse{
+		UserID: userID,
+		Locale: string(locale),
+	}, nil
+}
@@ -0,0 +1,238 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/webhook"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 50
+	defaultMaxAttempts  = 8
+	defaultRetryBase    = 30 * time.Second
+	defaultRetryMax     = time.Hour
+	defaultTimeout      = 10 * time.Second
+
+	maxErrorLength = 500
+)
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret.
+// Signing the timestamp lets receivers reject replayed deliveries.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of the delivery, comparing in constant time
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Dispatcher delivers queued webhook deliveries, retrying failures with exponential backoff
+// until they succeed or run out of attempts and become dead letters
+type Dispatcher struct {
+	webhookRepo  webhook.Repository
+	client       *http.Client
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	retryBase    time.Duration
+	retryMax     time.Duration
+	timeout      time.Duration
+}
+
+func NewDispatcher(cfg config.WebhookConfig, webhookRepo webhook.Repository, client *http.Client) *Dispatcher {
+	d := &Dispatcher{
+		webhookRepo:  webhookRepo,
+		client:       client,
+		pollInterval: secondsOr(cfg.PollIntervalSeconds, defaultPollInterval),
+		batchSize:    cfg.BatchSize,
+		maxAttempts:  cfg.MaxAttempts,
+		retryBase:    secondsOr(cfg.RetryBaseSeconds, defaultRetryBase),
+		retryMax:     secondsOr(cfg.RetryMaxSeconds, defaultRetryMax),
+		timeout:      secondsOr(cfg.TimeoutSeconds, defaultTimeout),
+	}
+	if d.batchSize <= 0 {
+		d.batchSize = defaultBatchSize
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = defaultMaxAttempts
+	}
+	if d.client == nil {
+		d.client = &http.Client{Timeout: d.timeout}
+	}
+
+	return d
+}
+
+func secondsOr(seconds, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return seconds * time.Second
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while there is a backlog, then wait for the next poll
+		for {
+			n, err := d.DispatchDue(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to dispatch webhooks", "error", err)
+			}
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends one batch of due deliveries concurrently and returns how many it sent
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	// a claimed delivery is hidden from other dispatchers for longer than it can take to send
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.batchSize, 2*d.timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	statusCode, err := d.send(ctx, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastStatusCode = sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+		delivery.LastError = sql.NullString{}
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = models.DeliveryStatusDead
+		delivery.LastError = errorText(err)
+		slog.WarnContext(ctx, "Webhook delivery is dead", "delivery_id", delivery.ID, "event_id", delivery.EventID,
+			"url", delivery.URL, "attempts", delivery.Attempts, "error", err)
+	default:
+		delivery.Status = models.DeliveryStatusPending
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+		delivery.LastError = errorText(err)
+	}
+
+	// recorded even when ctx is cancelled mid-send, so the attempt is not lost
+	if err = d.webhookRepo.RecordAttempt(context.WithoutCancel(ctx), delivery); err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts the signed event and returns the response status, failing on anything but 2xx
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(models.WebhookEnvelope{
+		ID:        delivery.EventID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.EventCreatedAt,
+		Data:      json.RawMessage(delivery.Payload),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bluehorntech-webhooks")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return resp.StatusCode, fmt.Errorf("webhook receiver responded %d: %s", resp.StatusCode, snippet)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the wait after every failed attempt, up to the configured maximum
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.retryBase
+	for i := 1; i < attempts && wait < d.retryMax; i++ {
+		wait *= 2
+	}
+	if wait > d.retryMax {
+		wait = d.retryMax
+	}
+	return wait
+}
+
+func errorText(err error) sql.NullString {
+	text := err.Error()
+	if len(text) > maxErrorLength {
+		text = text[:maxErrorLength]
+	}
+	return sql.NullString{String: text, Valid: true}
+}
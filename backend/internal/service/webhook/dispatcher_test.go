@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	const (
+		secret    = "whsec_test"
+		timestamp = "1754049600"
+	)
+	body := []byte(`{"id":1,"type":"visit.started"}`)
+	signature := Sign(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: secret, timestamp: timestamp, body: body, signature: signature, want: true},
+		{name: "other secret", secret: "whsec_other", timestamp: timestamp, body: body, signature: signature, want: false},
+		{name: "replayed with a new timestamp", secret: secret, timestamp: "1754049601", body: body, signature: signature, want: false},
+		{name: "tampered body", secret: secret, timestamp: timestamp, body: []byte(`{"id":2,"type":"visit.started"}`), signature: signature, want: false},
+		{name: "missing prefix", secret: secret, timestamp: timestamp, body: body, signature: signature[len("sha256="):], want: false},
+		{name: "empty signature", secret: secret, timestamp: timestamp, body: body, signature: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.timestamp, tt.body, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(config.WebhookConfig{RetryBaseSeconds: 30, RetryMaxSeconds: 3600}, nil, nil)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 50, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffDefaults(t *testing.T) {
+	d := NewDispatcher(config.WebhookConfig{}, nil, nil)
+
+	if got := d.backoff(1); got != defaultRetryBase {
+		t.Errorf("backoff(1) = %v, want %v", got, defaultRetryBase)
+	}
+	if got := d.backoff(100); got != defaultRetryMax {
+		t.Errorf("backoff(100) = %v, want %v", got, defaultRetryMax)
+	}
+}
+
+// stubRepository records the attempts of the deliveries it hands out; other methods are not used by the dispatcher
+type stubRepository struct {
+	webhook.Repository
+
+	mu         sync.Mutex
+	deliveries []models.WebhookDelivery
+	recorded   []models.WebhookDelivery
+}
+
+func (r *stubRepository) ClaimDueDeliveries(_ context.Context, limit int, _ time.Duration) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.deliveries) > limit {
+		return r.deliveries[:limit], nil
+	}
+	return r.deliveries, nil
+}
+
+func (r *stubRepository) RecordAttempt(_ context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recorded = append(r.recorded, *delivery)
+	return nil
+}
+
+func TestDispatchDue(t *testing.T) {
+	const secret = "whsec_test"
+
+	tests := []struct {
+		name         string
+		status       int
+		attempts     int
+		wantStatus   string
+		wantAttempts int
+		wantRetryIn  time.Duration
+		wantError    bool
+	}{
+		{name: "delivered", status: http.StatusNoContent, wantStatus: models.DeliveryStatusDelivered, wantAttempts: 1},
+		{name: "first failure is retried after the base wait", status: http.StatusInternalServerError, wantStatus: models.DeliveryStatusPending, wantAttempts: 1, wantRetryIn: 30 * time.Second, wantError: true},
+		{name: "third failure waits four times as long", status: http.StatusBadGateway, attempts: 2, wantStatus: models.DeliveryStatusPending, wantAttempts: 3, wantRetryIn: 2 * time.Minute, wantError: true},
+		{name: "last attempt becomes a dead letter", status: http.StatusGone, attempts: 2, wantStatus: models.DeliveryStatusDead, wantAttempts: 3, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a dead letter only happens on the last attempt
+			maxAttempts := 5
+			if tt.wantStatus == models.DeliveryStatusDead {
+				maxAttempts = tt.attempts + 1
+			}
+
+			var signatureValid bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				signatureValid = Verify(secret, r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			repo := &stubRepository{deliveries: []models.WebhookDelivery{{
+				ID:        1,
+				EventID:   10,
+				Attempts:  tt.attempts,
+				EventType: models.EventVisitStarted,
+				Payload:   []byte(`{"schedule_id":1}`),
+				URL:       server.URL,
+				Secret:    secret,
+			}}}
+			d := NewDispatcher(config.WebhookConfig{MaxAttempts: maxAttempts, RetryBaseSeconds: 30, RetryMaxSeconds: 3600}, repo, server.Client())
+
+			before := time.Now().UTC()
+			n, err := d.DispatchDue(context.Background())
+			if err != nil || n != 1 {
+				t.Fatalf("DispatchDue() = %d, %v, want 1, nil", n, err)
+			}
+
+			if !signatureValid {
+				t.Error("receiver could not verify the signature")
+			}
+			if len(repo.recorded) != 1 {
+				t.Fatalf("recorded %d attempts, want 1", len(repo.recorded))
+			}
+			got := repo.recorded[0]
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			if got.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got.Attempts, tt.wantAttempts)
+			}
+			if got.LastStatusCode.Int64 != int64(tt.status) {
+				t.Errorf("last status code = %d, want %d", got.LastStatusCode.Int64, tt.status)
+			}
+			if got.LastError.Valid != tt.wantError {
+				t.Errorf("last error = %q, want an error: %v", got.LastError.String, tt.wantError)
+			}
+			if tt.wantRetryIn > 0 {
+				if retryIn := got.NextAttemptAt.Sub(before); retryIn < tt.wantRetryIn || retryIn > tt.wantRetryIn+time.Minute {
+					t.Errorf("next attempt in %v, want about %v", retryIn, tt.wantRetryIn)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/internal/models"
+	"github.com/erizkiatama/bluehorntech/internal/repository/webhook"
+	"github.com/lib/pq"
+)
+
+const minSecretLength = 16
+
+type Service interface {
+	GetWebhooks(ctx context.Context) ([]models.WebhookSubscriptionResponse, error)
+	GetWebhook(ctx context.Context, id int64) (*models.WebhookSubscriptionResponse, error)
+	CreateWebhook(ctx context.Context, req *models.CreateWebhookRequest) (*models.WebhookSubscriptionResponse, error)
+	UpdateWebhook(ctx context.Context, id int64, req *models.UpdateWebhookRequest) (*models.WebhookSubscriptionResponse, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	PingWebhook(ctx context.Context, id int64) error
+	GetDeadLetters(ctx context.Context) ([]models.WebhookDeliveryResponse, error)
+	RetryDeadLetter(ctx context.Context, deliveryID int64) error
+}
+
+type service struct {
+	webhookRepo webhook.Repository
+}
+
+func New(webhookRepo webhook.Repository) Service {
+	return &service{webhookRepo: webhookRepo}
+}
+
+func (s *service) GetWebhooks(ctx context.Context) ([]models.WebhookSubscriptionResponse, error) {
+	subs, err := s.webhookRepo.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.WebhookSubscriptionResponse, len(subs))
+	for i, sub := range subs {
+		resp[i] = sub.ToWebhookSubscriptionResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *service) GetWebhook(ctx context.Context, id int64) (*models.WebhookSubscriptionResponse, error) {
+	sub, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := sub.ToWebhookSubscriptionResponse()
+	return &resp, nil
+}
+
+// CreateWebhook subscribes a URL to event types. Without a secret one is generated;
+// either way it is only returned here, for the receiver to verify signatures with.
+func (s *service) CreateWebhook(ctx context.Context, req *models.CreateWebhookRequest) (*models.WebhookSubscriptionResponse, error) {
+	sub := &models.WebhookSubscription{Active: true}
+	if err := applyWebhook(sub, req.URL, req.Secret, req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	resp := sub.ToWebhookSubscriptionResponse()
+	resp.Secret = sub.Secret
+	return &resp, nil
+}
+
+// UpdateWebhook replaces a subscription's URL and event types. A new secret rotates it and is returned once;
+// deliveries still pending are signed with the new secret.
+func (s *service) UpdateWebhook(ctx context.Context, id int64, req *models.UpdateWebhookRequest) (*models.WebhookSubscriptionResponse, error) {
+	sub, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := sub.Secret
+	if req.Secret != "" {
+		secret = req.Secret
+	}
+	if err = applyWebhook(sub, req.URL, secret, req.EventTypes); err != nil {
+		return nil, err
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+
+	if err = s.webhookRepo.UpdateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	resp := sub.ToWebhookSubscriptionResponse()
+	if req.Secret != "" {
+		resp.Secret = sub.Secret
+	}
+	return &resp, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.webhookRepo.DeleteSubscription(ctx, id)
+}
+
+// PingWebhook queues a webhook.ping event to one subscription so a receiver can check its signature verification
+func (s *service) PingWebhook(ctx context.Context, id int64) error {
+	sub, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	event, err := models.NewOutboxEvent(models.EventWebhookPing, 0, map[string]interface{}{
+		"subscription_id": sub.ID,
+		"sent_at":         time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.webhookRepo.EnqueueTo(ctx, sub.ID, event)
+}
+
+func (s *service) GetDeadLetters(ctx context.Context) ([]models.WebhookDeliveryResponse, error) {
+	deliveries, err := s.webhookRepo.GetDeadLetters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		resp[i] = d.ToWebhookDeliveryResponse()
+	}
+
+	return resp, nil
+}
+
+func (s *service) RetryDeadLetter(ctx context.Context, deliveryID int64) error {
+	return s.webhookRepo.RetryDeadLetter(ctx, deliveryID)
+}
+
+// applyWebhook validates and sets the URL, secret and event types of a subscription
+func applyWebhook(sub *models.WebhookSubscription, rawURL, secret string, eventTypes []string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.ErrInvalidWebhookURL
+	}
+
+	if len(eventTypes) == 0 {
+		return models.ErrInvalidEventType
+	}
+	seen := make(map[string]bool, len(eventTypes))
+	types := make(pq.StringArray, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		t = strings.TrimSpace(t)
+		if !models.IsValidEventType(t) {
+			return models.ErrInvalidEventType
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return err
+		}
+	}
+	if len(secret) < minSecretLength {
+		return models.ErrInvalidWebhookSecret
+	}
+
+	sub.URL = u.String()
+	sub.Secret = secret
+	sub.EventTypes = types
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_schedules_user_time_range;
+DROP TABLE IF EXISTS time_off_requests CASCADE;
+DROP TABLE IF EXISTS user_availability CASCADE;
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS user_availability (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    day_of_week SMALLINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_availability_day CHECK (day_of_week BETWEEN 0 AND 6),
+    CONSTRAINT chk_availability_range CHECK (start_time < end_time)
+);
+
+CREATE INDEX idx_user_availability_user_id ON user_availability(user_id);
+
+CREATE TABLE IF NOT EXISTS time_off_requests (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+
+    -- review data
+    reviewed_by INTEGER,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_time_off_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT chk_time_off_range CHECK (start_time < end_time)
+);
+
+CREATE INDEX idx_time_off_requests_user_id ON time_off_requests(user_id);
+CREATE INDEX idx_time_off_requests_range ON time_off_requests(start_time, end_time);
+
+-- used by the conflict checker to load a caregiver's visits for a day
+CREATE INDEX idx_schedules_user_time_range ON schedules(user_id, start_time, end_time);
+
+-- seed data inserts explicit ids, so move the sequences past them before schedules can be created via API
+SELECT setval('users_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM users), false);
+SELECT setval('schedules_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM schedules), false);
+SELECT setval('tasks_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM tasks), false);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_schedules_client_user;
+DROP TABLE IF EXISTS service_skill_requirements CASCADE;
+DROP TABLE IF EXISTS user_skills CASCADE;
+
+DELETE FROM schedules WHERE user_id IS NULL;
+ALTER TABLE schedules ALTER COLUMN user_id SET NOT NULL;
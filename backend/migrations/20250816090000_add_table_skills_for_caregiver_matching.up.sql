@@ -0,0 +1,30 @@
+-- visits can be created before a caregiver is matched to them
+ALTER TABLE schedules ALTER COLUMN user_id DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS user_skills (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    skill VARCHAR(100) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_skills UNIQUE (user_id, skill)
+);
+
+CREATE INDEX idx_user_skills_user_id ON user_skills(user_id);
+
+CREATE TABLE IF NOT EXISTS service_skill_requirements (
+    id SERIAL PRIMARY KEY,
+    service_name VARCHAR(100) NOT NULL,
+    skill VARCHAR(100) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_service_skill_requirements UNIQUE (service_name, skill)
+);
+
+CREATE INDEX idx_service_skill_requirements_service_name ON service_skill_requirements(service_name);
+
+-- used to score continuity of care
+CREATE INDEX idx_schedules_client_user ON schedules(client_name, user_id) WHERE status = 'completed';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS service_credential_requirements CASCADE;
+DROP TABLE IF EXISTS user_credentials CASCADE;
+DROP TABLE IF EXISTS credential_types CASCADE;
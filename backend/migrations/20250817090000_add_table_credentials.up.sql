@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS credential_types (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_credentials (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    credential_type_id INTEGER NOT NULL,
+    credential_number VARCHAR(100),
+    issued_at DATE NOT NULL,
+    expires_at DATE,
+    document_ref TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (credential_type_id) REFERENCES credential_types(id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_credentials_dates CHECK (expires_at IS NULL OR expires_at >= issued_at)
+);
+
+CREATE INDEX idx_user_credentials_user_id ON user_credentials(user_id);
+CREATE INDEX idx_user_credentials_expires_at ON user_credentials(expires_at);
+
+CREATE TABLE IF NOT EXISTS service_credential_requirements (
+    id SERIAL PRIMARY KEY,
+    service_name VARCHAR(100) NOT NULL,
+    credential_type_id INTEGER NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (credential_type_id) REFERENCES credential_types(id) ON DELETE CASCADE,
+    CONSTRAINT uq_service_credential_requirements UNIQUE (service_name, credential_type_id)
+);
+
+CREATE INDEX idx_service_credential_requirements_service_name ON service_credential_requirements(service_name);
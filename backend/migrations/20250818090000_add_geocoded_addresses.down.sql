@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS geocode_cache CASCADE;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS clock_out_address;
+ALTER TABLE schedules DROP COLUMN IF EXISTS clock_in_address;
@@ -0,0 +1,15 @@
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS clock_in_address TEXT;
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS clock_out_address TEXT;
+
+-- reverse geocoding results keyed by coordinates rounded to the configured precision
+CREATE TABLE IF NOT EXISTS geocode_cache (
+    latitude_key DECIMAL(10,6) NOT NULL,
+    longitude_key DECIMAL(11,6) NOT NULL,
+    address TEXT NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (latitude_key, longitude_key)
+);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS visit_attestations (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER UNIQUE NOT NULL,
+
+    -- client or family member attesting the visit happened
+    signer_name VARCHAR(100),
+    signer_relationship VARCHAR(20),
+    signature_format VARCHAR(10),
+    signature_data TEXT,
+    unable_to_sign_reason TEXT,
+
+    -- optional caregiver counter-signature
+    caregiver_signature_format VARCHAR(10),
+    caregiver_signature_data TEXT,
+
+    captured_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_signature_format CHECK (signature_format IS NULL OR signature_format IN ('strokes', 'png')),
+    CONSTRAINT chk_caregiver_signature_format CHECK (caregiver_signature_format IS NULL OR caregiver_signature_format IN ('strokes', 'png')),
+    CONSTRAINT chk_attested CHECK (signature_data IS NOT NULL OR unable_to_sign_reason IS NOT NULL)
+);
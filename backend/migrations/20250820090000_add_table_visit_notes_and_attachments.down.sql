@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS visit_attachments CASCADE;
+DROP TABLE IF EXISTS visit_notes CASCADE;
@@ -0,0 +1,34 @@
+-- timestamped progress notes left by the caregiver during or after a visit
+CREATE TABLE IF NOT EXISTS visit_notes (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_visit_notes_schedule ON visit_notes(schedule_id, created_at);
+
+-- files uploaded against a visit or one of its tasks; the bytes live in the configured storage backend
+CREATE TABLE IF NOT EXISTS visit_attachments (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL,
+    task_id INTEGER,
+    user_id INTEGER NOT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT chk_size_bytes CHECK (size_bytes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_visit_attachments_schedule ON visit_attachments(schedule_id, created_at);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_schedule_order;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_task_type;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS value;
+ALTER TABLE tasks DROP COLUMN IF EXISTS options;
+ALTER TABLE tasks DROP COLUMN IF EXISTS task_type;
+ALTER TABLE tasks DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE tasks DROP COLUMN IF EXISTS is_required;
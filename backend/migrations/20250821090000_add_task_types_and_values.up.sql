@@ -0,0 +1,21 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS is_required BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS task_type VARCHAR(30) NOT NULL DEFAULT 'general';
+
+-- per-type settings such as checklist items or the prescribed medication, and the value captured by the caregiver
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS options JSONB;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS value JSONB;
+
+ALTER TABLE tasks ADD CONSTRAINT chk_task_type
+    CHECK (task_type IN ('general', 'blood_pressure', 'temperature', 'medication', 'checklist'));
+
+-- keep the existing order of tasks within each visit
+UPDATE tasks t
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY schedule_id ORDER BY id) AS position
+    FROM tasks
+) ordered
+WHERE ordered.id = t.id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_schedule_order ON tasks(schedule_id, sort_order);
@@ -0,0 +1,23 @@
+-- audit trail of every task outcome change, including corrections after the first update
+CREATE TABLE IF NOT EXISTS task_status_changes (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL,
+    schedule_id INTEGER NOT NULL,
+    changed_by INTEGER NOT NULL,
+    approved_by INTEGER,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    from_reason TEXT,
+    to_reason TEXT,
+    from_value JSONB,
+    to_value JSONB,
+    change_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users(id),
+    FOREIGN KEY (approved_by) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_status_changes_task ON task_status_changes(task_id, created_at);
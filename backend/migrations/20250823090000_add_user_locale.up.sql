@@ -0,0 +1,2 @@
+-- language the caregiver chose in the app; NULL falls back to the Accept-Language header
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(5) CHECK (locale IN ('en', 'es', 'tl'));
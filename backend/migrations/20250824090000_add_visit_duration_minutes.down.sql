@@ -0,0 +1,3 @@
+ALTER TABLE schedules DROP COLUMN IF EXISTS variance_minutes;
+ALTER TABLE schedules DROP COLUMN IF EXISTS scheduled_minutes;
+ALTER TABLE schedules DROP COLUMN IF EXISTS actual_minutes;
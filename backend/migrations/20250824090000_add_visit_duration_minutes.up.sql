@@ -0,0 +1,13 @@
+-- actual and scheduled visit length in whole minutes, written at clock-out for duration reporting
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS actual_minutes INTEGER;
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS scheduled_minutes INTEGER;
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS variance_minutes INTEGER;
+
+UPDATE schedules
+SET actual_minutes = ROUND(EXTRACT(EPOCH FROM (clock_out_time - clock_in_time)) / 60),
+    scheduled_minutes = ROUND(EXTRACT(EPOCH FROM (end_time - start_time)) / 60)
+WHERE clock_in_time IS NOT NULL AND clock_out_time IS NOT NULL;
+
+UPDATE schedules
+SET variance_minutes = actual_minutes - scheduled_minutes
+WHERE actual_minutes IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE schedules DROP COLUMN IF EXISTS early_departure_note;
+ALTER TABLE schedules DROP COLUMN IF EXISTS early_departure_reason;
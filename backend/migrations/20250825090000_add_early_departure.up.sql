@@ -0,0 +1,5 @@
+-- why a caregiver left before the scheduled end; such visits are flagged EARLY_DEPARTURE for supervisor review
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS early_departure_reason VARCHAR(30) CHECK (early_departure_reason IN (
+    'client_request', 'client_unavailable', 'client_hospitalized', 'unsafe_environment', 'caregiver_emergency', 'other'
+));
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS early_departure_note TEXT;
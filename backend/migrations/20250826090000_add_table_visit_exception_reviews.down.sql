@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS visit_review_status;
+DROP TABLE IF EXISTS visit_exception_reviews;
@@ -0,0 +1,36 @@
+-- supervisor decisions on compliance flags; a flag without a row here is still open
+CREATE TABLE IF NOT EXISTS visit_exception_reviews (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL,
+    flag VARCHAR(40) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reviewed_by INTEGER NOT NULL,
+    note TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id),
+    CONSTRAINT uq_visit_exception_reviews UNIQUE (schedule_id, flag),
+    CONSTRAINT chk_visit_exception_review_status CHECK (status IN ('acknowledged', 'approved', 'rejected'))
+);
+
+-- one review status per visit for payroll and aggregator exports:
+-- clear (no flags), pending (a flag is open or only acknowledged), rejected (any flag rejected) or approved
+CREATE OR REPLACE VIEW visit_review_status AS
+SELECT s.id AS schedule_id,
+    CASE
+        WHEN COALESCE(cardinality(s.compliance_flags), 0) = 0 THEN 'clear'
+        WHEN EXISTS (
+            SELECT 1 FROM unnest(s.compliance_flags) AS f(flag)
+            LEFT JOIN visit_exception_reviews r ON r.schedule_id = s.id AND r.flag = f.flag
+            WHERE r.status IS NULL OR r.status = 'acknowledged'
+        ) THEN 'pending'
+        WHEN EXISTS (
+            SELECT 1 FROM visit_exception_reviews r
+            WHERE r.schedule_id = s.id AND r.status = 'rejected' AND r.flag = ANY(s.compliance_flags)
+        ) THEN 'rejected'
+        ELSE 'approved'
+    END AS review_status
+FROM schedules s;
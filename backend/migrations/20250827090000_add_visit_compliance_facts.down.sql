@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS visit_compliance_facts;
+DROP INDEX IF EXISTS idx_schedules_start_time;
@@ -0,0 +1,25 @@
+CREATE INDEX IF NOT EXISTS idx_schedules_start_time ON schedules(start_time);
+
+-- one row per visit with the facts compliance reports aggregate over; reports filter on start_time and GROUP BY
+CREATE OR REPLACE VIEW visit_compliance_facts AS
+SELECT s.id AS schedule_id,
+    s.user_id,
+    COALESCE(u.name, '') AS caregiver_name,
+    s.client_name,
+    s.service_name,
+    s.start_time,
+    s.clock_in_time IS NOT NULL AS clocked_in,
+    COALESCE(s.compliance_flags && ARRAY['TIME_WARNING', 'TIME_ERROR']::TEXT[], FALSE) AS late_clock_in,
+    COALESCE(s.compliance_flags && ARRAY['LOCATION_WARNING', 'LOCATION_ERROR']::TEXT[], FALSE) AS location_exception,
+    (s.status = 'cancelled' OR (s.clock_in_time IS NULL AND s.end_time < CURRENT_TIMESTAMP)) AS missed,
+    t.tasks_total,
+    t.tasks_completed,
+    s.actual_minutes,
+    s.scheduled_minutes,
+    s.variance_minutes
+FROM schedules s
+LEFT JOIN users u ON u.id = s.user_id
+LEFT JOIN LATERAL (
+    SELECT COUNT(*) AS tasks_total, COUNT(*) FILTER (WHERE status = 'completed') AS tasks_completed
+    FROM tasks WHERE tasks.schedule_id = s.id
+) t ON TRUE;
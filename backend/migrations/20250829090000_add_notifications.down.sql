@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_schedules_end_time;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,42 @@
+-- users without a row get the defaults: push and email on, sms off, no quiet hours
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY,
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sms_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    push_token TEXT,
+    quiet_hours_start TIME,
+    quiet_hours_end TIME,
+    timezone VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_quiet_hours CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+-- one row per message to send: a kind of notification about a visit, to a caregiver, over a channel
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    schedule_id INTEGER NOT NULL,
+    kind VARCHAR(30) NOT NULL,
+    channel VARCHAR(10) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    send_after TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    UNIQUE (schedule_id, user_id, kind, channel),
+    CONSTRAINT chk_notification_status CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+    CONSTRAINT chk_notification_channel CHECK (channel IN ('push', 'sms', 'email'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(send_after) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_schedules_end_time ON schedules(end_time);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS visit_alerts;
+DROP TABLE IF EXISTS client_risk_levels;
@@ -0,0 +1,30 @@
+-- clients without a row use the configured default risk level
+CREATE TABLE IF NOT EXISTS client_risk_levels (
+    client_name VARCHAR(255) PRIMARY KEY,
+    risk_level VARCHAR(30) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- every escalation step raised for a visit nobody clocked into, who it went to and who acknowledged it
+CREATE TABLE IF NOT EXISTS visit_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL,
+    step INTEGER NOT NULL,
+    contact VARCHAR(20) NOT NULL,
+    recipient_id INTEGER NOT NULL,
+    risk_level VARCHAR(30) NOT NULL,
+    minutes_late INTEGER NOT NULL,
+    delivered_channels TEXT[] NOT NULL DEFAULT '{}',
+    delivery_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_by INTEGER,
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    acknowledgement_note TEXT,
+
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (recipient_id) REFERENCES users(id),
+    FOREIGN KEY (acknowledged_by) REFERENCES users(id),
+    UNIQUE (schedule_id, step)
+);
+
+CREATE INDEX IF NOT EXISTS idx_visit_alerts_open ON visit_alerts(schedule_id) WHERE acknowledged_at IS NULL;
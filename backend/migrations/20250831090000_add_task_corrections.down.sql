@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_corrections;
+DELETE FROM users WHERE id = 2 AND role = 'supervisor';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_user_role;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,37 @@
+-- supervisors and admins approve what caregivers cannot do on their own, such as late task corrections
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'caregiver';
+ALTER TABLE users ADD CONSTRAINT chk_user_role CHECK (role IN ('caregiver', 'supervisor', 'admin'));
+
+-- a supervisor for development, until accounts are managed through the API
+INSERT INTO users (id, name, phone, email, role) VALUES
+    (2, 'Sam Supervisor', '+1-555-0199', 'sam.supervisor@bluehorntech.com', 'supervisor')
+ON CONFLICT DO NOTHING;
+SELECT setval('users_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM users), false);
+
+-- a change to a task's outcome requested after clock-out, applied only once a supervisor approves it
+CREATE TABLE IF NOT EXISTS task_corrections (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL,
+    schedule_id INTEGER NOT NULL,
+    requested_by INTEGER NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    to_reason TEXT,
+    to_value JSONB,
+    correction_reason TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    reviewed_by INTEGER,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (requested_by) REFERENCES users(id),
+    FOREIGN KEY (reviewed_by) REFERENCES users(id),
+    CONSTRAINT chk_task_correction_status CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+-- a task has at most one correction waiting for a supervisor
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_corrections_pending ON task_corrections(task_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_task_corrections_status ON task_corrections(status, created_at);
@@ -0,0 +1 @@
+DELETE FROM users WHERE id IN (3, 4) AND role IN ('admin', 'supervisor');
@@ -0,0 +1,6 @@
+-- coordinators for development, alerted about late visits by the escalation policies in config.yaml
+INSERT INTO users (id, name, phone, email, role) VALUES
+    (3, 'Alex Admin', '+1-555-0198', 'alex.admin@bluehorntech.com', 'admin'),
+    (4, 'Casey Coordinator', '+1-555-0197', 'casey.coordinator@bluehorntech.com', 'supervisor')
+ON CONFLICT DO NOTHING;
+SELECT setval('users_id_seq', (SELECT COALESCE(MAX(id), 0) + 1 FROM users), false);
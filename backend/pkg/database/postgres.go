@@ -4,7 +4,8 @@ import (
 	"github.com/erizkiatama/bluehorntech/config"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
-	"log"
+	"log/slog"
+	"os"
 )
 
 func New(cfg config.DatabaseConfig) *sqlx.DB {
@@ -14,7 +15,8 @@ func New(cfg config.DatabaseConfig) *sqlx.DB {
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping database: %s", err.Error())
+		slog.Error("Failed to ping database", "error", err)
+		os.Exit(1)
 	}
 
 	return db
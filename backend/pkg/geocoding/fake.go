@@ -0,0 +1,21 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+)
+
+type fake struct{}
+
+// NewFake returns a provider that answers locally without any network access, for development and tests
+func NewFake() Provider {
+	return &fake{}
+}
+
+func (f *fake) Name() string {
+	return ProviderFake
+}
+
+func (f *fake) ReverseGeocode(_ context.Context, lat, lng float64) (string, error) {
+	return fmt.Sprintf("Near %.4f, %.4f", lat, lng), nil
+}
@@ -0,0 +1,102 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+)
+
+const (
+	ProviderFake = "fake"
+	ProviderHTTP = "http"
+
+	defaultTimeout   = 3 * time.Second
+	defaultPrecision = 4
+)
+
+// Provider turns coordinates into a human-readable address
+type Provider interface {
+	Name() string
+	ReverseGeocode(ctx context.Context, lat, lng float64) (string, error)
+}
+
+// Cache stores resolved addresses keyed by rounded coordinates and the provider that resolved them
+type Cache interface {
+	Get(ctx context.Context, lat, lng float64, provider string) (string, bool, error)
+	Set(ctx context.Context, lat, lng float64, address, provider string) error
+}
+
+// Resolver resolves coordinates to an address and never fails;
+// when the provider errors or times out it falls back to the formatted coordinates.
+type Resolver interface {
+	Resolve(ctx context.Context, lat, lng float64) string
+}
+
+type resolver struct {
+	provider  Provider
+	cache     Cache
+	timeout   time.Duration
+	precision int
+}
+
+// NewProvider returns the provider selected in config
+func NewProvider(cfg config.GeocodingConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", ProviderFake:
+		return NewFake(), nil
+	case ProviderHTTP:
+		return NewHTTP(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown geocoding provider %q", cfg.Provider)
+	}
+}
+
+func NewResolver(cfg config.GeocodingConfig, provider Provider, cache Cache) Resolver {
+	timeout := cfg.TimeoutSeconds * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	precision := cfg.CachePrecision
+	if precision <= 0 {
+		precision = defaultPrecision
+	}
+
+	return &resolver{provider: provider, cache: cache, timeout: timeout, precision: precision}
+}
+
+func (r *resolver) Resolve(ctx context.Context, lat, lng float64) string {
+	latKey, lngKey := Round(lat, r.precision), Round(lng, r.precision)
+
+	if address, ok, err := r.cache.Get(ctx, latKey, lngKey, r.provider.Name()); err != nil {
+		slog.WarnContext(ctx, "Geocode cache lookup failed", "latitude", latKey, "longitude", lngKey, "error", err)
+	} else if ok {
+		return address
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	address, err := r.provider.ReverseGeocode(lookupCtx, latKey, lngKey)
+	if err != nil || address == "" {
+		slog.WarnContext(ctx, "Reverse geocoding failed", "provider", r.provider.Name(), "latitude", latKey, "longitude", lngKey, "error", err)
+		return helpers.GetLocationDetail(lat, lng)
+	}
+
+	if err = r.cache.Set(ctx, latKey, lngKey, address, r.provider.Name()); err != nil {
+		slog.WarnContext(ctx, "Geocode cache store failed", "latitude", latKey, "longitude", lngKey, "error", err)
+	}
+
+	return address
+}
+
+// Round rounds a coordinate to the given number of decimal places
+func Round(v float64, precision int) float64 {
+	factor := math.Pow(10, float64(precision))
+	return math.Round(v*factor) / factor
+}
@@ -0,0 +1,247 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+type stubProvider struct {
+	address string
+	err     error
+	delay   time.Duration
+	calls   int
+}
+
+func (p *stubProvider) Name() string {
+	return "stub"
+}
+
+func (p *stubProvider) ReverseGeocode(ctx context.Context, lat, lng float64) (string, error) {
+	p.calls++
+	if p.delay > 0 {
+		select {
+		case <-time.After(p.delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return p.address, p.err
+}
+
+type stubCache struct {
+	entries map[string]string
+	getErr  error
+	setErr  error
+	sets    int
+}
+
+func newStubCache() *stubCache {
+	return &stubCache{entries: make(map[string]string)}
+}
+
+func cacheKey(lat, lng float64, provider string) string {
+	return fmt.Sprintf("%v,%v,%s", lat, lng, provider)
+}
+
+func (c *stubCache) Get(_ context.Context, lat, lng float64, provider string) (string, bool, error) {
+	if c.getErr != nil {
+		return "", false, c.getErr
+	}
+	address, ok := c.entries[cacheKey(lat, lng, provider)]
+	return address, ok, nil
+}
+
+func (c *stubCache) Set(_ context.Context, lat, lng float64, address, provider string) error {
+	c.sets++
+	if c.setErr != nil {
+		return c.setErr
+	}
+	c.entries[cacheKey(lat, lng, provider)] = address
+	return nil
+}
+
+func TestResolve(t *testing.T) {
+	const lat, lng = 1.352083, 103.819836
+	fallback := "1.35208, 103.81984"
+
+	tests := []struct {
+		name          string
+		provider      *stubProvider
+		cached        map[string]string
+		cacheGetErr   error
+		cacheSetErr   error
+		timeout       time.Duration
+		want          string
+		wantCalls     int
+		wantCacheSets int
+	}{
+		{
+			name:          "provider address is cached",
+			provider:      &stubProvider{address: "1 Orchard Road"},
+			want:          "1 Orchard Road",
+			wantCalls:     1,
+			wantCacheSets: 1,
+		},
+		{
+			name:      "cache hit skips the provider",
+			provider:  &stubProvider{address: "1 Orchard Road"},
+			cached:    map[string]string{cacheKey(1.3521, 103.8198, "stub"): "Cached Road"},
+			want:      "Cached Road",
+			wantCalls: 0,
+		},
+		{
+			name:          "address cached by another provider is resolved again",
+			provider:      &stubProvider{address: "1 Orchard Road"},
+			cached:        map[string]string{cacheKey(1.3521, 103.8198, ProviderFake): "Near 1.3521, 103.8198"},
+			want:          "1 Orchard Road",
+			wantCalls:     1,
+			wantCacheSets: 1,
+		},
+		{
+			name:      "provider error falls back to coordinates",
+			provider:  &stubProvider{err: errors.New("provider down")},
+			want:      fallback,
+			wantCalls: 1,
+		},
+		{
+			name:      "empty address falls back to coordinates",
+			provider:  &stubProvider{},
+			want:      fallback,
+			wantCalls: 1,
+		},
+		{
+			name:      "slow provider falls back after the timeout",
+			provider:  &stubProvider{address: "1 Orchard Road", delay: time.Second},
+			timeout:   10 * time.Millisecond,
+			want:      fallback,
+			wantCalls: 1,
+		},
+		{
+			name:          "cache lookup error still resolves",
+			provider:      &stubProvider{address: "1 Orchard Road"},
+			cacheGetErr:   errors.New("cache down"),
+			want:          "1 Orchard Road",
+			wantCalls:     1,
+			wantCacheSets: 1,
+		},
+		{
+			name:          "cache store error still returns the address",
+			provider:      &stubProvider{address: "1 Orchard Road"},
+			cacheSetErr:   errors.New("cache down"),
+			want:          "1 Orchard Road",
+			wantCalls:     1,
+			wantCacheSets: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newStubCache()
+			for k, v := range tt.cached {
+				cache.entries[k] = v
+			}
+			cache.getErr, cache.setErr = tt.cacheGetErr, tt.cacheSetErr
+
+			r := NewResolver(config.GeocodingConfig{}, tt.provider, cache).(*resolver)
+			if tt.timeout > 0 {
+				r.timeout = tt.timeout
+			}
+
+			if got := r.Resolve(context.Background(), lat, lng); got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+			if tt.provider.calls != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", tt.provider.calls, tt.wantCalls)
+			}
+			if cache.sets != tt.wantCacheSets {
+				t.Errorf("cache stored %d times, want %d", cache.sets, tt.wantCacheSets)
+			}
+		})
+	}
+}
+
+func TestResolveCachesByRoundedCoordinates(t *testing.T) {
+	provider := &stubProvider{address: "1 Orchard Road"}
+	r := NewResolver(config.GeocodingConfig{CachePrecision: 3}, provider, newStubCache())
+
+	// both round to 1.352, 103.820
+	first := r.Resolve(context.Background(), 1.35208, 103.81984)
+	second := r.Resolve(context.Background(), 1.35160, 103.82010)
+
+	if first != "1 Orchard Road" || second != "1 Orchard Road" {
+		t.Errorf("Resolve() = %q, %q, want the provider address twice", first, second)
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value     float64
+		precision int
+		want      float64
+	}{
+		{value: 1.352083, precision: 4, want: 1.3521},
+		{value: 103.819836, precision: 4, want: 103.8198},
+		{value: -6.20875, precision: 2, want: -6.21},
+		{value: 1.5, precision: 0, want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := Round(tt.value, tt.precision); got != tt.want {
+			t.Errorf("Round(%v, %d) = %v, want %v", tt.value, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPProviderErrorsOmitCoordinates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lat") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"display_name": "1 Orchard Road"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "address", url: server.URL + "/reverse?lat={lat}&lon={lng}", want: "1 Orchard Road"},
+		{name: "unreachable", url: "http://127.0.0.1:1/reverse?lat={lat}&lon={lng}&key={key}", wantErr: true},
+		{name: "invalid url", url: "http://[::1/reverse?lat={lat}&lon={lng}&key={key}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewHTTP(config.GeocodingConfig{URL: tt.url, APIKey: "secret-key"})
+
+			got, err := p.ReverseGeocode(context.Background(), 1.3521, 103.8198)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReverseGeocode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReverseGeocode() = %q, want %q", got, tt.want)
+			}
+			if err != nil {
+				for _, secret := range []string{"1.3521", "103.8198", "secret-key"} {
+					if strings.Contains(err.Error(), secret) {
+						t.Errorf("error %q contains %q", err, secret)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+const defaultAddressField = "display_name"
+
+type httpProvider struct {
+	client       *http.Client
+	url          string
+	apiKey       string
+	addressField string
+	userAgent    string
+}
+
+// NewHTTP calls a reverse geocoding API. The configured URL may contain {lat}, {lng} and {key} placeholders,
+// and the address is read from a top-level string field of the JSON response.
+func NewHTTP(cfg config.GeocodingConfig) Provider {
+	addressField := cfg.AddressField
+	if addressField == "" {
+		addressField = defaultAddressField
+	}
+
+	return &httpProvider{
+		client:       &http.Client{},
+		url:          cfg.URL,
+		apiKey:       cfg.APIKey,
+		addressField: addressField,
+		userAgent:    cfg.UserAgent,
+	}
+}
+
+func (p *httpProvider) Name() string {
+	return ProviderHTTP
+}
+
+func (p *httpProvider) ReverseGeocode(ctx context.Context, lat, lng float64) (string, error) {
+	endpoint := strings.NewReplacer(
+		"{lat}", strconv.FormatFloat(lat, 'f', -1, 64),
+		"{lng}", strconv.FormatFloat(lng, 'f', -1, 64),
+		"{key}", url.QueryEscape(p.apiKey),
+	).Replace(p.url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build geocoding request: %w", withoutURL(err))
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call geocoding provider: %w", withoutURL(err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("geocoding provider returned status %d", resp.StatusCode)
+	}
+
+	var body map[string]any
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+
+	address, _ := body[p.addressField].(string)
+	if address == "" {
+		return "", fmt.Errorf("geocoding response has no %q field", p.addressField)
+	}
+
+	return address, nil
+}
+
+// withoutURL drops the request URL from err, since it holds the visit's coordinates and the API key
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
@@ -6,9 +6,9 @@ import (
 	"time"
 )
 
-// TODO: Implement this properly using map API later
+// GetLocationDetail formats coordinates for display when no address could be resolved
 func GetLocationDetail(lat, long float64) string {
-	return fmt.Sprintf("Location details: %.2f - %.2f", lat, long)
+	return fmt.Sprintf("%.5f, %.5f", lat, long)
 }
 
 func FormatShiftDate(start time.Time) string {
@@ -42,3 +42,25 @@ func FormatDuration(duration time.Duration) string {
 	}
 	return fmt.Sprintf("%dm", minutes)
 }
+
+// EstimateTravelTime estimates how long it takes to cover the given distance in meters at an average speed
+func EstimateTravelTime(distanceMeters, speedKmh float64) time.Duration {
+	if speedKmh <= 0 {
+		return 0
+	}
+
+	hours := (distanceMeters / 1000) / speedKmh
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// DayBounds returns the start and end of the day containing t in the given timezone, falling back to UTC
+func DayBounds(t time.Time, tz string) (time.Time, time.Time) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return start, start.Add(24 * time.Hour)
+}
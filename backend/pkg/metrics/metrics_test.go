@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEscapeLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "/api/v1/schedules/:id", want: "/api/v1/schedules/:id"},
+		{name: "backslash", value: `C:\path`, want: `C:\\path`},
+		{name: "quote", value: `say "hi"`, want: `say \"hi\"`},
+		{name: "newline", value: "a\nb", want: `a\nb`},
+		{name: "all", value: "\\\"\n", want: `\\\"\n`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeLabel(tt.value); got != tt.want {
+				t.Errorf("escapeLabel(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeHelp(t *testing.T) {
+	// quotes are only escaped in label values
+	if got, want := escapeHelp("a \"b\" \\ c\nd"), `a "b" \\ c\nd`; got != want {
+		t.Errorf("escapeHelp() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{value: 0, want: "0"},
+		{value: 3, want: "3"},
+		{value: 0.005, want: "0.005"},
+		{value: 2.5, want: "2.5"},
+		{value: math.Inf(1), want: "+Inf"},
+		{value: math.Inf(-1), want: "-Inf"},
+		{value: math.NaN(), want: "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatFloat(tt.value); got != tt.want {
+				t.Errorf("formatFloat(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(r *Registry)
+		want  string
+	}{
+		{
+			name: "unlabelled counter starts at zero",
+			setup: func(r *Registry) {
+				r.NewCounter("visits_total", "Visits.")
+			},
+			want: "# HELP visits_total Visits.\n" +
+				"# TYPE visits_total counter\n" +
+				"visits_total 0\n",
+		},
+		{
+			name: "labelled counter is sorted and escaped",
+			setup: func(r *Registry) {
+				c := r.NewCounter("rejected_total", "Rejected,\nby code.", "error")
+				c.Inc("TOO_FAR")
+				c.Add(2, `say "no"`)
+				c.Add(-5, "TOO_FAR")
+			},
+			want: "# HELP rejected_total Rejected,\\nby code.\n" +
+				"# TYPE rejected_total counter\n" +
+				"rejected_total{error=\"TOO_FAR\"} 1\n" +
+				"rejected_total{error=\"say \\\"no\\\"\"} 2\n",
+		},
+		{
+			name: "histogram buckets are cumulative and end with +Inf",
+			setup: func(r *Registry) {
+				h := r.NewHistogram("latency_seconds", "Latency.", []float64{1, 0.1}, "route")
+				h.Observe(0.05, "/a")
+				h.Observe(0.5, "/a")
+				h.Observe(3, "/a")
+			},
+			want: "# HELP latency_seconds Latency.\n" +
+				"# TYPE latency_seconds histogram\n" +
+				"latency_seconds_bucket{route=\"/a\",le=\"0.1\"} 1\n" +
+				"latency_seconds_bucket{route=\"/a\",le=\"1\"} 2\n" +
+				"latency_seconds_bucket{route=\"/a\",le=\"+Inf\"} 3\n" +
+				"latency_seconds_sum{route=\"/a\"} 3.55\n" +
+				"latency_seconds_count{route=\"/a\"} 3\n",
+		},
+		{
+			name: "unlabelled histogram only has le",
+			setup: func(r *Registry) {
+				r.NewHistogram("size", "Size.", []float64{10}).Observe(10)
+			},
+			want: "# HELP size Size.\n" +
+				"# TYPE size histogram\n" +
+				"size_bucket{le=\"10\"} 1\n" +
+				"size_bucket{le=\"+Inf\"} 1\n" +
+				"size_sum 10\n" +
+				"size_count 1\n",
+		},
+		{
+			name: "functions are read on write, metrics sorted by name",
+			setup: func(r *Registry) {
+				r.NewGaugeFunc("b_open", "Open.", func() float64 { return 4 })
+				r.NewCounterFunc("a_waits_total", "Waits.", func() float64 { return 7 })
+			},
+			want: "# HELP a_waits_total Waits.\n" +
+				"# TYPE a_waits_total counter\n" +
+				"a_waits_total 7\n" +
+				"# HELP b_open Open.\n" +
+				"# TYPE b_open gauge\n" +
+				"b_open 4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			tt.setup(r)
+
+			var sb strings.Builder
+			n, err := r.WriteTo(&sb)
+			if err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("WriteTo() wrote\n%s\nwant\n%s", got, tt.want)
+			}
+			if n != int64(sb.Len()) {
+				t.Errorf("WriteTo() = %d, wrote %d bytes", n, sb.Len())
+			}
+		})
+	}
+}
+
+func TestCounterValue(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("outcomes_total", "Outcomes.", "status")
+	c.Inc("completed")
+	c.Inc("completed")
+
+	if got := c.Value("completed"); got != 2 {
+		t.Errorf("Value(completed) = %v, want 2", got)
+	}
+	if got := c.Value("not completed"); got != 0 {
+		t.Errorf("Value(not completed) = %v, want 0", got)
+	}
+}
+
+func TestWrongLabelCountPanics(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("outcomes_total", "Outcomes.", "status")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc() without the status label did not panic")
+		}
+	}()
+	c.Inc()
+}
+
+func TestDuplicateRegistrationPanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("visits_total", "Visits.")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering visits_total twice did not panic")
+		}
+	}()
+	r.NewCounter("visits_total", "Visits.")
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("visits_total", "Visits.").Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "visits_total 1\n") {
+		t.Errorf("body does not contain the counter:\n%s", body)
+	}
+}
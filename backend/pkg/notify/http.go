@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+const maxErrorBody = 512
+
+type httpPush struct {
+	client    *http.Client
+	url       string
+	serverKey string
+}
+
+// NewHTTPPush sends push notifications through an FCM-style HTTP API:
+// a JSON {"to", "notification": {"title", "body"}, "data"} POST authorized with "key=<server key>"
+func NewHTTPPush(cfg config.PushConfig) Channel {
+	return &httpPush{
+		client:    &http.Client{Timeout: timeout(cfg.TimeoutSeconds)},
+		url:       cfg.URL,
+		serverKey: cfg.ServerKey,
+	}
+}
+
+func (p *httpPush) Name() string {
+	return ChannelPush
+}
+
+func (p *httpPush) Send(ctx context.Context, msg Message) error {
+	body := map[string]interface{}{
+		"to": msg.To,
+		"notification": map[string]string{
+			"title": msg.Title,
+			"body":  msg.Body,
+		},
+		"data": msg.Data,
+	}
+
+	return postJSON(ctx, p.client, p.url, "key="+p.serverKey, body)
+}
+
+type httpSMS struct {
+	client *http.Client
+	url    string
+	apiKey string
+	from   string
+}
+
+// NewHTTPSMS sends text messages through an SMS gateway taking a JSON {"from", "to", "body"} POST
+// authorized with a bearer API key
+func NewHTTPSMS(cfg config.SMSConfig) Channel {
+	return &httpSMS{
+		client: &http.Client{Timeout: timeout(cfg.TimeoutSeconds)},
+		url:    cfg.URL,
+		apiKey: cfg.APIKey,
+		from:   cfg.From,
+	}
+}
+
+func (s *httpSMS) Name() string {
+	return ChannelSMS
+}
+
+func (s *httpSMS) Send(ctx context.Context, msg Message) error {
+	body := map[string]string{
+		"from": s.from,
+		"to":   msg.To,
+		"body": msg.Title + ": " + msg.Body,
+	}
+
+	return postJSON(ctx, s.client, s.url, "Bearer "+s.apiKey, body)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url, authorization string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("notification provider responded %d: %s", resp.StatusCode, snippet)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+)
+
+type logChannel struct {
+	name string
+}
+
+// NewLog returns a channel that only logs its messages, for local development
+func NewLog(name string) Channel {
+	return &logChannel{name: name}
+}
+
+func (c *logChannel) Name() string {
+	return c.name
+}
+
+func (c *logChannel) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "Notification sent", "channel", c.name, "to", msg.To, "title", msg.Title, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+// Channel names, matching the channels stored with queued notifications
+const (
+	ChannelPush  = "push"
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+const (
+	ProviderLog  = "log"
+	ProviderHTTP = "http"
+	ProviderSMTP = "smtp"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Message is one notification to one recipient; To is a push token, phone number or email address
+type Message struct {
+	To    string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Channel delivers messages over one medium
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewChannels returns the push, sms and email channels selected in config, keyed by channel name
+func NewChannels(cfg config.NotificationConfig) (map[string]Channel, error) {
+	push, err := NewPush(cfg.Push)
+	if err != nil {
+		return nil, err
+	}
+	sms, err := NewSMS(cfg.SMS)
+	if err != nil {
+		return nil, err
+	}
+	email, err := NewEmail(cfg.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]Channel{
+		push.Name():  push,
+		sms.Name():   sms,
+		email.Name(): email,
+	}, nil
+}
+
+func NewPush(cfg config.PushConfig) (Channel, error) {
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return NewLog(ChannelPush), nil
+	case ProviderHTTP:
+		return NewHTTPPush(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown push provider %q", cfg.Provider)
+	}
+}
+
+func NewSMS(cfg config.SMSConfig) (Channel, error) {
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return NewLog(ChannelSMS), nil
+	case ProviderHTTP:
+		return NewHTTPSMS(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %q", cfg.Provider)
+	}
+}
+
+func NewEmail(cfg config.EmailConfig) (Channel, error) {
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return NewLog(ChannelEmail), nil
+	case ProviderSMTP:
+		return NewSMTP(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+	}
+}
+
+func timeout(seconds time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultTimeout
+	}
+	return seconds * time.Second
+}
@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+const defaultSMTPPort = 587
+
+type smtpChannel struct {
+	addr    string
+	host    string
+	auth    smtp.Auth
+	from    string
+	timeout time.Duration
+}
+
+// NewSMTP sends email through an SMTP server, authenticating only when a username is configured
+// (a local catcher such as MailHog needs none)
+func NewSMTP(cfg config.EmailConfig) Channel {
+	port := cfg.Port
+	if port <= 0 {
+		port = defaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &smtpChannel{
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		host:    cfg.Host,
+		auth:    auth,
+		from:    cfg.From,
+		timeout: timeout(cfg.TimeoutSeconds),
+	}
+}
+
+func (c *smtpChannel) Name() string {
+	return ChannelEmail
+}
+
+// Send talks SMTP over its own connection instead of smtp.SendMail, which has no timeout:
+// the whole conversation must finish within the timeout or the ctx deadline, and cancelling
+// ctx closes the connection
+func (c *smtpChannel) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") {
+		return fmt.Errorf("invalid email address %q", msg.To)
+	}
+
+	body := strings.Join([]string{
+		"From: " + c.from,
+		"To: " + msg.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Title),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		msg.Body,
+	}, "\r\n")
+
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	if err = c.send(conn, msg.To, []byte(body)); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("failed to send email: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// send runs the same steps as smtp.SendMail on an open connection
+func (c *smtpChannel) send(conn net.Conn, to string, body []byte) error {
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return err
+		}
+	}
+	if c.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+		if err = client.Auth(c.auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(c.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(body); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -1,35 +1,51 @@
 package response
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 
+	"github.com/erizkiatama/bluehorntech/internal/i18n"
 	"github.com/erizkiatama/bluehorntech/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
-// Success sends a successful response
+// Success sends a successful response, message is a key of the i18n catalogue
 func Success(c *gin.Context, message string, data interface{}) {
-	response := models.NewSuccessResponse(message, data)
+	locale := i18n.FromContext(c.Request.Context())
+	response := models.NewSuccessResponse(locale.T(message, nil), data)
 	c.JSON(http.StatusOK, response)
 }
 
-// Error sends an error response with logging
-func Error(c *gin.Context, statusCode int, message string, err error) {
-	// Log the error
-	log.Printf("API Error [%s %s]: %s - %v",
-		c.Request.Method,
-		c.Request.URL.Path,
-		message,
-		err)
+// FromError translates err through the error catalogue and sends it with the matching status, code and message.
+// Errors outside the catalogue are sent as a 500 with the fallback message.
+func FromError(c *gin.Context, fallback string, err error) {
+	locale := i18n.FromContext(c.Request.Context())
 
-	var detail string
-	if err != nil {
-		detail = err.Error()
+	def, ok := models.LookupError(err)
+	if !ok {
+		send(c, http.StatusInternalServerError, models.ErrorCodeInternal, locale.T(fallback, nil), err, nil)
+		return
 	}
 
-	response := models.NewErrorResponse(message, detail)
-	c.JSON(statusCode, response)
+	var data interface{}
+	var dataErr models.DataError
+	if errors.As(err, &dataErr) {
+		data = dataErr.ErrorData()
+	}
+	send(c, def.Status, def.Code, def.LocalizedMessage(locale, err), err, data)
+}
+
+// Error sends an error response with logging, message is a key of the i18n catalogue
+func Error(c *gin.Context, statusCode int, message string, err error) {
+	locale := i18n.FromContext(c.Request.Context())
+	send(c, statusCode, models.ErrorCodeForStatus(statusCode), locale.T(message, nil), err, nil)
+}
+
+// ErrorWithData sends an error response that also carries structured data, e.g. validation results
+func ErrorWithData(c *gin.Context, statusCode int, message string, err error, data interface{}) {
+	locale := i18n.FromContext(c.Request.Context())
+	send(c, statusCode, models.ErrorCodeForStatus(statusCode), locale.T(message, nil), err, data)
 }
 
 // InternalError sends a 500 internal server error
@@ -41,3 +57,28 @@ func InternalError(c *gin.Context, message string, err error) {
 func BadRequest(c *gin.Context, message string, err error) {
 	Error(c, http.StatusBadRequest, message, err)
 }
+
+func send(c *gin.Context, statusCode int, code, message string, err error, data interface{}) {
+	// server errors are logged as errors, client errors only as warnings
+	level := slog.LevelWarn
+	if statusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.Log(c.Request.Context(), level, "API error",
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"status", statusCode,
+		"code", code,
+		"message", message,
+		"error", err)
+
+	// the raw error may expose queries or internal addresses, only show it outside production
+	var detail string
+	if err != nil && gin.Mode() != gin.ReleaseMode {
+		detail = err.Error()
+	}
+
+	response := models.NewErrorResponse(code, message, detail)
+	response.Data = data
+	c.JSON(statusCode, response)
+}
@@ -0,0 +1,61 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+	"github.com/erizkiatama/bluehorntech/pkg/helpers"
+)
+
+const ProviderHaversine = "haversine"
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Estimate struct {
+	DistanceMeters float64
+	Duration       time.Duration
+}
+
+// Provider estimates the distance and travel time between two locations.
+// Implementations backed by a real routing engine can replace the default straight-line estimate.
+type Provider interface {
+	Name() string
+	Estimate(ctx context.Context, from, to Point) (Estimate, error)
+}
+
+// New returns the provider selected in config
+func New(cfg config.ServiceConfig) (Provider, error) {
+	switch cfg.RoutingProvider {
+	case "", ProviderHaversine:
+		return NewHaversine(cfg.AverageTravelSpeedKmh), nil
+	default:
+		return nil, fmt.Errorf("unknown routing provider %q", cfg.RoutingProvider)
+	}
+}
+
+type haversine struct {
+	speedKmh float64
+}
+
+// NewHaversine estimates straight-line distance and travel time at a constant average speed
+func NewHaversine(speedKmh float64) Provider {
+	return &haversine{speedKmh: speedKmh}
+}
+
+func (h *haversine) Name() string {
+	return ProviderHaversine
+}
+
+func (h *haversine) Estimate(_ context.Context, from, to Point) (Estimate, error) {
+	distance := helpers.CalculateDistance(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+
+	return Estimate{
+		DistanceMeters: distance,
+		Duration:       helpers.EstimateTravelTime(distance, h.speedKmh),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+// local keeps files on disk and serves them through the API's signed download route
+type local struct {
+	dir     string
+	baseURL string
+	secret  string
+}
+
+func NewLocal(cfg config.StorageConfig) (Store, error) {
+	if cfg.LocalDir == "" {
+		return nil, errors.New("storage localDir is required for the local provider")
+	}
+	if cfg.SigningKey == "" {
+		return nil, errors.New("storage signingKey is required for the local provider")
+	}
+
+	dir, err := filepath.Abs(cfg.LocalDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage directory: %w", err)
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &local{
+		dir:     dir,
+		baseURL: strings.TrimRight(cfg.PublicBaseURL, "/"),
+		secret:  cfg.SigningKey,
+	}, nil
+}
+
+func (l *local) Name() string {
+	return ProviderLocal
+}
+
+func (l *local) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	// write to a temporary file first so a failed upload never leaves a partial object behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+
+	return nil
+}
+
+func (l *local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	return f, nil
+}
+
+func (l *local) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+func (l *local) SignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	expires := time.Now().Add(expiry).Unix()
+
+	query := url.Values{}
+	query.Set("expires", fmt.Sprintf("%d", expires))
+	query.Set("signature", Sign(l.secret, key, expires))
+
+	return fmt.Sprintf("%s/%s?%s", l.baseURL, escapePath(key), query.Encode()), nil
+}
+
+// path maps a key to a file inside the storage directory, refusing keys that escape it
+func (l *local) path(key string) (string, error) {
+	path := filepath.Join(l.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, l.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return path, nil
+}
+
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
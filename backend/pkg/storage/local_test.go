@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+func newTestLocal(t *testing.T) *local {
+	t.Helper()
+
+	store, err := NewLocal(config.StorageConfig{
+		LocalDir:      t.TempDir(),
+		PublicBaseURL: "http://localhost:8080/api/v1/files/",
+		SigningKey:    "signing-secret",
+	})
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	return store.(*local)
+}
+
+func TestLocalPath(t *testing.T) {
+	l := newTestLocal(t)
+
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "nested key", key: "schedules/1/photo.jpg", want: filepath.Join(l.dir, "schedules", "1", "photo.jpg")},
+		{name: "dot segments inside the directory", key: "schedules/../notes/a.txt", want: filepath.Join(l.dir, "notes", "a.txt")},
+		{name: "parent directory", key: "../secret.txt", wantErr: true},
+		{name: "deep traversal", key: "schedules/../../../etc/passwd", wantErr: true},
+		{name: "absolute path stays inside", key: "/etc/passwd", want: filepath.Join(l.dir, "etc", "passwd")},
+		{name: "directory itself", key: "", wantErr: true},
+		{name: "dot", key: ".", wantErr: true},
+		{name: "sibling with the same prefix", key: "../" + filepath.Base(l.dir) + "-other/file", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.path(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("path(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("path(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalRefusesTraversal(t *testing.T) {
+	l := newTestLocal(t)
+	ctx := context.Background()
+
+	if err := l.Put(ctx, "../escaped.txt", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Error("Put() outside the storage directory succeeded")
+	}
+	if _, err := l.Get(ctx, "../escaped.txt"); err == nil {
+		t.Error("Get() outside the storage directory succeeded")
+	}
+	if err := l.Delete(ctx, "../escaped.txt"); err == nil {
+		t.Error("Delete() outside the storage directory succeeded")
+	}
+}
+
+func TestLocalPutGetDelete(t *testing.T) {
+	l := newTestLocal(t)
+	ctx := context.Background()
+	key := "schedules/1/photo.jpg"
+
+	if err := l.Put(ctx, key, strings.NewReader("image"), 5, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	rc, err := l.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	if string(body) != "image" {
+		t.Errorf("Get() = %q, want %q", body, "image")
+	}
+
+	if err = l.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err = l.Get(ctx, key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestLocalSignedURL(t *testing.T) {
+	l := newTestLocal(t)
+	key := "schedules/1/my photo.jpg"
+
+	signed, err := l.SignedURL(context.Background(), key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("SignedURL() = %q is not a URL: %v", signed, err)
+	}
+	if want := "/api/v1/files/schedules/1/my photo.jpg"; u.Path != want {
+		t.Errorf("SignedURL() path = %q, want %q", u.Path, want)
+	}
+
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("SignedURL() expires = %q: %v", u.Query().Get("expires"), err)
+	}
+	signature := u.Query().Get("signature")
+	if !Verify(l.secret, key, expires, signature, time.Now()) {
+		t.Error("SignedURL() signature does not verify")
+	}
+	if Verify(l.secret, key, expires, signature, time.Now().Add(2*time.Minute)) {
+		t.Error("SignedURL() signature still verifies after it expired")
+	}
+}
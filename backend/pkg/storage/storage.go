@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/erizkiatama/bluehorntech/config"
+)
+
+const (
+	ProviderLocal = "local"
+	ProviderS3    = "s3"
+
+	defaultURLExpiry = 15 * time.Minute
+	defaultTimeout   = 30 * time.Second
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// Store saves uploaded files under a key and hands out time-limited download URLs
+type Store interface {
+	Name() string
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// New returns the store selected in config
+func New(cfg config.StorageConfig) (Store, error) {
+	switch cfg.Provider {
+	case "", ProviderLocal:
+		return NewLocal(cfg)
+	case ProviderS3:
+		return NewS3(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage provider %q", cfg.Provider)
+	}
+}
+
+// URLExpiry returns the configured lifetime of signed download URLs
+func URLExpiry(cfg config.StorageConfig) time.Duration {
+	expiry := cfg.URLExpirySeconds * time.Second
+	if expiry <= 0 {
+		return defaultURLExpiry
+	}
+	return expiry
+}
+
+// Sign returns the signature of a download URL for key that is valid until expires (unix seconds)
+func Sign(secret, key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches key and expires and the URL has not expired yet
+func Verify(secret, key string, expires int64, signature string, now time.Time) bool {
+	if now.Unix() > expires {
+		return false
+	}
+	expected := Sign(secret, key, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const (
+		secret = "signing-secret"
+		key    = "schedules/1/photo.jpg"
+	)
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Minute).Unix()
+	signature := Sign(secret, key, expires)
+
+	// flip the last hex digit
+	last := byte('0')
+	if signature[len(signature)-1] == '0' {
+		last = '1'
+	}
+	tampered := signature[:len(signature)-1] + string(last)
+
+	tests := []struct {
+		name      string
+		secret    string
+		key       string
+		expires   int64
+		signature string
+		now       time.Time
+		want      bool
+	}{
+		{name: "valid", secret: secret, key: key, expires: expires, signature: signature, now: now, want: true},
+		{name: "valid until the expiry second", secret: secret, key: key, expires: expires, signature: signature, now: time.Unix(expires, 0), want: true},
+		{name: "expired", secret: secret, key: key, expires: expires, signature: signature, now: time.Unix(expires+1, 0), want: false},
+		{name: "tampered key", secret: secret, key: "schedules/2/photo.jpg", expires: expires, signature: signature, now: now, want: false},
+		{name: "extended expiry", secret: secret, key: key, expires: expires + 3600, signature: signature, now: now, want: false},
+		{name: "other secret", secret: "other-secret", key: key, expires: expires, signature: signature, now: now, want: false},
+		{name: "tampered signature", secret: secret, key: key, expires: expires, signature: tampered, now: now, want: false},
+		{name: "empty signature", secret: secret, key: key, expires: expires, signature: "", now: now, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.key, tt.expires, tt.signature, tt.now); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignIsDeterministic(t *testing.T) {
+	a := Sign("secret", "key", 100)
+	if b := Sign("secret", "key", 100); a != b {
+		t.Errorf("Sign() = %q then %q for the same input", a, b)
+	}
+	if len(a) != 64 {
+		t.Errorf("Sign() = %q, want a hex encoded SHA-256", a)
+	}
+}